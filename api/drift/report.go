@@ -0,0 +1,134 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ReportKey is the key of the report in the Secret data.
+	ReportKey = "report.json"
+
+	// TFDriftReportNameLabel is the label of the report Secrets, set to the
+	// name of their Terraform object.
+	TFDriftReportNameLabel = "infra.contrib.fluxcd.io/drift-report-name"
+
+	// SensitiveValue replaces the sensitive values in a report.
+	SensitiveValue = "(sensitive value)"
+
+	// UnknownValue replaces the values known after apply in a report.
+	UnknownValue = "(known after apply)"
+
+	// reportMaxSizeBytes leaves room in the Secret for its metadata.
+	reportMaxSizeBytes = 768 * 1024
+)
+
+// Report is the structured report of the drift found by a drift detection.
+type Report struct {
+	// Revision of the source the drift was detected at.
+	Revision string `json:"revision"`
+
+	// DetectedAt is the time when the drift was detected.
+	DetectedAt metav1.Time `json:"detectedAt"`
+
+	// Resources are the resources which drifted.
+	Resources []Resource `json:"resources,omitempty"`
+
+	// Outputs are the names of the outputs which drifted.
+	Outputs []string `json:"outputs,omitempty"`
+
+	// Truncated is true when the values of the attributes were left out to
+	// fit the report in its Secret.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Resource is a resource which drifted.
+type Resource struct {
+	// Address of the resource, like module.app.aws_instance.web[0].
+	Address string `json:"address"`
+
+	// Type of the resource, like aws_instance.
+	Type string `json:"type"`
+
+	// Actions of the plan remediating the drift, like update, or delete and
+	// create for a replacement.
+	Actions []string `json:"actions"`
+
+	// DriftAction is the action of the drift policy for the resource, if
+	// the object has a drift policy.
+	DriftAction string `json:"driftAction,omitempty"`
+
+	// Attributes are the attributes which drifted.
+	Attributes []Attribute `json:"attributes,omitempty"`
+}
+
+// Attribute is an attribute which drifted.
+type Attribute struct {
+	// Path of the attribute, like tags.env or ingress.0.cidr_blocks.
+	Path string `json:"path"`
+
+	// Before is the current value of the infrastructure.
+	Before interface{} `json:"before,omitempty"`
+
+	// After is the value of the configuration, set by the remediation.
+	After interface{} `json:"after,omitempty"`
+
+	// Sensitive is true when the values are masked, in part or in whole.
+	Sensitive bool `json:"sensitive,omitempty"`
+}
+
+// SecretName returns the name of the Secret holding the drift report of a
+// Terraform object. The report is kept in a Secret as the values of the
+// attributes may be sensitive without being marked so.
+func SecretName(name string, workspace string) string {
+	return "tfdrift-" + workspace + "-" + name
+}
+
+// Encode encodes the report in JSON. The values of the attributes are left
+// out when the report is too large for a Secret.
+func (r *Report) Encode() (string, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	if len(data) <= reportMaxSizeBytes {
+		return string(data), nil
+	}
+
+	truncated := *r
+	truncated.Truncated = true
+	truncated.Resources = make([]Resource, len(r.Resources))
+	for i, resource := range r.Resources {
+		truncated.Resources[i] = resource
+		truncated.Resources[i].Attributes = make([]Attribute, len(resource.Attributes))
+		for j, attribute := range resource.Attributes {
+			truncated.Resources[i].Attributes[j] = Attribute{Path: attribute.Path, Sensitive: attribute.Sensitive}
+		}
+	}
+
+	data, err = json.Marshal(truncated)
+	if err != nil {
+		return "", err
+	}
+	if len(data) > reportMaxSizeBytes {
+		return "", fmt.Errorf("drift report of %d resources is too large for a Secret", len(r.Resources))
+	}
+	return string(data), nil
+}
+
+// NewFromSecret decodes the report held by a Secret.
+func NewFromSecret(secret *v1.Secret) (*Report, error) {
+	data, ok := secret.Data[ReportKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s missing key %s", secret.Name, ReportKey)
+	}
+
+	report := &Report{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("unable to decode the drift report of secret %s: %w", secret.Name, err)
+	}
+	return report, nil
+}
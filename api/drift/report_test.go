@@ -0,0 +1,67 @@
+package drift
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestEncodeAndDecodeReport(t *testing.T) {
+	report := &Report{
+		Revision: "main@sha1:1234",
+		Resources: []Resource{{
+			Address: "aws_instance.web",
+			Type:    "aws_instance",
+			Actions: []string{"update"},
+			Attributes: []Attribute{
+				{Path: "tags.env", Before: "dev", After: "prod"},
+				{Path: "password", Before: SensitiveValue, After: SensitiveValue, Sensitive: true},
+			},
+		}},
+	}
+
+	data, err := report.Encode()
+	if err != nil {
+		t.Fatalf("unexpected error encoding the report: %v", err)
+	}
+
+	decoded, err := NewFromSecret(&v1.Secret{Data: map[string][]byte{ReportKey: []byte(data)}})
+	if err != nil {
+		t.Fatalf("unexpected error decoding the report: %v", err)
+	}
+	if decoded.Truncated {
+		t.Fatalf("expected a complete report")
+	}
+	if got := decoded.Resources[0].Attributes[0].After; got != "prod" {
+		t.Fatalf("expected the after value prod, got %v", got)
+	}
+}
+
+func TestEncodeTruncatesLargeReport(t *testing.T) {
+	report := &Report{Revision: "main@sha1:1234"}
+	for i := 0; i < 100; i++ {
+		report.Resources = append(report.Resources, Resource{
+			Address:    "aws_s3_object.large",
+			Type:       "aws_s3_object",
+			Actions:    []string{"update"},
+			Attributes: []Attribute{{Path: "content", Before: strings.Repeat("x", 10*1024), After: "y"}},
+		})
+	}
+
+	data, err := report.Encode()
+	if err != nil {
+		t.Fatalf("unexpected error encoding the report: %v", err)
+	}
+
+	decoded, err := NewFromSecret(&v1.Secret{Data: map[string][]byte{ReportKey: []byte(data)}})
+	if err != nil {
+		t.Fatalf("unexpected error decoding the report: %v", err)
+	}
+	if !decoded.Truncated {
+		t.Fatalf("expected a truncated report")
+	}
+	if attribute := decoded.Resources[99].Attributes[0]; attribute.Path != "content" || attribute.Before != nil {
+		t.Fatalf("expected the attribute paths without values, got %+v", attribute)
+	}
+}
@@ -0,0 +1,165 @@
+package graph
+
+import (
+	"sort"
+	"strings"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Graph is the dependency graph of Terraform objects. Its edges go from a
+// Terraform object to the objects it depends on, with .spec.dependsOn or
+// .spec.varsFrom.
+type Graph struct {
+	nodes      map[types.NamespacedName]*infrav1.Terraform
+	dependsOn  map[types.NamespacedName][]types.NamespacedName
+	dependants map[types.NamespacedName][]types.NamespacedName
+}
+
+// New builds the dependency graph of the Terraform objects. Dependencies
+// missing from the objects are nodes of the graph as well, without object.
+func New(terraforms []infrav1.Terraform) *Graph {
+	g := &Graph{
+		nodes:      map[types.NamespacedName]*infrav1.Terraform{},
+		dependsOn:  map[types.NamespacedName][]types.NamespacedName{},
+		dependants: map[types.NamespacedName][]types.NamespacedName{},
+	}
+
+	for i := range terraforms {
+		terraform := &terraforms[i]
+		g.nodes[types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}] = terraform
+	}
+
+	for i := range terraforms {
+		terraform := &terraforms[i]
+		node := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+		for _, d := range terraform.GetDependsOn() {
+			dependency := types.NamespacedName{Namespace: d.Namespace, Name: d.Name}
+			if dependency.Namespace == "" {
+				dependency.Namespace = terraform.Namespace
+			}
+			if _, ok := g.nodes[dependency]; !ok {
+				g.nodes[dependency] = nil
+			}
+			g.dependsOn[node] = append(g.dependsOn[node], dependency)
+			g.dependants[dependency] = append(g.dependants[dependency], node)
+		}
+	}
+
+	return g
+}
+
+// Nodes returns the nodes of the graph, sorted by namespace and name.
+func (g *Graph) Nodes() []types.NamespacedName {
+	nodes := make([]types.NamespacedName, 0, len(g.nodes))
+	for node := range g.nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].String() < nodes[j].String() })
+	return nodes
+}
+
+// Terraform returns the object of a node, nil if the object does not exist.
+func (g *Graph) Terraform(node types.NamespacedName) *infrav1.Terraform {
+	return g.nodes[node]
+}
+
+// DependsOn returns the dependencies of a node.
+func (g *Graph) DependsOn(node types.NamespacedName) []types.NamespacedName {
+	return g.dependsOn[node]
+}
+
+// Dependants returns the nodes depending on a node.
+func (g *Graph) Dependants(node types.NamespacedName) []types.NamespacedName {
+	return g.dependants[node]
+}
+
+// DependantsOf returns the nodes depending on a node, directly or not, in
+// reverse topological order: a node comes after all of its dependants. The
+// order of the nodes of a cycle is unspecified.
+func (g *Graph) DependantsOf(node types.NamespacedName) []types.NamespacedName {
+	var order []types.NamespacedName
+	visited := map[types.NamespacedName]bool{node: true}
+	var visit func(u types.NamespacedName)
+	visit = func(u types.NamespacedName) {
+		for _, v := range g.dependants[u] {
+			if visited[v] {
+				continue
+			}
+			visited[v] = true
+			visit(v)
+			order = append(order, v)
+		}
+	}
+	visit(node)
+	return order
+}
+
+// CycleOf returns the shortest dependency cycle going through a node, as a
+// path starting and ending with the node, or nil if the node is not part of
+// a cycle.
+func (g *Graph) CycleOf(node types.NamespacedName) []types.NamespacedName {
+	// Breadth-first search of the node from its dependencies.
+	parent := map[types.NamespacedName]types.NamespacedName{}
+	queue := []types.NamespacedName{node}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, v := range g.dependsOn[u] {
+			if v == node {
+				cycle := []types.NamespacedName{node}
+				for w := u; w != node; w = parent[w] {
+					cycle = append(cycle, w)
+				}
+				cycle = append(cycle, node)
+				// The path was built backwards, from the last dependency.
+				for i, j := 1, len(cycle)-2; i < j; i, j = i+1, j-1 {
+					cycle[i], cycle[j] = cycle[j], cycle[i]
+				}
+				return cycle
+			}
+			if _, seen := parent[v]; seen {
+				continue
+			}
+			parent[v] = u
+			queue = append(queue, v)
+		}
+	}
+	return nil
+}
+
+// InCycle reports whether the edge from a node to one of its dependencies is
+// part of a dependency cycle.
+func (g *Graph) InCycle(node, dependency types.NamespacedName) bool {
+	return g.reaches(dependency, node)
+}
+
+// reaches reports whether there is a path from a node to another.
+func (g *Graph) reaches(from, to types.NamespacedName) bool {
+	seen := map[types.NamespacedName]bool{from: true}
+	stack := []types.NamespacedName{from}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if u == to {
+			return true
+		}
+		for _, v := range g.dependsOn[u] {
+			if !seen[v] {
+				seen[v] = true
+				stack = append(stack, v)
+			}
+		}
+	}
+	return false
+}
+
+// FormatCycle formats a dependency cycle like a/b -> a/c -> a/b.
+func FormatCycle(cycle []types.NamespacedName) string {
+	nodes := make([]string, 0, len(cycle))
+	for _, node := range cycle {
+		nodes = append(nodes, node.String())
+	}
+	return strings.Join(nodes, " -> ")
+}
@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func terraform(namespace, name string, dependsOn ...string) infrav1.Terraform {
+	t := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	for _, d := range dependsOn {
+		t.Spec.DependsOn = append(t.Spec.DependsOn, meta.NamespacedObjectReference{Name: d})
+	}
+	return t
+}
+
+func TestCycleOf(t *testing.T) {
+	g := New([]infrav1.Terraform{
+		terraform("infra", "network"),
+		terraform("infra", "database", "network", "app"),
+		terraform("infra", "app", "cache"),
+		terraform("infra", "cache", "database"),
+		terraform("infra", "monitoring", "app"),
+		terraform("infra", "loop", "loop"),
+	})
+
+	node := func(name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: "infra", Name: name}
+	}
+
+	tests := []struct {
+		node string
+		want string
+	}{
+		{node: "network", want: ""},
+		{node: "monitoring", want: ""},
+		{node: "database", want: "infra/database -> infra/app -> infra/cache -> infra/database"},
+		{node: "cache", want: "infra/cache -> infra/database -> infra/app -> infra/cache"},
+		{node: "loop", want: "infra/loop -> infra/loop"},
+	}
+	for _, tt := range tests {
+		if got := FormatCycle(g.CycleOf(node(tt.node))); got != tt.want {
+			t.Errorf("CycleOf(%s) = %q, want %q", tt.node, got, tt.want)
+		}
+	}
+
+	if !g.InCycle(node("app"), node("cache")) {
+		t.Errorf("expected app -> cache to be in a cycle")
+	}
+	if g.InCycle(node("database"), node("network")) || g.InCycle(node("monitoring"), node("app")) {
+		t.Errorf("expected database -> network and monitoring -> app not to be in a cycle")
+	}
+}
+
+func TestNew(t *testing.T) {
+	g := New([]infrav1.Terraform{
+		terraform("infra", "app", "network"),
+	})
+
+	nodes := g.Nodes()
+	if len(nodes) != 2 || nodes[0].Name != "app" || nodes[1].Name != "network" {
+		t.Fatalf("unexpected nodes %v", nodes)
+	}
+	if g.Terraform(nodes[1]) != nil {
+		t.Errorf("expected the missing dependency to have no object")
+	}
+	if deps := g.DependsOn(nodes[0]); len(deps) != 1 || deps[0] != nodes[1] {
+		t.Errorf("unexpected dependencies %v", deps)
+	}
+}
+
+func TestDependantsOf(t *testing.T) {
+	g := New([]infrav1.Terraform{
+		terraform("infra", "network"),
+		terraform("infra", "app", "network"),
+		terraform("infra", "frontend", "app", "dns"),
+		terraform("infra", "dns", "network"),
+		terraform("infra", "unrelated"),
+	})
+
+	node := func(name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: "infra", Name: name}
+	}
+
+	if deps := g.Dependants(node("network")); len(deps) != 2 || deps[0] != node("app") || deps[1] != node("dns") {
+		t.Errorf("unexpected dependants %v", deps)
+	}
+
+	// frontend comes before both of its dependencies
+	if got := fmt.Sprint(g.DependantsOf(node("network"))); got != "[infra/frontend infra/app infra/dns]" {
+		t.Errorf("DependantsOf(network) = %q", got)
+	}
+	if deps := g.DependantsOf(node("unrelated")); len(deps) != 0 {
+		t.Errorf("unexpected dependants %v", deps)
+	}
+}
@@ -0,0 +1,198 @@
+package statebackup
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/flux-iac/tofu-controller/api/plan"
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Kubernetes Label names associated with Terraform state backups
+	TFStateBackupWorkspaceLabel = "infra.contrib.fluxcd.io/state-backup-workspace"
+
+	// Kubernetes Annotation names associated with Terraform state backups
+	TFStateBackupFullNameAnnotation      = "infra.contrib.fluxcd.io/state-backup-full-name"
+	TFStateBackupFullWorkspaceAnnotation = "infra.contrib.fluxcd.io/state-backup-full-workspace"
+	TFStateBackupRevisionAnnotation      = "infra.contrib.fluxcd.io/state-backup-revision"
+	TFStateBackupSerialAnnotation        = "infra.contrib.fluxcd.io/state-backup-serial"
+	TFStateBackupLineageAnnotation       = "infra.contrib.fluxcd.io/state-backup-lineage"
+
+	// TFStateName is the data key of the gzipped Terraform state
+	TFStateName = "tfstate"
+
+	// Reasons a Terraform state backup is taken for, stored as
+	// the value of the infrav1.StateBackupLabel label
+	ReasonApply     = "apply"
+	ReasonMigration = "migration"
+
+	// resourceDataMaxSizeBytes defines the maximum size of data
+	// that can be stored in a Kubernetes Secret
+	resourceDataMaxSizeBytes = 1 * 1024 * 1024 // 1MB
+)
+
+// Backup describes a Terraform state backup stored in a Kubernetes Secret.
+type Backup struct {
+	Name      string
+	Namespace string
+	Terraform string
+	Workspace string
+	Reason    string
+	Revision  string
+	Serial    int64
+	Lineage   string
+	CreatedAt metav1.Time
+}
+
+// stateMeta holds the fields of a Terraform state used to identify a backup.
+type stateMeta struct {
+	Serial  int64  `json:"serial"`
+	Lineage string `json:"lineage"`
+}
+
+// SecretName returns the name of the Secret holding the backup with the given ID.
+func SecretName(name, workspace, id string) string {
+	return fmt.Sprintf("tfstate-backup-%s-%s-%s", workspace, name, id)
+}
+
+// Serial returns the serial of the given Terraform state, which identifies
+// the backups taken before apply.
+func Serial(state []byte) (int64, error) {
+	var meta stateMeta
+	if err := json.Unmarshal(state, &meta); err != nil {
+		return 0, fmt.Errorf("unable to parse the Terraform state: %s", err)
+	}
+
+	return meta.Serial, nil
+}
+
+// ToSecret converts a Terraform state into a Kubernetes Secret. The Secret is not
+// owned by the Terraform object, so that the backup outlives it.
+func ToSecret(terraform *infrav1.Terraform, namespace, reason, id, revision string, state []byte) (*v1.Secret, error) {
+	var meta stateMeta
+	if err := json.Unmarshal(state, &meta); err != nil {
+		return nil, fmt.Errorf("unable to parse the Terraform state: %s", err)
+	}
+
+	encoded, err := plan.GzipEncode(state)
+	if err != nil {
+		return nil, fmt.Errorf("unable to gzip encode the state: %s", err)
+	}
+
+	if len(encoded) > resourceDataMaxSizeBytes {
+		return nil, fmt.Errorf("the encoded state is %d bytes, exceeding the maximum size of a Secret", len(encoded))
+	}
+
+	workspace := terraform.WorkspaceName()
+
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SecretName(terraform.Name, workspace, id),
+			Namespace: namespace,
+			Annotations: map[string]string{
+				"encoding":                           "gzip",
+				TFStateBackupFullNameAnnotation:      terraform.Name,
+				TFStateBackupFullWorkspaceAnnotation: workspace,
+				TFStateBackupRevisionAnnotation:      revision,
+				TFStateBackupSerialAnnotation:        strconv.FormatInt(meta.Serial, 10),
+				TFStateBackupLineageAnnotation:       meta.Lineage,
+			},
+			Labels: map[string]string{
+				infrav1.RunnerLabel:         plan.SafeLabelValue(terraform.Name),
+				infrav1.StateBackupLabel:    reason,
+				TFStateBackupWorkspaceLabel: plan.SafeLabelValue(workspace),
+			},
+		},
+		Type: v1.SecretTypeOpaque,
+		Data: map[string][]byte{TFStateName: encoded},
+	}, nil
+}
+
+// FromSecret describes the backup held by a Kubernetes Secret.
+func FromSecret(secret v1.Secret) (*Backup, error) {
+	if _, ok := secret.Data[TFStateName]; !ok {
+		return nil, fmt.Errorf("secret %s missing key %s", secret.Name, TFStateName)
+	}
+
+	reason, ok := secret.Labels[infrav1.StateBackupLabel]
+	if !ok {
+		return nil, fmt.Errorf("missing state backup label on secret %s", secret.Name)
+	}
+
+	var serial int64
+	if serialStr, ok := secret.Annotations[TFStateBackupSerialAnnotation]; ok && serialStr != "" {
+		var err error
+		serial, err = strconv.ParseInt(serialStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid serial annotation found on secret %s: %s", secret.Name, err)
+		}
+	}
+
+	return &Backup{
+		Name:      secret.Name,
+		Namespace: secret.Namespace,
+		Terraform: secret.Annotations[TFStateBackupFullNameAnnotation],
+		Workspace: secret.Annotations[TFStateBackupFullWorkspaceAnnotation],
+		Reason:    reason,
+		Revision:  secret.Annotations[TFStateBackupRevisionAnnotation],
+		Serial:    serial,
+		Lineage:   secret.Annotations[TFStateBackupLineageAnnotation],
+		CreatedAt: secret.CreationTimestamp,
+	}, nil
+}
+
+// State returns the decoded Terraform state held by a Kubernetes Secret.
+func State(secret v1.Secret) ([]byte, error) {
+	encoded, ok := secret.Data[TFStateName]
+	if !ok {
+		return nil, fmt.Errorf("secret %s missing key %s", secret.Name, TFStateName)
+	}
+
+	state, err := plan.GzipDecode(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode state backup %s: %s", secret.Name, err)
+	}
+
+	return state, nil
+}
+
+// MatchingLabels returns the labels selecting the backups of a Terraform object.
+func MatchingLabels(name, workspace string) map[string]string {
+	return map[string]string{
+		infrav1.RunnerLabel:         plan.SafeLabelValue(name),
+		TFStateBackupWorkspaceLabel: plan.SafeLabelValue(workspace),
+	}
+}
+
+// Sort orders the backups from the newest to the oldest.
+func Sort(backups []Backup) {
+	sort.SliceStable(backups, func(i, j int) bool {
+		if !backups[i].CreatedAt.Equal(&backups[j].CreatedAt) {
+			return backups[j].CreatedAt.Before(&backups[i].CreatedAt)
+		}
+		return backups[i].Serial > backups[j].Serial
+	})
+}
+
+// Expired returns the backups taken before apply exceeding the number of
+// backups to retain. Backups taken for other reasons never expire.
+func Expired(backups []Backup, retain int) []Backup {
+	sorted := make([]Backup, 0, len(backups))
+	for _, backup := range backups {
+		if backup.Reason == ReasonApply {
+			sorted = append(sorted, backup)
+		}
+	}
+	Sort(sorted)
+
+	if len(sorted) <= retain {
+		return nil
+	}
+
+	return sorted[retain:]
+}
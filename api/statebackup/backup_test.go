@@ -0,0 +1,99 @@
+package statebackup
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSecretRoundTrip(t *testing.T) {
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "flux-system",
+		},
+	}
+
+	state := []byte(`{"version":4,"serial":12,"lineage":"6a3c4c1e-6d3f-4b0b-8d2e-3b1f9b0a5c7d","resources":[]}`)
+
+	secret, err := ToSecret(terraform, "tf-backups", ReasonApply, "12", "main@sha1:1d2e3f4a", state)
+	if err != nil {
+		t.Fatalf("unexpected error converting to secret: %v", err)
+	}
+
+	if secret.Name != "tfstate-backup-default-my-stack-12" {
+		t.Fatalf("unexpected secret name: %s", secret.Name)
+	}
+
+	if secret.Namespace != "tf-backups" {
+		t.Fatalf("unexpected secret namespace: %s", secret.Namespace)
+	}
+
+	backup, err := FromSecret(*secret)
+	if err != nil {
+		t.Fatalf("unexpected error reading the backup: %v", err)
+	}
+
+	if backup.Terraform != "my-stack" || backup.Workspace != "default" || backup.Reason != ReasonApply {
+		t.Fatalf("unexpected backup: %+v", backup)
+	}
+
+	if backup.Serial != 12 || backup.Lineage != "6a3c4c1e-6d3f-4b0b-8d2e-3b1f9b0a5c7d" || backup.Revision != "main@sha1:1d2e3f4a" {
+		t.Fatalf("unexpected backup: %+v", backup)
+	}
+
+	decoded, err := State(*secret)
+	if err != nil {
+		t.Fatalf("unexpected error decoding the state: %v", err)
+	}
+
+	if !bytes.Equal(decoded, state) {
+		t.Fatalf("decoded state does not match, got %s", decoded)
+	}
+}
+
+func TestToSecretInvalidState(t *testing.T) {
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-stack"},
+	}
+
+	if _, err := ToSecret(terraform, "flux-system", ReasonApply, "1", "", []byte("not a state")); err == nil {
+		t.Fatal("expected an error for an invalid state")
+	}
+}
+
+func TestExpired(t *testing.T) {
+	now := time.Now()
+	backup := func(name, reason string, serial int64, age time.Duration) Backup {
+		return Backup{
+			Name:      name,
+			Reason:    reason,
+			Serial:    serial,
+			CreatedAt: metav1.NewTime(now.Add(-age)),
+		}
+	}
+
+	backups := []Backup{
+		backup("b1", ReasonApply, 1, 4*time.Hour),
+		backup("m1", ReasonMigration, 2, 3*time.Hour),
+		backup("b3", ReasonApply, 3, 2*time.Hour),
+		backup("b4", ReasonApply, 4, time.Hour),
+		backup("b2", ReasonApply, 2, 3*time.Hour),
+	}
+
+	expired := Expired(backups, 2)
+	if len(expired) != 2 {
+		t.Fatalf("expected 2 expired backups, got %d", len(expired))
+	}
+
+	if expired[0].Name != "b2" || expired[1].Name != "b1" {
+		t.Fatalf("unexpected expired backups: %s, %s", expired[0].Name, expired[1].Name)
+	}
+
+	if expired := Expired(backups, 5); len(expired) != 0 {
+		t.Fatalf("expected no expired backups, got %d", len(expired))
+	}
+}
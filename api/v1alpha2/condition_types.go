@@ -28,6 +28,10 @@ var OwnedConditions = []string{
 	ConditionTypeHealthCheck,
 	ConditionTypeOutput,
 	ConditionTypeStateLocked,
+	ConditionTypeStateMigrationPending,
+	ConditionTypeValidated,
+	ConditionTypeTested,
+	ConditionTypeVariablesInvalid,
 }
 
 // These constants are the Condition Types that the Terraform Resource works with
@@ -37,6 +41,16 @@ const (
 	ConditionTypeOutput      = "Output"
 	ConditionTypePlan        = "Plan"
 	ConditionTypeStateLocked = "StateLocked"
+	ConditionTypeTested      = "Tested"
+	ConditionTypeValidated   = "Validated"
+
+	// ConditionTypeVariablesInvalid has a negative polarity: it is True when the
+	// variables do not match the declarations of the module. Only the
+	// VariablesInvalid reason blocks the plan, missing and unknown variables are
+	// reported with their own reasons.
+	ConditionTypeVariablesInvalid = "VariablesInvalid"
+
+	ConditionTypeStateMigrationPending = "StateMigrationPending"
 )
 
 const (
@@ -48,6 +62,23 @@ const (
 	// cannot be created because the namespace resource quota is exhausted.
 	RunnerQuotaExhaustedReason = "RunnerQuotaExhausted"
 
+	// ConcurrencyQuotaReachedReason represents the fact that the
+	// reconciliation waits for the namespace or the tenant of the
+	// Terraform to run less concurrent reconciliations.
+	ConcurrencyQuotaReachedReason = "ConcurrencyQuotaReached"
+
+	// RolloutPendingReason represents the fact that the new revision of the
+	// source waits for an earlier wave, or for a slot of the RolloutPolicy.
+	RolloutPendingReason = "RolloutPending"
+
+	// RolloutHaltedReason represents the fact that the rollout of the new
+	// revision of the source was halted by a failure of another Terraform.
+	RolloutHaltedReason = "RolloutHalted"
+
+	// InvalidScheduleReason represents the fact that the schedule or the
+	// time zone of the Terraform is invalid.
+	InvalidScheduleReason = "InvalidSchedule"
+
 	// ArtifactFailedReason represents the fact that the artifact download
 	// for the Teraform failed.
 	ArtifactFailedReason = "ArtifactFailed"
@@ -56,11 +87,23 @@ const (
 	// reached the maximum number of retries.
 	RetryLimitReachedReason = "RetryLimitReached"
 
+	// BackendConfigChangedReason represents the fact that the backend
+	// configuration differs from the one the state was initialized with.
+	BackendConfigChangedReason = "BackendConfigChanged"
+
 	// DeletionBlockedByDependantsReason represents the fact that the
 	// Terraform resource could not be deleted because there are
 	// still resources depending on it.
 	DeletionBlockedByDependants = "DeletionBlockedByDependantsReason"
 
+	// CascadingDeletionReason represents the fact that the dependants of
+	// the Terraform resource are being deleted before the resource itself.
+	CascadingDeletionReason = "CascadingDeletion"
+
+	// DependencyCycleDetectedReason represents the fact that the
+	// Terraform object is part of a dependency cycle.
+	DependencyCycleDetectedReason = "DependencyCycleDetected"
+
 	// DependencyNotReadyReason represents the fact that
 	// one of the dependencies is not ready.
 	DependencyNotReadyReason = "DependencyNotReady"
@@ -85,6 +128,18 @@ const (
 	// outputs for the Terraform resource status failed.
 	OutputsWritingFailedReason = "OutputsWritingFailed"
 
+	// OutputsWrittenReason represents the fact that the outputs
+	// have been written to their destinations.
+	OutputsWrittenReason = "OutputsWritten"
+
+	// OutputsChangedReason represents the fact that the values
+	// of outputs changed after an apply.
+	OutputsChangedReason = "OutputsChanged"
+
+	// OutputsRolloutFailedReason represents the fact that the Deployments
+	// consuming the outputs could not be annotated.
+	OutputsRolloutFailedReason = "OutputsRolloutFailed"
+
 	// PlannedNoChangesReason represents the fact that Terraform
 	// planned no changes during reconciliation.
 	PlannedNoChangesReason = "TerraformPlannedNoChanges"
@@ -141,14 +196,86 @@ const (
 	// of 'terraform plan' succeeded.
 	TFExecPlanSucceedReason = "TerraformPlanSucceed"
 
+	// StateBackupFailedReason represents the fact that backing up
+	// the Terraform state before apply failed.
+	StateBackupFailedReason = "StateBackupFailed"
+
+	// StateRestoreFailedReason represents the fact that restoring
+	// the Terraform state from a backup failed.
+	StateRestoreFailedReason = "StateRestoreFailed"
+
+	// StateRestoredReason represents the fact that the Terraform
+	// state was restored from a backup.
+	StateRestoredReason = "StateRestored"
+
+	// StateOperationSucceededReason represents the fact that a state
+	// inspection or surgery operation succeeded.
+	StateOperationSucceededReason = "StateOperationSucceeded"
+
+	// StateOperationFailedReason represents the fact that a state
+	// inspection or surgery operation failed.
+	StateOperationFailedReason = "StateOperationFailed"
+
+	// StateMigrationPendingReason represents the fact that a state
+	// migration to a new backend is awaiting approval.
+	StateMigrationPendingReason = "StateMigrationPending"
+
+	// StateMigrationFailedReason represents the fact that migrating
+	// the Terraform state to a new backend failed.
+	StateMigrationFailedReason = "StateMigrationFailed"
+
+	// StateMigratedReason represents the fact that the Terraform
+	// state was migrated to a new backend.
+	StateMigratedReason = "StateMigrated"
+
+	// TestsFailedReason represents the fact that the tests
+	// of the module failed.
+	TestsFailedReason = "TestsFailed"
+
+	// TestsPassedReason represents the fact that the tests
+	// of the module passed.
+	TestsPassedReason = "TestsPassed"
+
+	// ValidationFailedReason represents the fact that the module
+	// failed 'terraform validate' or 'terraform fmt -check'.
+	ValidationFailedReason = "ValidationFailed"
+
+	// ValidationSucceededReason represents the fact that the module
+	// passed 'terraform validate' and 'terraform fmt -check'.
+	ValidationSucceededReason = "ValidationSucceeded"
+
 	// TemplateGenerationFailedReason represents the fact that
 	// the generation of the Terraform .tf template failed.
 	TemplateGenerationFailedReason = "TemplateGenerationFailed"
 
+	// TerraformsGenerationFailedReason represents the fact that a TerraformSet
+	// failed to generate its Terraform objects.
+	TerraformsGenerationFailedReason = "TerraformsGenerationFailed"
+
+	// TerraformsNotReadyReason represents the fact that some of the Terraform
+	// objects generated by a TerraformSet are not ready.
+	TerraformsNotReadyReason = "TerraformsNotReady"
+
+	// ImportsGenerationFailedReason represents the fact that the generation
+	// of the import and moved blocks failed.
+	ImportsGenerationFailedReason = "ImportsGenerationFailed"
+
 	// VarsGenerationFailedReason represents the fact that
 	// the generation of the Terraform variables failed.
 	VarsGenerationFailedReason = "VarsGenerationFailed"
 
+	// VariablesInvalidReason represents the fact that variables do not
+	// have the declared type.
+	VariablesInvalidReason = "VariablesInvalid"
+
+	// MissingVariablesReason represents the fact that no value was found for
+	// required variables. Terraform fails the plan if it finds none either.
+	MissingVariablesReason = "MissingVariables"
+
+	// UnknownVariablesReason represents the fact that values are given
+	// for variables the module does not declare. Terraform ignores them.
+	UnknownVariablesReason = "UnknownVariables"
+
 	// WorkspaceSelectFailedReason represents the fact that selecting
 	// a Terraform workspace failed.
 	WorkspaceSelectFailedReason = "SelectWorkspaceFailed"
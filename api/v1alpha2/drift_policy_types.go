@@ -0,0 +1,71 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+// DriftAction is the handling of the drift of a resource.
+// +kubebuilder:validation:Enum=ignore;notify;auto-remediate;require-approval
+type DriftAction string
+
+const (
+	// DriftActionIgnore does not count the changes of the resource as drift.
+	DriftActionIgnore DriftAction = "ignore"
+	// DriftActionNotify reports the drift, without remediating it.
+	DriftActionNotify DriftAction = "notify"
+	// DriftActionAutoRemediate applies the drift plan, whatever .spec.approvePlan.
+	DriftActionAutoRemediate DriftAction = "auto-remediate"
+	// DriftActionRequireApproval keeps the drift plan pending until it is
+	// approved with its plan id, even when .spec.approvePlan is auto.
+	DriftActionRequireApproval DriftAction = "require-approval"
+)
+
+// DriftPolicy decides how the drift found by the drift detection is handled,
+// resource by resource.
+type DriftPolicy struct {
+	// Rules matching the changed resources of the drift plan. The first rule
+	// matching a resource decides of its action.
+	// +optional
+	Rules []DriftRule `json:"rules,omitempty"`
+
+	// DefaultAction of the resources matched by no rule, or by a rule without
+	// action. When empty, the drift is remediated as per .spec.approvePlan:
+	// auto-remediate when it is auto, require-approval otherwise.
+	// +optional
+	DefaultAction DriftAction `json:"defaultAction,omitempty"`
+}
+
+// DriftRule matches the changed resources of the drift plan by address or by
+// type. A rule without addresses and types matches all the resources.
+type DriftRule struct {
+	// Addresses of the resources, where * matches any sequence of
+	// characters, like module.app.aws_instance.* or aws_s3_bucket.logs.
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+
+	// Types of the resources, like aws_autoscaling_group.
+	// +optional
+	Types []string `json:"types,omitempty"`
+
+	// Action for the matched resources. Defaults to .defaultAction.
+	// +optional
+	Action DriftAction `json:"action,omitempty"`
+
+	// IgnoreAttributes are attribute paths whose changes are not drift, like
+	// desired_capacity or tags.LastScaledAt, like ignore_changes on the
+	// controller side. A resource changing only those attributes is ignored.
+	// +optional
+	IgnoreAttributes []string `json:"ignoreAttributes,omitempty"`
+}
@@ -38,6 +38,41 @@ func (s *CrossNamespaceSourceReference) String() string {
 	return fmt.Sprintf("%s/%s", s.Kind, s.Name)
 }
 
+// ObjectDependency is a reference to a Kubernetes object of any kind a
+// Terraform object depends on.
+type ObjectDependency struct {
+	// API version of the referent.
+	// +required
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the referent.
+	// +required
+	Kind string `json:"kind"`
+
+	// Name of the referent.
+	// +required
+	Name string `json:"name"`
+
+	// Namespace of the referent, defaults to the namespace of the Terraform
+	// object for namespaced kinds. Ignored for cluster-scoped kinds.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// ReadyExpr is a CEL expression that replaces the kstatus readiness check
+	// of the referent. It must return a boolean, and can use the variables
+	// `dep`, the referent, and `self`, the Terraform object. It cannot be set
+	// on a Secret or a ConfigMap.
+	// +optional
+	ReadyExpr string `json:"readyExpr,omitempty"`
+}
+
+func (s *ObjectDependency) String() string {
+	if s.Namespace != "" {
+		return fmt.Sprintf("%s/%s/%s", s.Kind, s.Namespace, s.Name)
+	}
+	return fmt.Sprintf("%s/%s", s.Kind, s.Name)
+}
+
 type FileMapping struct {
 	// Reference to a Secret that contains the file content
 	SecretRef meta.SecretKeyReference `json:"secretRef"`
@@ -75,21 +110,92 @@ type BackendConfigsReference struct {
 	Optional bool `json:"optional,omitempty"`
 }
 
-// VarsReference contain a reference of a Secret or a ConfigMap to generate
-// variables for Terraform resources based on its data, selectively by varsKey.
-type VarsReference struct {
-	// Kind of the values referent, valid values are ('Secret', 'ConfigMap').
+// KubeConfigReference contains a reference to a Secret holding the kubeconfig
+// of a remote cluster.
+type KubeConfigReference struct {
+	// SecretRef holds the name of a Secret in the namespace of the Terraform object,
+	// and optionally the key of the kubeconfig. The key defaults to value, then value.yaml.
+	// +required
+	SecretRef meta.SecretKeyReference `json:"secretRef"`
+}
+
+// RemoteOutputsReference references a destination of .spec.writeOutputsTo
+// written to a remote cluster.
+type RemoteOutputsReference struct {
+	// Kind of the destination, valid values are ('Secret', 'ConfigMap').
 	// +kubebuilder:validation:Enum=Secret;ConfigMap
 	// +required
 	Kind string `json:"kind"`
 
-	// Name of the values referent. Should reside in the same namespace as the
+	// Name of the destination.
+	// +required
+	Name string `json:"name"`
+
+	// Namespace of the destination in the remote cluster, defaults to the
+	// namespace of the Terraform object.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// KubeConfig references the kubeconfig of the remote cluster.
+	// +required
+	KubeConfig KubeConfigReference `json:"kubeConfig"`
+}
+
+// ImportIDReference contains a reference to the key of a Secret or a ConfigMap
+// holding the ID of a resource to import.
+type ImportIDReference struct {
+	// Kind of the referent, valid values are ('Secret', 'ConfigMap').
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// +required
+	Kind string `json:"kind"`
+
+	// Name of the referent. Should reside in the same namespace as the
 	// referring resource.
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=253
 	// +required
 	Name string `json:"name"`
 
+	// Key is the data key where the ID can be found at.
+	// +required
+	Key string `json:"key"`
+}
+
+// VarsReference contain a reference of a Secret, a ConfigMap or the published
+// outputs of another Terraform object to generate variables for Terraform
+// resources based on its data, selectively by varsKey.
+type VarsReference struct {
+	// Kind of the values referent, valid values are ('Secret', 'ConfigMap', 'Terraform',
+	// 'Vault', 'HTTP').
+	// A Terraform referent provides the outputs it writes to its
+	// .spec.writeOutputsToSecret or, without it, to its first .spec.writeOutputsTo
+	// destination in the local cluster, and becomes an implicit dependency.
+	// Vault and HTTP referents are read by the runner, configured by the field
+	// of the same name, and are identified by Name in messages only.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap;Terraform;Vault;HTTP
+	// +required
+	Kind string `json:"kind"`
+
+	// Name of the values referent. Should reside in the same namespace as the
+	// referring resource, unless Namespace is set for a Terraform referent.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	Name string `json:"name"`
+
+	// Namespace of the Terraform referent, defaults to the namespace of the
+	// referring resource. Only valid for the Terraform kind.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Vault configures the HashiCorp Vault KV secret of the Vault kind.
+	// +optional
+	Vault *VaultVarsSource `json:"vault,omitempty"`
+
+	// HTTP configures the JSON document of the HTTP kind.
+	// +optional
+	HTTP *HTTPVarsSource `json:"http,omitempty"`
+
 	// VarsKeys is the data key at which a specific value can be found. Defaults to all keys.
 	// +optional
 	VarsKeys []string `json:"varsKeys,omitempty"`
@@ -101,6 +207,93 @@ type VarsReference struct {
 	Optional bool `json:"optional,omitempty"`
 }
 
+const (
+	// VaultVarsSourceKind is the varsFrom kind reading a HashiCorp Vault KV secret.
+	VaultVarsSourceKind = "Vault"
+	// HTTPVarsSourceKind is the varsFrom kind reading a JSON document over HTTP.
+	HTTPVarsSourceKind = "HTTP"
+)
+
+// VaultVarsSource reads the variables from a HashiCorp Vault KV secret, one
+// variable per key of the secret.
+type VaultVarsSource struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200.
+	// +required
+	Address string `json:"address"`
+
+	// Mount is the mount path of the KV secrets engine.
+	// +kubebuilder:default=secret
+	// +optional
+	Mount string `json:"mount,omitempty"`
+
+	// Path of the secret within the mount.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Path string `json:"path"`
+
+	// KVVersion is the version of the KV secrets engine.
+	// +kubebuilder:validation:Enum=1;2
+	// +kubebuilder:default=2
+	// +optional
+	KVVersion int `json:"kvVersion,omitempty"`
+
+	// Namespace is the Vault Enterprise namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Auth configures how the runner authenticates with Vault.
+	// +required
+	Auth VaultAuth `json:"auth"`
+}
+
+// VaultAuth configures the authentication with Vault. Exactly one method must be set.
+type VaultAuth struct {
+	// TokenSecretRef refers to a Secret holding a Vault token. The key defaults to token.
+	// +optional
+	TokenSecretRef *meta.SecretKeyReference `json:"tokenSecretRef,omitempty"`
+
+	// Kubernetes logs in with the service account token of the runner pod.
+	// +optional
+	Kubernetes *VaultKubernetesAuth `json:"kubernetes,omitempty"`
+}
+
+// VaultKubernetesAuth configures the Kubernetes auth method of Vault.
+type VaultKubernetesAuth struct {
+	// Role to log in with.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Role string `json:"role"`
+
+	// Mount is the mount path of the Kubernetes auth method.
+	// +kubebuilder:default=kubernetes
+	// +optional
+	Mount string `json:"mount,omitempty"`
+}
+
+// HTTPVarsSource reads the variables from a JSON object served over HTTP, one
+// variable per field of the object.
+type HTTPVarsSource struct {
+	// URL of the JSON document.
+	// +kubebuilder:validation:Pattern="^https?://"
+	// +required
+	URL string `json:"url"`
+
+	// HeadersSecretRef refers to a Secret whose keys and values are sent as
+	// request headers, e.g. Authorization.
+	// +optional
+	HeadersSecretRef *meta.LocalObjectReference `json:"headersSecretRef,omitempty"`
+
+	// Field selects a nested object of the document, as a dot separated path.
+	// Defaults to the whole document.
+	// +optional
+	Field string `json:"field,omitempty"`
+
+	// Timeout of the request.
+	// +kubebuilder:default="30s"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
 // HealthCheck contains configuration needed to perform a health check after
 // terraform is applied.
 type HealthCheck struct {
@@ -0,0 +1,143 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	RolloutPolicyKind = "RolloutPolicy"
+)
+
+// RolloutPolicySpec defines how a new revision of a source is rolled out to
+// the Terraform objects using it as their .spec.sourceRef.
+type RolloutPolicySpec struct {
+	// SourceRef is the source whose revisions are rolled out. It must be in
+	// the namespace of the RolloutPolicy, while the Terraform objects using
+	// it can be in any namespace.
+	// +required
+	SourceRef RolloutSourceReference `json:"sourceRef"`
+
+	// Waves of the rollout, in order. A Terraform object belongs to the first
+	// wave whose selector matches its labels, the objects matched by no wave
+	// form an implicit last wave. A wave starts once every object of the
+	// previous waves is ready at the new revision.
+	// +optional
+	Waves []RolloutWave `json:"waves,omitempty"`
+
+	// MaxInFlight is the maximum number of Terraform objects reconciling the
+	// new revision at the same time. Defaults to 0, for no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxInFlight int32 `json:"maxInFlight,omitempty"`
+
+	// StopOnFailure halts the rollout as soon as a Terraform object fails at
+	// the new revision: the objects which have not started yet wait for the
+	// failure to be fixed, or for another revision. Defaults to true.
+	// +kubebuilder:default:=true
+	// +optional
+	StopOnFailure bool `json:"stopOnFailure"`
+
+	// Suspend the rollout policy, the new revisions are then reconciled by
+	// all the Terraform objects at once.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// RolloutSourceReference is a reference to a source in the namespace of the
+// RolloutPolicy.
+type RolloutSourceReference struct {
+	// Kind of the referent.
+	// +kubebuilder:validation:Enum=GitRepository;Bucket;OCIRepository
+	// +required
+	Kind string `json:"kind"`
+
+	// Name of the referent.
+	// +required
+	Name string `json:"name"`
+}
+
+func (s *RolloutSourceReference) String() string {
+	return fmt.Sprintf("%s/%s", s.Kind, s.Name)
+}
+
+// RolloutWave is a group of Terraform objects selected by their labels.
+type RolloutWave struct {
+	// Name of the wave, as shown in the status of the Terraform objects
+	// waiting for it.
+	// +required
+	Name string `json:"name"`
+
+	// Selector of the Terraform objects of the wave.
+	// +required
+	Selector metav1.LabelSelector `json:"selector"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=rollout
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.sourceRef.name",description=""
+// +kubebuilder:printcolumn:name="Max In Flight",type="integer",JSONPath=".spec.maxInFlight",description=""
+// +kubebuilder:printcolumn:name="Suspended",type="boolean",JSONPath=".spec.suspend",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// RolloutPolicy is the Schema for the rolloutpolicies API
+type RolloutPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RolloutPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RolloutPolicyList contains a list of RolloutPolicy
+type RolloutPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RolloutPolicy `json:"items"`
+}
+
+// WaveOf returns the index of the wave of an object with the given labels,
+// or the number of waves for the implicit last wave.
+func (in RolloutPolicy) WaveOf(labels map[string]string) (int, error) {
+	for i, wave := range in.Spec.Waves {
+		selector, err := metav1.LabelSelectorAsSelector(&wave.Selector)
+		if err != nil {
+			return 0, fmt.Errorf("invalid selector of wave %s: %w", wave.Name, err)
+		}
+		if selector.Matches(k8slabels.Set(labels)) {
+			return i, nil
+		}
+	}
+	return len(in.Spec.Waves), nil
+}
+
+// WaveName returns the name of the wave of the given index.
+func (in RolloutPolicy) WaveName(wave int) string {
+	if wave < len(in.Spec.Waves) {
+		return in.Spec.Waves[wave].Name
+	}
+	return "default"
+}
+
+func init() {
+	SchemeBuilder.Register(&RolloutPolicy{}, &RolloutPolicyList{})
+}
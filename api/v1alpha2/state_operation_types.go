@@ -0,0 +1,196 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StateOperationAnnotation holds the JSON encoded StateOperation requested on a Terraform object.
+const StateOperationAnnotation = "infra.contrib.fluxcd.io/state-operation"
+
+// StateOperationType is the type of a state inspection or surgery operation.
+// +kubebuilder:validation:Enum=list;mv;rm;import
+type StateOperationType string
+
+const (
+	// StateOperationList lists the resources in the state, like `terraform state list`.
+	StateOperationList StateOperationType = "list"
+	// StateOperationMove moves a resource in the state, like `terraform state mv`.
+	StateOperationMove StateOperationType = "mv"
+	// StateOperationRemove removes resources from the state, like `terraform state rm`.
+	StateOperationRemove StateOperationType = "rm"
+	// StateOperationImport imports an existing resource into the state, like `terraform import`.
+	StateOperationImport StateOperationType = "import"
+)
+
+// StateOperation is a state inspection or surgery operation, executed by the runner
+// of the Terraform object against its backend.
+type StateOperation struct {
+	// ID identifies the operation request.
+	// +required
+	ID string `json:"id"`
+
+	// Type is the type of the operation.
+	// +required
+	Type StateOperationType `json:"type"`
+
+	// Addresses are the resource addresses to list or remove.
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+
+	// Source is the address of the resource to move.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Destination is the address to move the resource to.
+	// +optional
+	Destination string `json:"destination,omitempty"`
+
+	// Address is the address to import the resource to.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// ResourceID is the provider specific ID of the resource to import.
+	// +optional
+	ResourceID string `json:"resourceID,omitempty"`
+}
+
+// StateOperationStatus records the result of the last state operation.
+type StateOperationStatus struct {
+	// Operation is the last executed state operation.
+	// +optional
+	Operation *StateOperation `json:"operation,omitempty"`
+
+	// Succeeded is true if the operation succeeded.
+	// +optional
+	Succeeded bool `json:"succeeded,omitempty"`
+
+	// Message describes the result of the operation.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Addresses are the resource addresses listed by a list operation, or removed
+	// by a remove operation, including when it failed after removing some of them.
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+
+	// CompletedAt is the time when the operation completed.
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
+// Validate returns an error if the arguments of the operation do not match its type.
+func (in StateOperation) Validate() error {
+	if in.ID == "" {
+		return fmt.Errorf("state operation is missing an id")
+	}
+
+	switch in.Type {
+	case StateOperationList:
+		return nil
+	case StateOperationMove:
+		if in.Source == "" || in.Destination == "" {
+			return fmt.Errorf("state operation %s requires a source and a destination", in.Type)
+		}
+	case StateOperationRemove:
+		if len(in.Addresses) == 0 {
+			return fmt.Errorf("state operation %s requires at least one address", in.Type)
+		}
+	case StateOperationImport:
+		if in.Address == "" || in.ResourceID == "" {
+			return fmt.Errorf("state operation %s requires an address and a resource ID", in.Type)
+		}
+	default:
+		return fmt.Errorf("unknown state operation type %q", in.Type)
+	}
+
+	return nil
+}
+
+// String describes the operation like the equivalent Terraform command.
+func (in StateOperation) String() string {
+	switch in.Type {
+	case StateOperationMove:
+		return fmt.Sprintf("state mv %s %s", in.Source, in.Destination)
+	case StateOperationRemove:
+		return fmt.Sprintf("state rm %v", in.Addresses)
+	case StateOperationImport:
+		return fmt.Sprintf("import %s %s", in.Address, in.ResourceID)
+	}
+
+	return fmt.Sprintf("state %s", in.Type)
+}
+
+// GetStateOperation returns the state operation requested with the StateOperationAnnotation.
+func (in Terraform) GetStateOperation() (*StateOperation, bool, error) {
+	value, ok := in.GetAnnotations()[StateOperationAnnotation]
+	if !ok || value == "" {
+		return nil, false, nil
+	}
+
+	var op StateOperation
+	if err := json.Unmarshal([]byte(value), &op); err != nil {
+		return nil, true, fmt.Errorf("invalid %s annotation: %s", StateOperationAnnotation, err)
+	}
+
+	if err := op.Validate(); err != nil {
+		return &op, true, err
+	}
+
+	return &op, true, nil
+}
+
+// TerraformStateOperationCompleted records a successful state operation, and
+// drops the pending plan if the state has been modified.
+func TerraformStateOperationCompleted(terraform *Terraform, op *StateOperation, message string, addresses []string) *Terraform {
+	terraform.Status.StateOperation = StateOperationStatus{
+		Operation:   op,
+		Succeeded:   true,
+		Message:     trimString(message, MaxConditionMessageLength),
+		Addresses:   addresses,
+		CompletedAt: &metav1.Time{Time: time.Now()},
+	}
+
+	if op.Type != StateOperationList {
+		terraform.Status.Plan.Pending = ""
+	}
+
+	return terraform
+}
+
+// TerraformStateOperationFailed records a failed state operation, with the
+// addresses it removed from the state before failing.
+func TerraformStateOperationFailed(terraform *Terraform, op *StateOperation, message string, addresses []string) *Terraform {
+	terraform.Status.StateOperation = StateOperationStatus{
+		Operation:   op,
+		Message:     trimString(message, MaxConditionMessageLength),
+		Addresses:   addresses,
+		CompletedAt: &metav1.Time{Time: time.Now()},
+	}
+
+	// the state changed, the pending plan is stale
+	if len(addresses) > 0 {
+		terraform.Status.Plan.Pending = ""
+	}
+
+	return terraform
+}
@@ -0,0 +1,114 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetStateOperation(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		annotation  string
+		expectedOp  *StateOperation
+		expectedOk  bool
+		expectedErr bool
+	}{
+		{
+			name:       "no operation requested",
+			expectedOk: false,
+		},
+		{
+			name:       "move",
+			annotation: `{"id":"abc","type":"mv","source":"aws_instance.a","destination":"aws_instance.b"}`,
+			expectedOp: &StateOperation{
+				ID:          "abc",
+				Type:        StateOperationMove,
+				Source:      "aws_instance.a",
+				Destination: "aws_instance.b",
+			},
+			expectedOk: true,
+		},
+		{
+			name:        "import without resource ID",
+			annotation:  `{"id":"abc","type":"import","address":"aws_instance.a"}`,
+			expectedOp:  &StateOperation{ID: "abc", Type: StateOperationImport, Address: "aws_instance.a"},
+			expectedOk:  true,
+			expectedErr: true,
+		},
+		{
+			name:        "remove without addresses",
+			annotation:  `{"id":"abc","type":"rm"}`,
+			expectedOp:  &StateOperation{ID: "abc", Type: StateOperationRemove},
+			expectedOk:  true,
+			expectedErr: true,
+		},
+		{
+			name:        "unknown operation",
+			annotation:  `{"id":"abc","type":"push"}`,
+			expectedOp:  &StateOperation{ID: "abc", Type: "push"},
+			expectedOk:  true,
+			expectedErr: true,
+		},
+		{
+			name:        "invalid annotation",
+			annotation:  `mv a b`,
+			expectedOk:  true,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terraform := Terraform{}
+			if tt.annotation != "" {
+				terraform.Annotations = map[string]string{StateOperationAnnotation: tt.annotation}
+			}
+
+			op, ok, err := terraform.GetStateOperation()
+			g.Expect(ok).To(Equal(tt.expectedOk))
+			g.Expect(op).To(Equal(tt.expectedOp))
+			if tt.expectedErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestTerraformStateOperationCompleted(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	terraform := &Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld"},
+	}
+	terraform.Status.Plan.Pending = "plan-main-1234"
+
+	list := &StateOperation{ID: "list", Type: StateOperationList}
+	TerraformStateOperationCompleted(terraform, list, "Listed 1 resources", []string{"random_pet.name"})
+	g.Expect(terraform.Status.StateOperation.Succeeded).To(BeTrue())
+	g.Expect(terraform.Status.StateOperation.Addresses).To(Equal([]string{"random_pet.name"}))
+	g.Expect(terraform.Status.Plan.Pending).To(Equal("plan-main-1234"))
+
+	rm := &StateOperation{ID: "rm", Type: StateOperationRemove, Addresses: []string{"random_pet.name"}}
+	TerraformStateOperationFailed(terraform, rm, "state locked", nil)
+	g.Expect(terraform.Status.StateOperation.Succeeded).To(BeFalse())
+	g.Expect(terraform.Status.StateOperation.Addresses).To(BeEmpty())
+	g.Expect(terraform.Status.Plan.Pending).To(Equal("plan-main-1234"))
+
+	TerraformStateOperationCompleted(terraform, rm, "Removed random_pet.name", nil)
+	g.Expect(terraform.Status.StateOperation.Operation.ID).To(Equal("rm"))
+	g.Expect(terraform.Status.Plan.Pending).To(BeEmpty())
+
+	// a remove failing after removing some addresses records them
+	terraform.Status.Plan.Pending = "plan-main-5678"
+	rm = &StateOperation{ID: "rm-2", Type: StateOperationRemove, Addresses: []string{"random_pet.name", "random_pet.other"}}
+	TerraformStateOperationFailed(terraform, rm, "state locked", []string{"random_pet.name"})
+	g.Expect(terraform.Status.StateOperation.Succeeded).To(BeFalse())
+	g.Expect(terraform.Status.StateOperation.Addresses).To(Equal([]string{"random_pet.name"}))
+	g.Expect(terraform.Status.Plan.Pending).To(BeEmpty())
+}
@@ -33,6 +33,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/flux-iac/tofu-controller/api/planid"
 )
@@ -41,12 +42,26 @@ const (
 	CACertSecretName = "tf-controller.tls"
 	// RunnerTLSSecretName is the name of the secret containing a TLS cert that will be written to
 	// the namespace in which a terraform runner is created
-	RunnerTLSSecretName     = "terraform-runner.tls"
-	RunnerLabel             = "infra.contrib.fluxcd.io/terraform"
-	GitRepositoryIndexKey   = ".metadata.gitRepository"
-	BucketIndexKey          = ".metadata.bucket"
-	OCIRepositoryIndexKey   = ".metadata.ociRepository"
-	BreakTheGlassAnnotation = "break-the-glass.tf-controller/requestedAt"
+	RunnerTLSSecretName       = "terraform-runner.tls"
+	RunnerLabel               = "infra.contrib.fluxcd.io/terraform"
+	StateBackupLabel          = "infra.contrib.fluxcd.io/state-backup"
+	GitRepositoryIndexKey     = ".metadata.gitRepository"
+	BucketIndexKey            = ".metadata.bucket"
+	OCIRepositoryIndexKey     = ".metadata.ociRepository"
+	VarsFromTerraformIndexKey = ".spec.varsFrom.terraform"
+	InlineConfigMapIndexKey   = ".spec.inline.configMapRef"
+	DependsOnIndexKey         = ".spec.dependsOn"
+	BreakTheGlassAnnotation   = "break-the-glass.tf-controller/requestedAt"
+	RestoreStateAnnotation    = "infra.contrib.fluxcd.io/restore-state-from"
+	// CascadeDeletionAnnotation, set to "true" on a Terraform object, deletes its
+	// dependants in its namespace, in reverse dependency order, before the object itself.
+	CascadeDeletionAnnotation = "infra.contrib.fluxcd.io/cascade-deletion"
+	// OutputsHashAnnotationPrefix prefixes, with the name of the Terraform object, the
+	// annotation of the pod template of the Deployments consuming the outputs. Names
+	// longer than 63 characters are truncated and suffixed with their hash.
+	OutputsHashAnnotationPrefix = "outputs.infra.contrib.fluxcd.io/"
+	// MaxOutputsHistory is the number of entries kept in .status.outputsHistory.
+	MaxOutputsHistory = 10
 )
 
 type ReadInputsFromSecretSpec struct {
@@ -77,6 +92,113 @@ type WriteOutputsToSecretSpec struct {
 	Outputs []string `json:"outputs,omitempty"`
 }
 
+const (
+	// OutputSensitivityAll selects all the outputs.
+	OutputSensitivityAll = "All"
+	// OutputSensitivitySensitive selects the outputs marked as sensitive.
+	OutputSensitivitySensitive = "Sensitive"
+	// OutputSensitivityNonSensitive selects the outputs not marked as sensitive.
+	OutputSensitivityNonSensitive = "NonSensitive"
+)
+
+// OutputDestination defines a ConfigMap or a Secret to write outputs to.
+type OutputDestination struct {
+	// Kind of the destination, valid values are ('Secret', 'ConfigMap').
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// +required
+	Kind string `json:"kind"`
+
+	// Name of the destination. It is created in the namespace of the Terraform object,
+	// unless it is written to a remote cluster.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	Name string `json:"name"`
+
+	// KubeConfig references the kubeconfig of a remote cluster to write the
+	// destination to, instead of the cluster of the Terraform object.
+	// +optional
+	KubeConfig *KubeConfigReference `json:"kubeConfig,omitempty"`
+
+	// Namespace of the destination in the remote cluster. Defaults to the namespace
+	// of the Terraform object. Only valid with KubeConfig.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Type of the Secret, e.g. kubernetes.io/tls or kubernetes.io/dockerconfigjson.
+	// Defaults to Opaque. Only valid for Secrets.
+	// +optional
+	Type corev1.SecretType `json:"type,omitempty"`
+
+	// Labels to add to the destination.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations to add to the destination.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Sensitivity selects the outputs by their sensitive flag, valid values are
+	// ('All', 'Sensitive', 'NonSensitive'). Defaults to All for Secrets. ConfigMaps
+	// only hold outputs which are not sensitive.
+	// +kubebuilder:validation:Enum=All;Sensitive;NonSensitive
+	// +optional
+	Sensitivity string `json:"sensitivity,omitempty"`
+
+	// Outputs contain the selected names of outputs to be written, optionally
+	// renamed with the output:alias form. Empty array means writing all outputs.
+	// +optional
+	Outputs []string `json:"outputs,omitempty"`
+
+	// Keys maps the keys of the destination to Go templates rendered with the
+	// selected outputs, e.g. `tls.crt: "{{ .certificate }}"`. The hermetic sprig
+	// functions are available. When set, only these keys are written.
+	// +optional
+	Keys map[string]string `json:"keys,omitempty"`
+}
+
+// GetSensitivity returns the sensitivity of the outputs to write to the destination.
+func (in OutputDestination) GetSensitivity() string {
+	if in.Kind == "ConfigMap" {
+		return OutputSensitivityNonSensitive
+	}
+	if in.Sensitivity == "" {
+		return OutputSensitivityAll
+	}
+	return in.Sensitivity
+}
+
+// RemoteOutputsReference returns the reference of the destination written to
+// a remote cluster, or nil for a destination in the local cluster.
+func (in OutputDestination) RemoteOutputsReference() *RemoteOutputsReference {
+	if in.KubeConfig == nil {
+		return nil
+	}
+	return &RemoteOutputsReference{
+		Kind:       in.Kind,
+		Name:       in.Name,
+		Namespace:  in.Namespace,
+		KubeConfig: *in.KubeConfig,
+	}
+}
+
+// Validate checks that the destination only uses the fields of its kind.
+func (in OutputDestination) Validate() error {
+	if in.Namespace != "" && in.KubeConfig == nil {
+		return fmt.Errorf("output destination %s/%s can only set a namespace with a kubeConfig", in.Kind, in.Name)
+	}
+	if in.Kind != "ConfigMap" {
+		return nil
+	}
+	if in.Type != "" {
+		return fmt.Errorf("output destination ConfigMap/%s cannot have a type", in.Name)
+	}
+	if in.Sensitivity != "" && in.Sensitivity != OutputSensitivityNonSensitive {
+		return fmt.Errorf("output destination ConfigMap/%s cannot hold sensitive outputs", in.Name)
+	}
+	return nil
+}
+
 type Variable struct {
 	// Name is the name of the variable
 	// +required
@@ -90,6 +212,7 @@ type Variable struct {
 }
 
 // TerraformSpec defines the desired state of Terraform
+// +kubebuilder:validation:XValidation:rule="has(self.sourceRef) != has(self.inline)",message="exactly one of sourceRef and inline must be set"
 type TerraformSpec struct {
 
 	// ApprovePlan specifies name of a plan wanted to approve.
@@ -107,6 +230,35 @@ type TerraformSpec struct {
 	// +optional
 	BackendConfigsFrom []BackendConfigsReference `json:"backendConfigsFrom,omitempty"`
 
+	// StateMigration controls how the Terraform state is migrated when
+	// the backend configuration (BackendConfig or BackendConfigsFrom) changes.
+	// +optional
+	StateMigration *StateMigrationSpec `json:"stateMigration,omitempty"`
+
+	// StateBackup enables backing up the Terraform state before every apply.
+	// +optional
+	StateBackup *StateBackupSpec `json:"stateBackup,omitempty"`
+
+	// Imports are existing resources to import into the Terraform state. They are
+	// generated as import blocks in imports.tf before planning, until they have been applied.
+	// +optional
+	Imports []ImportSpec `json:"imports,omitempty"`
+
+	// Moves are resources to move in the Terraform state. They are generated
+	// as moved blocks in moved.tf before planning.
+	// +optional
+	Moves []MoveSpec `json:"moves,omitempty"`
+
+	// Validate runs `terraform validate` and `terraform fmt -check` before planning.
+	// A failed validation blocks the plan and is reported in the Validated condition.
+	// +optional
+	Validate *ValidateSpec `json:"validate,omitempty"`
+
+	// Test runs `terraform test` against the *.tftest.hcl files of the module before
+	// planning. Failing tests block the plan and are reported in the Tested condition.
+	// +optional
+	Test *TestSpec `json:"test,omitempty"`
+
 	// +optional
 	Cloud *CloudSpec `json:"cloud,omitempty"`
 
@@ -130,6 +282,12 @@ type TerraformSpec struct {
 	// +optional
 	Values *apiextensionsv1.JSON `json:"values,omitempty"`
 
+	// Templating renders the module sources before planning, from the variables,
+	// the inputs read from Secrets and the metadata of the object.
+	// When not set, only main.tf.tpl is rendered, to main.tf.
+	// +optional
+	Templating *TemplatingSpec `json:"templating,omitempty"`
+
 	// TfVarsFiles loads all given .tfvars files. It copycats the -var-file functionality.
 	// +optional
 	TfVarsFiles []string `json:"tfVarsFiles,omitempty"`
@@ -142,6 +300,19 @@ type TerraformSpec struct {
 	// +required
 	Interval metav1.Duration `json:"interval"`
 
+	// Schedule of the periodic reconciliations, as a cron expression like
+	// "0 6 * * 1-5", or a descriptor like "@daily". When set, the drift
+	// detection and the replans run at the scheduled times instead of at
+	// every interval. A change of the source or of the object is still
+	// reconciled right away.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// TimeZone of the schedule, as an IANA time zone name like
+	// "Europe/Paris". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
 	// The interval at which to retry a previously failed reconciliation.
 	// The default value is 15 when not specified.
 	// +optional
@@ -168,8 +339,19 @@ type TerraformSpec struct {
 	Path string `json:"path,omitempty"`
 
 	// SourceRef is the reference of the source where the Terraform files are stored.
-	// +required
-	SourceRef CrossNamespaceSourceReference `json:"sourceRef"`
+	// Required unless Inline is set.
+	// +optional
+	SourceRef CrossNamespaceSourceReference `json:"sourceRef,omitzero"`
+
+	// Inline holds the files of the module, in place of a source.
+	// +optional
+	Inline *InlineSource `json:"inline,omitempty"`
+
+	// AdditionalSources are extracted into subdirectories of the working directory,
+	// on top of the files of the source, for example to add shared modules or
+	// the variable files of an environment.
+	// +optional
+	AdditionalSources []AdditionalSource `json:"additionalSources,omitempty"`
 
 	// Suspend is to tell the controller to suspend subsequent TF executions,
 	// it does not apply to already started executions. Defaults to false.
@@ -189,12 +371,28 @@ type TerraformSpec struct {
 	// +optional
 	WriteOutputsToSecret *WriteOutputsToSecretSpec `json:"writeOutputsToSecret,omitempty"`
 
+	// WriteOutputsTo is a list of ConfigMaps and Secrets the outputs are written to,
+	// in addition to WriteOutputsToSecret.
+	// +optional
+	WriteOutputsTo []OutputDestination `json:"writeOutputsTo,omitempty"`
+
+	// RolloutOnOutputsChange restarts the Deployments consuming the outputs when
+	// they change, by annotating their pod template.
+	// +optional
+	RolloutOnOutputsChange []OutputsConsumer `json:"rolloutOnOutputsChange,omitempty"`
+
 	// Disable automatic drift detection. Drift detection may be resource intensive in
 	// the context of a large cluster or complex Terraform statefile. Defaults to false.
 	// +kubebuilder:default:=false
 	// +optional
 	DisableDriftDetection bool `json:"disableDriftDetection,omitempty"`
 
+	// DriftPolicy decides, resource by resource, whether the drift is ignored,
+	// only reported, remediated automatically, or remediated once approved.
+	// Without it, the drift is remediated as per .spec.approvePlan.
+	// +optional
+	DriftPolicy *DriftPolicy `json:"driftPolicy,omitempty"`
+
 	// +optional
 	// PushSpec *PushSpec `json:"pushSpec,omitempty"`
 
@@ -275,6 +473,22 @@ type TerraformSpec struct {
 	// +optional
 	DependsOn []meta.NamespacedObjectReference `json:"dependsOn,omitempty"`
 
+	// DependsOnObjects is a list of Kubernetes objects of any kind, e.g. a Flux
+	// Kustomization or HelmRelease, that must be ready before this Terraform is
+	// reconciled. Their readiness is computed with kstatus, or with the CEL
+	// expression of readyExpr when it is set.
+	// +optional
+	DependsOnObjects []ObjectDependency `json:"dependsOnObjects,omitempty"`
+
+	// Priority of the reconciliations of this Terraform object in the queue of
+	// the controller, when the priority queue is enabled. The reconciliations
+	// of the objects with a higher priority are started first, e.g. to plan
+	// the production stacks ahead of the sandboxes when a source changes.
+	// +kubebuilder:validation:Minimum=-100
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
 	// Enterprise is the enterprise configuration placeholder.
 	// +optional
 	Enterprise *apiextensionsv1.JSON `json:"enterprise,omitempty"`
@@ -378,6 +592,11 @@ type PlanStatus struct {
 
 	// +optional
 	IsDriftDetectionPlan bool `json:"isDriftDetectionPlan,omitempty"`
+
+	// DriftAction is the action of the drift policy for the pending plan,
+	// when it remediates a drift.
+	// +optional
+	DriftAction DriftAction `json:"driftAction,omitempty"`
 }
 
 // TerraformStatus defines the observed state of Terraform
@@ -430,6 +649,11 @@ type TerraformStatus struct {
 	// +optional
 	LastAppliedByDriftDetectionAt *metav1.Time `json:"lastAppliedByDriftDetectionAt,omitempty"`
 
+	// DriftReport references the report of the drift found by the last drift
+	// detection. It is cleared when the drift detection finds no drift.
+	// +optional
+	DriftReport *DriftReportReference `json:"driftReport,omitempty"`
+
 	// +optional
 	AvailableOutputs []string `json:"availableOutputs,omitempty"`
 
@@ -443,12 +667,107 @@ type TerraformStatus struct {
 	// +optional
 	Lock LockStatus `json:"lock,omitempty"`
 
+	// StateMigration records the backend configuration the Terraform state was
+	// last initialized with, and any migration awaiting approval.
+	// +optional
+	StateMigration StateMigrationStatus `json:"stateMigration,omitempty"`
+
+	// StateBackup records the last backup and restore of the Terraform state.
+	// +optional
+	StateBackup StateBackupStatus `json:"stateBackup,omitempty"`
+
+	// StateOperation records the result of the last state operation.
+	// +optional
+	StateOperation StateOperationStatus `json:"stateOperation,omitempty"`
+
+	// Imports are the imports of .spec.imports which have been applied.
+	// +optional
+	Imports []ImportStatus `json:"imports,omitempty"`
+
+	// SourceRevisions are the revisions of the source and of the additional
+	// sources of the last attempted reconciliation, when additional sources are set.
+	// +optional
+	SourceRevisions []SourceRevision `json:"sourceRevisions,omitempty"`
+
+	// RemoteOutputs are the destinations of .spec.writeOutputsTo written to
+	// remote clusters, so that the ones removed from the spec are deleted.
+	// +optional
+	RemoteOutputs []RemoteOutputsReference `json:"remoteOutputs,omitempty"`
+
+	// OutputsHistory holds the hashes of the outputs for the last applied
+	// revisions, and when the outputs changed, most recent first.
+	// +optional
+	OutputsHistory []OutputsRevision `json:"outputsHistory,omitempty"`
+
+	// Variables are the variables declared by the module, as discovered before the last plan.
+	// +optional
+	Variables []VariableSchema `json:"variables,omitempty"`
+
+	// UpstreamOutputsHash is the hash of the outputs of the Terraform objects
+	// referenced by .spec.varsFrom, as they were when the variables were last
+	// generated. A change triggers a new plan.
+	// +optional
+	UpstreamOutputsHash string `json:"upstreamOutputsHash,omitempty"`
+
 	// ReconciliationFailures is the number of reconciliation
 	// failures since the last success or update.
 	// +optional
 	ReconciliationFailures int64 `json:"reconciliationFailures,omitempty"`
 }
 
+// OutputsConsumer selects the Deployments consuming outputs.
+type OutputsConsumer struct {
+	// Selector of the Deployments, in the namespace of the Terraform object.
+	// +required
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// Outputs the Deployments consume. Defaults to all outputs.
+	// +optional
+	Outputs []string `json:"outputs,omitempty"`
+}
+
+// OutputsRevision records the outputs at an applied revision.
+type OutputsRevision struct {
+	// Revision of the source the outputs were obtained at.
+	Revision string `json:"revision"`
+
+	// ObservedAt is when the outputs were obtained.
+	ObservedAt metav1.Time `json:"observedAt"`
+
+	// Hashes of the values of the outputs, by output name, keyed with a secret
+	// of the controller.
+	// +optional
+	Hashes map[string]string `json:"hashes,omitempty"`
+}
+
+// VariableSchema describes a variable declared by the module.
+type VariableSchema struct {
+	// Name of the variable.
+	Name string `json:"name"`
+
+	// Type constraint of the variable, as written in the module. Empty when not declared.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Default value of the variable. Omitted for sensitive variables.
+	// +optional
+	Default *apiextensionsv1.JSON `json:"default,omitempty"`
+
+	// Required is true when the variable has no default value.
+	// +optional
+	Required bool `json:"required,omitempty"`
+
+	// +optional
+	Sensitive bool `json:"sensitive,omitempty"`
+
+	// Validations is the number of validation rules of the variable.
+	// +optional
+	Validations int32 `json:"validations,omitempty"`
+}
+
 // LockStatus defines the observed state of a Terraform State Lock
 type LockStatus struct {
 	// +optional
@@ -459,6 +778,81 @@ type LockStatus struct {
 	Pending string `json:"pending,omitempty"`
 }
 
+// StateBackupStatus defines the observed state of the Terraform State Backups
+type StateBackupStatus struct {
+	// LastBackup is the name of the Secret holding the last state backup
+	// taken before apply.
+	// +optional
+	LastBackup string `json:"lastBackup,omitempty"`
+
+	// LastBackupAt is the time when the last state backup was taken.
+	// +optional
+	LastBackupAt *metav1.Time `json:"lastBackupAt,omitempty"`
+
+	// LastRestored is the name of the Secret holding the state backup
+	// the state was last restored from.
+	// +optional
+	LastRestored string `json:"lastRestored,omitempty"`
+
+	// LastRestoredAt is the time when the state was last restored.
+	// +optional
+	LastRestoredAt *metav1.Time `json:"lastRestoredAt,omitempty"`
+}
+
+// DriftReportReference references the Secret holding a drift report.
+type DriftReportReference struct {
+	// SecretName is the name of the Secret holding the report, in the
+	// namespace of the object.
+	SecretName string `json:"secretName"`
+
+	// Revision of the source the drift was detected at.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// DetectedAt is the time when the drift was detected.
+	// +optional
+	DetectedAt *metav1.Time `json:"detectedAt,omitempty"`
+
+	// DriftedResources is the number of resources in the report.
+	// +optional
+	DriftedResources int `json:"driftedResources,omitempty"`
+}
+
+// StateMigrationStatus defines the observed state of a Terraform State Migration
+type StateMigrationStatus struct {
+	// BackendConfigHash is the hash of the backend configuration the
+	// Terraform state was last initialized with.
+	// +optional
+	BackendConfigHash string `json:"backendConfigHash,omitempty"`
+
+	// BackendConfig is the backend configuration, in HCL, the Terraform state
+	// was last initialized with. It is used as the source of a state migration.
+	// +optional
+	BackendConfig string `json:"backendConfig,omitempty"`
+
+	// BackendConfigsFrom are the backend config references the Terraform state
+	// was last initialized with.
+	// +optional
+	BackendConfigsFrom []BackendConfigsReference `json:"backendConfigsFrom,omitempty"`
+
+	// Pending holds the identifier of the state migration awaiting approval.
+	// +optional
+	Pending string `json:"pending,omitempty"`
+
+	// LastApplied holds the identifier of the last completed state migration.
+	// +optional
+	LastApplied string `json:"lastApplied,omitempty"`
+
+	// LastMigratedAt is the time when the last state migration was completed.
+	// +optional
+	LastMigratedAt *metav1.Time `json:"lastMigratedAt,omitempty"`
+
+	// LastBackup is the name of the Secret holding the state backup taken
+	// before the last state migration.
+	// +optional
+	LastBackup string `json:"lastBackup,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=tf
 // +kubebuilder:subresource:status
@@ -509,6 +903,180 @@ type BackendConfigSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+// StateMigrationSpec allows the user to approve a state migration
+type StateMigrationSpec struct {
+	// Approve a pending state migration. Set this to the identifier reported in
+	// `.status.stateMigration.pending`, e.g. `migrate-1b2c3d4e5f`, to migrate the
+	// state of that backend change. Set this to `auto` to migrate the state as soon
+	// as a backend change is detected.
+	//
+	// The state is always backed up to a Secret before migrating.
+	//
+	// +optional
+	Approve string `json:"approve,omitempty"`
+}
+
+// StateBackupSpec configures the backups of the Terraform state taken before every apply
+type StateBackupSpec struct {
+	// Retain is the number of backups taken before apply to keep. Older backups
+	// are pruned after every new backup. Defaults to 5.
+	// +kubebuilder:default:=5
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	Retain int `json:"retain,omitempty"`
+
+	// Namespace of the Secrets storing the backups. Defaults to the namespace
+	// of the Terraform object.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ValidateSpec defines the validation of the module before planning.
+type ValidateSpec struct {
+	// SkipFormatCheck disables `terraform fmt -check`, so that only
+	// `terraform validate` is run.
+	// +optional
+	SkipFormatCheck bool `json:"skipFormatCheck,omitempty"`
+}
+
+// TestSpec defines the tests of the module run before planning.
+type TestSpec struct {
+	// Timeout of `terraform test`. Defaults to the timeout of the Terraform object.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// InlineSource holds the files of a module, in the object or in a ConfigMap.
+type InlineSource struct {
+	// Files of the module, such as main.tf or main.tf.json, by file name.
+	// +optional
+	Files map[string]string `json:"files,omitempty"`
+
+	// ConfigMapRef is a ConfigMap, in the namespace of the Terraform object,
+	// whose data keys are file names. Its files are written before Files.
+	// +optional
+	ConfigMapRef *meta.LocalObjectReference `json:"configMapRef,omitempty"`
+}
+
+// AdditionalSource is a source whose artifact is extracted into a
+// subdirectory of the working directory.
+type AdditionalSource struct {
+	// SourceRef is the reference of the source.
+	// +required
+	SourceRef CrossNamespaceSourceReference `json:"sourceRef"`
+
+	// Path of the subdirectory of the working directory the artifact is extracted to.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Path string `json:"path"`
+}
+
+// SourceRevision records the revision of a source.
+type SourceRevision struct {
+	// SourceRef is the reference of the source, empty for an inline source.
+	// +optional
+	SourceRef CrossNamespaceSourceReference `json:"sourceRef,omitzero"`
+
+	// Path the artifact was extracted to, empty for the source of the module.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Revision of the artifact.
+	Revision string `json:"revision"`
+}
+
+const (
+	GoTemplateEngine = "GoTemplate"
+	CUEEngine        = "CUE"
+	JsonnetEngine    = "Jsonnet"
+)
+
+// TemplatingSpec defines how the module sources are rendered.
+type TemplatingSpec struct {
+	// Engine rendering the sources. GoTemplate renders every *.tpl file of the
+	// module path to the file of the same name without the extension. CUE and
+	// Jsonnet evaluate a generator to a .tf.json file.
+	// +kubebuilder:validation:Enum=GoTemplate;CUE;Jsonnet
+	// +kubebuilder:default:=GoTemplate
+	// +optional
+	Engine string `json:"engine,omitempty"`
+
+	// LeftDelimiter of the Go templates. Defaults to {{.
+	// +optional
+	LeftDelimiter string `json:"leftDelimiter,omitempty"`
+
+	// RightDelimiter of the Go templates. Defaults to }}.
+	// +optional
+	RightDelimiter string `json:"rightDelimiter,omitempty"`
+
+	// Generator is the path of the CUE package or of the Jsonnet file, relative
+	// to the module path. Defaults to . for CUE, and to main.jsonnet for Jsonnet.
+	// +optional
+	Generator string `json:"generator,omitempty"`
+
+	// Expression selects the field of the CUE value to export. Defaults to the whole value.
+	// +optional
+	Expression string `json:"expression,omitempty"`
+
+	// Output is the name of the file generated by CUE or Jsonnet, in the module path.
+	// Defaults to generated.tf.json.
+	// +kubebuilder:validation:Pattern=`^[^/]+\.tf\.json$`
+	// +optional
+	Output string `json:"output,omitempty"`
+}
+
+// GetEngine returns the templating engine, defaulting to GoTemplate.
+func (in TemplatingSpec) GetEngine() string {
+	if in.Engine == "" {
+		return GoTemplateEngine
+	}
+	return in.Engine
+}
+
+// ImportSpec defines an existing resource to import into the Terraform state.
+type ImportSpec struct {
+	// Address is the resource address to import the resource to.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Address string `json:"address"`
+
+	// ID is the provider specific ID of the resource to import.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// IDFrom reads the ID of the resource to import from a Secret or a ConfigMap.
+	// Takes precedence over ID.
+	// +optional
+	IDFrom *ImportIDReference `json:"idFrom,omitempty"`
+}
+
+// MoveSpec defines a resource to move in the Terraform state.
+type MoveSpec struct {
+	// From is the previous address of the resource.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	From string `json:"from"`
+
+	// To is the new address of the resource.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	To string `json:"to"`
+}
+
+// ImportStatus records an import which has been applied.
+type ImportStatus struct {
+	// Address is the resource address the resource was imported to.
+	Address string `json:"address"`
+
+	// Revision is the source revision the import was applied with.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// ImportedAt is the time when the import was applied.
+	// +optional
+	ImportedAt *metav1.Time `json:"importedAt,omitempty"`
+}
+
 // TFStateSpec allows the user to set ForceUnlock
 type TFStateSpec struct {
 	// ForceUnlock a Terraform state if it has become locked for any reason. Defaults to `no`.
@@ -596,6 +1164,10 @@ const (
 	ApprovePlanAutoValue      = "auto"
 	ApprovePlanDisableValue   = "disable"
 	DefaultWorkspaceName      = "default"
+
+	ApproveStateMigrationAutoValue = "auto"
+	StateMigrationIDPrefix         = "migrate-"
+	DefaultStateBackupRetain       = 5
 )
 
 // Webhook stages
@@ -697,6 +1269,7 @@ func TerraformPlannedWithChanges(terraform *Terraform, revision string, forceOrA
 		Pending:              planId, // pending plan id is always the short plan format.
 		IsDestroyPlan:        terraform.Spec.Destroy,
 		IsDriftDetectionPlan: terraform.HasDrift(),
+		DriftAction:          terraform.Status.Plan.DriftAction,
 	}
 
 	if revision != "" {
@@ -782,6 +1355,41 @@ func TerraformNoDrift(terraform *Terraform, revision, reason, message string) *T
 	return terraform
 }
 
+// TerraformValidated will set the Validated condition on the Terraform resource
+// indicating that the module passed `terraform validate` and `terraform fmt -check`.
+func TerraformValidated(terraform *Terraform, message string) *Terraform {
+	conditions.MarkTrue(terraform, ConditionTypeValidated, ValidationSucceededReason, "%s", trimString(message, MaxConditionMessageLength))
+	return terraform
+}
+
+// TerraformValidationFailed will set the Validated condition on the Terraform resource
+// to false, and mark the resource as not ready as the plan is blocked.
+func TerraformValidationFailed(terraform *Terraform, revision, message string) *Terraform {
+	conditions.MarkFalse(terraform, ConditionTypeValidated, ValidationFailedReason, "%s", trimString(message, MaxConditionMessageLength))
+	return TerraformNotReady(terraform, revision, ValidationFailedReason, message)
+}
+
+// TerraformVariablesInvalid will set the VariablesInvalid condition on the Terraform
+// resource, and mark the resource as not ready as the plan is blocked.
+func TerraformVariablesInvalid(terraform *Terraform, revision, message string) *Terraform {
+	conditions.MarkTrue(terraform, ConditionTypeVariablesInvalid, VariablesInvalidReason, "%s", trimString(message, MaxConditionMessageLength))
+	return TerraformNotReady(terraform, revision, VariablesInvalidReason, message)
+}
+
+// TerraformTested will set the Tested condition on the Terraform resource
+// indicating that the tests of the module passed.
+func TerraformTested(terraform *Terraform, message string) *Terraform {
+	conditions.MarkTrue(terraform, ConditionTypeTested, TestsPassedReason, "%s", trimString(message, MaxConditionMessageLength))
+	return terraform
+}
+
+// TerraformTestsFailed will set the Tested condition on the Terraform resource
+// to false, and mark the resource as not ready as the plan is blocked.
+func TerraformTestsFailed(terraform *Terraform, revision, message string) *Terraform {
+	conditions.MarkFalse(terraform, ConditionTypeTested, TestsFailedReason, "%s", trimString(message, MaxConditionMessageLength))
+	return TerraformNotReady(terraform, revision, TestsFailedReason, message)
+}
+
 func TerraformHealthCheckFailed(terraform *Terraform, message string) *Terraform {
 	conditions.MarkFalse(terraform, ConditionTypeHealthCheck, HealthChecksFailedReason, "%s", trimString(message, MaxConditionMessageLength))
 	return terraform
@@ -829,6 +1437,44 @@ func TerraformStateLockReleased(terraform *Terraform) *Terraform {
 	return terraform
 }
 
+// TerraformStateMigrationPending will set a new condition on the Terraform resource
+// indicating that the backend configuration has changed, and the state migration
+// is awaiting approval.
+func TerraformStateMigrationPending(terraform *Terraform, revision, migrationID, message string) *Terraform {
+	msg := trimString(message, MaxConditionMessageLength)
+	conditions.MarkTrue(terraform, ConditionTypeStateMigrationPending, BackendConfigChangedReason, "%s", msg)
+	SetTerraformReadiness(terraform, metav1.ConditionUnknown, StateMigrationPendingReason, msg, revision)
+
+	terraform.Status.StateMigration.Pending = migrationID
+	return terraform
+}
+
+// TerraformBackendInitialized records the backend configuration the Terraform state
+// has been initialized with, clearing any pending state migration.
+func TerraformBackendInitialized(terraform *Terraform, backendConfigHash, backendConfig string) *Terraform {
+	conditions.Delete(terraform, ConditionTypeStateMigrationPending)
+
+	terraform.Status.StateMigration.BackendConfigHash = backendConfigHash
+	terraform.Status.StateMigration.BackendConfig = backendConfig
+	terraform.Status.StateMigration.BackendConfigsFrom = nil
+	for _, ref := range terraform.Spec.BackendConfigsFrom {
+		terraform.Status.StateMigration.BackendConfigsFrom = append(terraform.Status.StateMigration.BackendConfigsFrom, *ref.DeepCopy())
+	}
+	terraform.Status.StateMigration.Pending = ""
+	return terraform
+}
+
+// TerraformStateMigrated will set the state migration status on the Terraform resource
+// indicating that the state has been migrated to the current backend configuration.
+func TerraformStateMigrated(terraform *Terraform, migrationID, backendConfigHash, backendConfig, backupName string) *Terraform {
+	TerraformBackendInitialized(terraform, backendConfigHash, backendConfig)
+
+	terraform.Status.StateMigration.LastApplied = migrationID
+	terraform.Status.StateMigration.LastMigratedAt = &metav1.Time{Time: time.Now()}
+	terraform.Status.StateMigration.LastBackup = backupName
+	return terraform
+}
+
 // TerraformReachedLimit will set a new condition on the Terraform resource
 // indicating that the resource has reached its retry limit.
 func TerraformReachedLimit(terraform *Terraform) *Terraform {
@@ -837,6 +1483,31 @@ func TerraformReachedLimit(terraform *Terraform) *Terraform {
 	return terraform
 }
 
+// TerraformDependencyCycle marks the Terraform resource as stalled, as it
+// cannot become ready until the dependency cycle is broken.
+func TerraformDependencyCycle(terraform *Terraform, revision, message string) *Terraform {
+	conditions.MarkStalled(terraform, DependencyCycleDetectedReason, "%s", trimString(message, MaxConditionMessageLength))
+	TerraformNotReady(terraform, revision, DependencyCycleDetectedReason, message)
+
+	return terraform
+}
+
+// TerraformRolloutPending registers that the given Terraform waits for its
+// turn in the rollout of a new source revision. The revision is not
+// attempted yet, so the last attempted revision is left untouched.
+func TerraformRolloutPending(terraform *Terraform, message string) *Terraform {
+	conditions.MarkUnknown(terraform, meta.ReadyCondition, RolloutPendingReason, "%s", trimString(message, MaxConditionMessageLength))
+	return terraform
+}
+
+// TerraformRolloutHalted registers that the rollout of a new source revision
+// to the given Terraform was halted by a failure of another Terraform.
+func TerraformRolloutHalted(terraform *Terraform, message string) *Terraform {
+	conditions.MarkFalse(terraform, meta.ReadyCondition, RolloutHaltedReason, "%s", trimString(message, MaxConditionMessageLength))
+	conditions.Delete(terraform, meta.ReconcilingCondition)
+	return terraform
+}
+
 // TerraformResetRetry will set a new condition on the Terraform resource
 // indicating that the resource retry count has been reset.
 func TerraformResetRetry(terraform *Terraform) *Terraform {
@@ -846,6 +1517,69 @@ func TerraformResetRetry(terraform *Terraform) *Terraform {
 	return terraform
 }
 
+// StateMigrationID returns the identifier of a state migration to the
+// backend configuration with the given hash.
+func StateMigrationID(backendConfigHash string) string {
+	if len(backendConfigHash) > 10 {
+		backendConfigHash = backendConfigHash[:10]
+	}
+	return StateMigrationIDPrefix + backendConfigHash
+}
+
+// PendingImports returns the imports of .spec.imports which have not been applied yet.
+func (in Terraform) PendingImports() []ImportSpec {
+	applied := map[string]bool{}
+	for _, imported := range in.Status.Imports {
+		applied[imported.Address] = true
+	}
+
+	var pending []ImportSpec
+	for _, spec := range in.Spec.Imports {
+		if !applied[spec.Address] {
+			pending = append(pending, spec)
+		}
+	}
+
+	return pending
+}
+
+// TerraformImportsApplied records the pending imports at the given addresses
+// as applied, the other ones staying pending. Imports removed from
+// .spec.imports are removed from the status.
+func TerraformImportsApplied(terraform *Terraform, revision string, addresses []string) *Terraform {
+	applied := map[string]bool{}
+	for _, address := range addresses {
+		applied[address] = true
+	}
+
+	wanted := map[string]bool{}
+	for _, spec := range terraform.Spec.Imports {
+		wanted[spec.Address] = true
+	}
+
+	var imports []ImportStatus
+	for _, imported := range terraform.Status.Imports {
+		if wanted[imported.Address] {
+			imports = append(imports, imported)
+		}
+	}
+
+	now := metav1.Now()
+	for _, spec := range terraform.PendingImports() {
+		if !applied[spec.Address] {
+			continue
+		}
+		imports = append(imports, ImportStatus{
+			Address:    spec.Address,
+			Revision:   revision,
+			ImportedAt: &now,
+		})
+	}
+
+	terraform.Status.Imports = imports
+	return terraform
+}
+
 // HasDrift returns true if drift has been detected since the last successful apply
 func (in Terraform) HasDrift() bool {
 	for _, condition := range in.Status.Conditions {
@@ -859,7 +1593,9 @@ func (in Terraform) HasDrift() bool {
 	return false
 }
 
-// GetDependsOn returns the list of dependencies, namespace scoped.
+// GetDependsOn returns the list of dependencies, namespace scoped. It includes
+// the Terraform objects referenced by .spec.varsFrom, which are implicit
+// dependencies unless the reference is optional.
 //
 // Spec.DependsOn is deliberately kept as []meta.NamespacedObjectReference and
 // converted here. fluxcd/pkg/runtime v0.111.0 requires Dependent to return
@@ -868,12 +1604,51 @@ func (in Terraform) HasDrift() bool {
 // advertise a field that does nothing. Change the spec type only alongside
 // implementing ReadyExpr.
 func (in Terraform) GetDependsOn() []meta.DependencyReference {
-	refs := make([]meta.DependencyReference, len(in.Spec.DependsOn))
-	for i, d := range in.Spec.DependsOn {
-		refs[i] = meta.DependencyReference{
+	refs := make([]meta.DependencyReference, 0, len(in.Spec.DependsOn))
+	seen := map[meta.DependencyReference]bool{}
+	add := func(ref meta.DependencyReference) {
+		key := ref
+		if key.Namespace == "" {
+			key.Namespace = in.GetNamespace()
+		}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		refs = append(refs, ref)
+	}
+
+	for _, d := range in.Spec.DependsOn {
+		add(meta.DependencyReference{
 			Name:      d.Name,
 			Namespace: d.Namespace,
+		})
+	}
+	for _, vars := range in.Spec.VarsFrom {
+		if vars.Kind != TerraformKind || vars.Optional {
+			continue
 		}
+		add(meta.DependencyReference{
+			Name:      vars.Name,
+			Namespace: vars.Namespace,
+		})
+	}
+	return refs
+}
+
+// GetVarsFromTerraform returns the references of .spec.varsFrom to other
+// Terraform objects, with their namespace defaulted.
+func (in Terraform) GetVarsFromTerraform() []types.NamespacedName {
+	var refs []types.NamespacedName
+	for _, vars := range in.Spec.VarsFrom {
+		if vars.Kind != TerraformKind {
+			continue
+		}
+		namespace := vars.Namespace
+		if namespace == "" {
+			namespace = in.GetNamespace()
+		}
+		refs = append(refs, types.NamespacedName{Namespace: namespace, Name: vars.Name})
 	}
 	return refs
 }
@@ -977,6 +1752,22 @@ func (in *Terraform) ShouldRetry() bool {
 	return in.GetReconciliationFailures() < in.Spec.Remediation.Retries
 }
 
+// GetStateBackupNamespace returns the namespace storing the state backups
+func (in Terraform) GetStateBackupNamespace() string {
+	if in.Spec.StateBackup != nil && in.Spec.StateBackup.Namespace != "" {
+		return in.Spec.StateBackup.Namespace
+	}
+	return in.Namespace
+}
+
+// GetStateBackupRetain returns the number of state backups taken before apply to keep
+func (in Terraform) GetStateBackupRetain() int {
+	if in.Spec.StateBackup != nil && in.Spec.StateBackup.Retain > 0 {
+		return in.Spec.StateBackup.Retain
+	}
+	return DefaultStateBackupRetain
+}
+
 func (in *TerraformSpec) GetAlwaysCleanupRunnerPod() bool {
 	if in.AlwaysCleanupRunnerPod == nil {
 		return true
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fluxcd/pkg/apis/meta"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -79,3 +80,69 @@ func TestGetRetryInterval(t *testing.T) {
 		})
 	}
 }
+
+func TestTerraformImportsApplied(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	terraform := &Terraform{
+		Spec: TerraformSpec{
+			Imports: []ImportSpec{
+				{Address: "aws_instance.web", ID: "i-0123456789abcdef0"},
+				{Address: "aws_s3_bucket.logs", ID: "logs"},
+			},
+		},
+		Status: TerraformStatus{
+			Imports: []ImportStatus{
+				{Address: "aws_s3_bucket.logs", Revision: "main@sha1:1"},
+				{Address: "aws_iam_role.removed", Revision: "main@sha1:1"},
+			},
+		},
+	}
+
+	g.Expect(terraform.PendingImports()).To(Equal([]ImportSpec{
+		{Address: "aws_instance.web", ID: "i-0123456789abcdef0"},
+	}))
+
+	// an import left out of the applied plan stays pending
+	TerraformImportsApplied(terraform, "main@sha1:2", nil)
+	g.Expect(terraform.PendingImports()).To(HaveLen(1))
+	g.Expect(terraform.Status.Imports).To(HaveLen(1))
+
+	TerraformImportsApplied(terraform, "main@sha1:2", []string{"aws_instance.web"})
+	g.Expect(terraform.PendingImports()).To(BeEmpty())
+	g.Expect(terraform.Status.Imports).To(HaveLen(2))
+	g.Expect(terraform.Status.Imports[0].Address).To(Equal("aws_s3_bucket.logs"))
+	g.Expect(terraform.Status.Imports[0].Revision).To(Equal("main@sha1:1"))
+	g.Expect(terraform.Status.Imports[1].Address).To(Equal("aws_instance.web"))
+	g.Expect(terraform.Status.Imports[1].Revision).To(Equal("main@sha1:2"))
+	g.Expect(terraform.Status.Imports[1].ImportedAt).ToNot(BeNil())
+
+	// removing all the imports from the spec clears the status
+	terraform.Spec.Imports = nil
+	TerraformImportsApplied(terraform, "main@sha1:3", nil)
+	g.Expect(terraform.Status.Imports).To(BeEmpty())
+}
+
+func TestGetDependsOnIncludesVarsFromTerraform(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	terraform := Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "apps"},
+		Spec: TerraformSpec{
+			DependsOn: []meta.NamespacedObjectReference{
+				{Name: "database"},
+			},
+			VarsFrom: []VarsReference{
+				{Kind: "Secret", Name: "credentials"},
+				{Kind: TerraformKind, Name: "database", Namespace: "apps"},
+				{Kind: TerraformKind, Name: "network", Namespace: "infra"},
+				{Kind: TerraformKind, Name: "cache", Optional: true},
+			},
+		},
+	}
+
+	g.Expect(terraform.GetDependsOn()).To(Equal([]meta.DependencyReference{
+		{Name: "database"},
+		{Name: "network", Namespace: "infra"},
+	}))
+}
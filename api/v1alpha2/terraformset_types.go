@@ -0,0 +1,250 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	TerraformSetKind = "TerraformSet"
+
+	// TerraformSetLabel is set on the Terraform objects generated by a
+	// TerraformSet, with the name of the TerraformSet.
+	TerraformSetLabel = "infra.contrib.fluxcd.io/terraformset"
+)
+
+// TerraformSetSpec defines the desired state of TerraformSet
+type TerraformSetSpec struct {
+	// Generators produce the parameters of the generated Terraform objects.
+	// One Terraform object is generated for every set of parameters of every
+	// generator.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Generators []TerraformSetGenerator `json:"generators"`
+
+	// Template of the generated Terraform objects. Its string fields are Go
+	// templates, rendered with the parameters of every generated object and
+	// the hermetic Sprig functions.
+	// +required
+	Template TerraformSetTemplate `json:"template"`
+
+	// LeftDelimiter of the template actions. Defaults to "{{". Set the
+	// delimiters to keep the ${{ }} templates of .spec.values intact.
+	// +optional
+	LeftDelimiter string `json:"leftDelimiter,omitempty"`
+
+	// RightDelimiter of the template actions. Defaults to "}}".
+	// +optional
+	RightDelimiter string `json:"rightDelimiter,omitempty"`
+
+	// Interval at which the generators are evaluated again, to pick up
+	// changes of the ConfigMaps, Secrets and Namespaces they read.
+	// +kubebuilder:default:="5m"
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// Prune deletes the generated Terraform objects that are no longer
+	// generated. Defaults to true.
+	// +kubebuilder:default:=true
+	// +optional
+	Prune bool `json:"prune"`
+
+	// Suspend the generation of the Terraform objects.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// TerraformSetGenerator produces sets of parameters. Exactly one of its
+// fields must be set.
+// +kubebuilder:validation:XValidation:rule="[has(self.list), has(self.configMap), has(self.secret), has(self.namespaces), has(self.clusters)].filter(x, x).size() == 1",message="exactly one generator must be set"
+type TerraformSetGenerator struct {
+	// List generates one Terraform object per element.
+	// +optional
+	List *ListGenerator `json:"list,omitempty"`
+
+	// ConfigMap generates one Terraform object per key of a ConfigMap.
+	// +optional
+	ConfigMap *DataGenerator `json:"configMap,omitempty"`
+
+	// Secret generates one Terraform object per key of a Secret, with the
+	// parameters .key and .secret, the name of the Secret. The values are not
+	// exposed to the template, read them with varsFrom.
+	// +optional
+	Secret *DataGenerator `json:"secret,omitempty"`
+
+	// Namespaces generates one Terraform object per selected Namespace.
+	// +optional
+	Namespaces *SelectorGenerator `json:"namespaces,omitempty"`
+
+	// Clusters generates one Terraform object per selected kubeconfig
+	// Secret, in the namespace of the TerraformSet.
+	// +optional
+	Clusters *SelectorGenerator `json:"clusters,omitempty"`
+}
+
+// ListGenerator generates the parameters listed in its elements.
+type ListGenerator struct {
+	// Elements are JSON objects, whose fields are the parameters.
+	// +required
+	Elements []apiextensionsv1.JSON `json:"elements"`
+}
+
+// DataGenerator generates parameters from every key of the data of a
+// ConfigMap or a Secret. For a ConfigMap, these are .key and .value, and a
+// value holding a JSON object is decoded, so its fields can be used as
+// .value.<field>.
+type DataGenerator struct {
+	// Name of the ConfigMap or the Secret, in the namespace of the TerraformSet.
+	// +required
+	Name string `json:"name"`
+}
+
+// SelectorGenerator generates the parameters .name, .labels and .annotations
+// from every selected object.
+type SelectorGenerator struct {
+	// Selector of the objects. An empty selector selects all the objects.
+	// +optional
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// TerraformSetTemplate is the template of the generated Terraform objects.
+type TerraformSetTemplate struct {
+	// +required
+	Metadata TerraformSetTemplateMeta `json:"metadata"`
+
+	// +required
+	Spec TerraformSpec `json:"spec"`
+}
+
+// TerraformSetTemplateMeta is the metadata of the generated Terraform
+// objects. They are generated in the namespace of the TerraformSet.
+type TerraformSetTemplateMeta struct {
+	// Name of the generated objects. It must render to a different name for
+	// every set of parameters, e.g. "app-{{ .region }}".
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// TerraformSetStatus defines the observed state of TerraformSet
+type TerraformSetStatus struct {
+	meta.ReconcileRequestStatus `json:",inline"`
+
+	// ObservedGeneration is the last reconciled generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Terraforms are the generated Terraform objects, with their readiness.
+	// +optional
+	Terraforms []TerraformSetResource `json:"terraforms,omitempty"`
+
+	// ReadyCount is the number of generated Terraform objects that are ready,
+	// displayed as ready/total.
+	// +optional
+	ReadyCount string `json:"readyCount,omitempty"`
+}
+
+// TerraformSetResource is the status of a generated Terraform object.
+type TerraformSetResource struct {
+	Name string `json:"name"`
+
+	// Ready is the status of the Ready condition of the object.
+	Ready metav1.ConditionStatus `json:"ready"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=tfset
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Terraforms",type="string",JSONPath=".status.readyCount",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// TerraformSet is the Schema for the terraformsets API
+type TerraformSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TerraformSetSpec `json:"spec,omitempty"`
+	// +kubebuilder:default={"observedGeneration":-1}
+	Status TerraformSetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TerraformSetList contains a list of TerraformSet
+type TerraformSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TerraformSet `json:"items"`
+}
+
+// GetInterval returns the interval at which the generators are evaluated.
+func (in TerraformSet) GetInterval() time.Duration {
+	if in.Spec.Interval.Duration <= 0 {
+		return 5 * time.Minute
+	}
+	return in.Spec.Interval.Duration
+}
+
+// GetDelimiters returns the delimiters of the template actions.
+func (in TerraformSet) GetDelimiters() (string, string) {
+	left, right := in.Spec.LeftDelimiter, in.Spec.RightDelimiter
+	if left == "" {
+		left = "{{"
+	}
+	if right == "" {
+		right = "}}"
+	}
+	return left, right
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice.
+func (in *TerraformSet) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// GetConditions returns the status conditions of the object.
+func (in TerraformSet) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions on the object.
+func (in *TerraformSet) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&TerraformSet{}, &TerraformSetList{})
+}
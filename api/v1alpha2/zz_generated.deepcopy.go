@@ -28,6 +28,22 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalSource) DeepCopyInto(out *AdditionalSource) {
+	*out = *in
+	out.SourceRef = in.SourceRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalSource.
+func (in *AdditionalSource) DeepCopy() *AdditionalSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendConfigSpec) DeepCopyInto(out *BackendConfigSpec) {
 	*out = *in
@@ -140,6 +156,92 @@ func (in *CrossNamespaceSourceReference) DeepCopy() *CrossNamespaceSourceReferen
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataGenerator) DeepCopyInto(out *DataGenerator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataGenerator.
+func (in *DataGenerator) DeepCopy() *DataGenerator {
+	if in == nil {
+		return nil
+	}
+	out := new(DataGenerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftPolicy) DeepCopyInto(out *DriftPolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]DriftRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftPolicy.
+func (in *DriftPolicy) DeepCopy() *DriftPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftReportReference) DeepCopyInto(out *DriftReportReference) {
+	*out = *in
+	if in.DetectedAt != nil {
+		in, out := &in.DetectedAt, &out.DetectedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftReportReference.
+func (in *DriftReportReference) DeepCopy() *DriftReportReference {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftReportReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftRule) DeepCopyInto(out *DriftRule) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreAttributes != nil {
+		in, out := &in.IgnoreAttributes, &out.IgnoreAttributes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftRule.
+func (in *DriftRule) DeepCopy() *DriftRule {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileMapping) DeepCopyInto(out *FileMapping) {
 	*out = *in
@@ -156,6 +258,31 @@ func (in *FileMapping) DeepCopy() *FileMapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPVarsSource) DeepCopyInto(out *HTTPVarsSource) {
+	*out = *in
+	if in.HeadersSecretRef != nil {
+		in, out := &in.HeadersSecretRef, &out.HeadersSecretRef
+		*out = new(meta.LocalObjectReference)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPVarsSource.
+func (in *HTTPVarsSource) DeepCopy() *HTTPVarsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPVarsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
 	*out = *in
@@ -177,123 +304,177 @@ func (in *HealthCheck) DeepCopy() *HealthCheck {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LockStatus) DeepCopyInto(out *LockStatus) {
+func (in *ImportIDReference) DeepCopyInto(out *ImportIDReference) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LockStatus.
-func (in *LockStatus) DeepCopy() *LockStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportIDReference.
+func (in *ImportIDReference) DeepCopy() *ImportIDReference {
 	if in == nil {
 		return nil
 	}
-	out := new(LockStatus)
+	out := new(ImportIDReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PlanSpec) DeepCopyInto(out *PlanSpec) {
+func (in *ImportSpec) DeepCopyInto(out *ImportSpec) {
 	*out = *in
-	if in.Lock != nil {
-		in, out := &in.Lock, &out.Lock
-		*out = new(bool)
+	if in.IDFrom != nil {
+		in, out := &in.IDFrom, &out.IDFrom
+		*out = new(ImportIDReference)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanSpec.
-func (in *PlanSpec) DeepCopy() *PlanSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportSpec.
+func (in *ImportSpec) DeepCopy() *ImportSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PlanSpec)
+	out := new(ImportSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PlanStatus) DeepCopyInto(out *PlanStatus) {
+func (in *ImportStatus) DeepCopyInto(out *ImportStatus) {
 	*out = *in
+	if in.ImportedAt != nil {
+		in, out := &in.ImportedAt, &out.ImportedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanStatus.
-func (in *PlanStatus) DeepCopy() *PlanStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportStatus.
+func (in *ImportStatus) DeepCopy() *ImportStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PlanStatus)
+	out := new(ImportStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReadInputsFromSecretSpec) DeepCopyInto(out *ReadInputsFromSecretSpec) {
+func (in *InlineSource) DeepCopyInto(out *InlineSource) {
 	*out = *in
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(meta.LocalObjectReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadInputsFromSecretSpec.
-func (in *ReadInputsFromSecretSpec) DeepCopy() *ReadInputsFromSecretSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InlineSource.
+func (in *InlineSource) DeepCopy() *InlineSource {
 	if in == nil {
 		return nil
 	}
-	out := new(ReadInputsFromSecretSpec)
+	out := new(InlineSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Remediation) DeepCopyInto(out *Remediation) {
+func (in *KubeConfigReference) DeepCopyInto(out *KubeConfigReference) {
 	*out = *in
+	out.SecretRef = in.SecretRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Remediation.
-func (in *Remediation) DeepCopy() *Remediation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeConfigReference.
+func (in *KubeConfigReference) DeepCopy() *KubeConfigReference {
 	if in == nil {
 		return nil
 	}
-	out := new(Remediation)
+	out := new(KubeConfigReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceInventory) DeepCopyInto(out *ResourceInventory) {
+func (in *ListGenerator) DeepCopyInto(out *ListGenerator) {
 	*out = *in
-	if in.Entries != nil {
-		in, out := &in.Entries, &out.Entries
-		*out = make([]ResourceRef, len(*in))
-		copy(*out, *in)
+	if in.Elements != nil {
+		in, out := &in.Elements, &out.Elements
+		*out = make([]apiextensionsv1.JSON, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceInventory.
-func (in *ResourceInventory) DeepCopy() *ResourceInventory {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListGenerator.
+func (in *ListGenerator) DeepCopy() *ListGenerator {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceInventory)
+	out := new(ListGenerator)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceRef) DeepCopyInto(out *ResourceRef) {
+func (in *LockStatus) DeepCopyInto(out *LockStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRef.
-func (in *ResourceRef) DeepCopy() *ResourceRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LockStatus.
+func (in *LockStatus) DeepCopy() *LockStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceRef)
+	out := new(LockStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RunnerPodMetadata) DeepCopyInto(out *RunnerPodMetadata) {
+func (in *MoveSpec) DeepCopyInto(out *MoveSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MoveSpec.
+func (in *MoveSpec) DeepCopy() *MoveSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MoveSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectDependency) DeepCopyInto(out *ObjectDependency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectDependency.
+func (in *ObjectDependency) DeepCopy() *ObjectDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputDestination) DeepCopyInto(out *OutputDestination) {
 	*out = *in
+	if in.KubeConfig != nil {
+		in, out := &in.KubeConfig, &out.KubeConfig
+		*out = new(KubeConfigReference)
+		**out = **in
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -308,194 +489,894 @@ func (in *RunnerPodMetadata) DeepCopyInto(out *RunnerPodMetadata) {
 			(*out)[key] = val
 		}
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodMetadata.
-func (in *RunnerPodMetadata) DeepCopy() *RunnerPodMetadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputDestination.
+func (in *OutputDestination) DeepCopy() *OutputDestination {
 	if in == nil {
 		return nil
 	}
-	out := new(RunnerPodMetadata)
+	out := new(OutputDestination)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RunnerPodSpec) DeepCopyInto(out *RunnerPodSpec) {
+func (in *OutputsConsumer) DeepCopyInto(out *OutputsConsumer) {
 	*out = *in
-	if in.EnvFrom != nil {
-		in, out := &in.EnvFrom, &out.EnvFrom
-		*out = make([]corev1.EnvFromSource, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]corev1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputsConsumer.
+func (in *OutputsConsumer) DeepCopy() *OutputsConsumer {
+	if in == nil {
+		return nil
 	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
+	out := new(OutputsConsumer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputsRevision) DeepCopyInto(out *OutputsRevision) {
+	*out = *in
+	in.ObservedAt.DeepCopyInto(&out.ObservedAt)
+	if in.Hashes != nil {
+		in, out := &in.Hashes, &out.Hashes
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(corev1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputsRevision.
+func (in *OutputsRevision) DeepCopy() *OutputsRevision {
+	if in == nil {
+		return nil
 	}
-	if in.VolumeMounts != nil {
-		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]corev1.VolumeMount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(OutputsRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanSpec) DeepCopyInto(out *PlanSpec) {
+	*out = *in
+	if in.Lock != nil {
+		in, out := &in.Lock, &out.Lock
+		*out = new(bool)
+		**out = **in
 	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]corev1.Volume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanSpec.
+func (in *PlanSpec) DeepCopy() *PlanSpec {
+	if in == nil {
+		return nil
 	}
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
+	out := new(PlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanStatus) DeepCopyInto(out *PlanStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanStatus.
+func (in *PlanStatus) DeepCopy() *PlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadInputsFromSecretSpec) DeepCopyInto(out *ReadInputsFromSecretSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadInputsFromSecretSpec.
+func (in *ReadInputsFromSecretSpec) DeepCopy() *ReadInputsFromSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadInputsFromSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Remediation) DeepCopyInto(out *Remediation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Remediation.
+func (in *Remediation) DeepCopy() *Remediation {
+	if in == nil {
+		return nil
+	}
+	out := new(Remediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteOutputsReference) DeepCopyInto(out *RemoteOutputsReference) {
+	*out = *in
+	out.KubeConfig = in.KubeConfig
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteOutputsReference.
+func (in *RemoteOutputsReference) DeepCopy() *RemoteOutputsReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteOutputsReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceInventory) DeepCopyInto(out *ResourceInventory) {
+	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]ResourceRef, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceInventory.
+func (in *ResourceInventory) DeepCopy() *ResourceInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRef) DeepCopyInto(out *ResourceRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRef.
+func (in *ResourceRef) DeepCopy() *ResourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutPolicy) DeepCopyInto(out *RolloutPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPolicy.
+func (in *RolloutPolicy) DeepCopy() *RolloutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RolloutPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutPolicyList) DeepCopyInto(out *RolloutPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RolloutPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPolicyList.
+func (in *RolloutPolicyList) DeepCopy() *RolloutPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RolloutPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutPolicySpec) DeepCopyInto(out *RolloutPolicySpec) {
+	*out = *in
+	out.SourceRef = in.SourceRef
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]RolloutWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPolicySpec.
+func (in *RolloutPolicySpec) DeepCopy() *RolloutPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutSourceReference) DeepCopyInto(out *RolloutSourceReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutSourceReference.
+func (in *RolloutSourceReference) DeepCopy() *RolloutSourceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutSourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutWave) DeepCopyInto(out *RolloutWave) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutWave.
+func (in *RolloutWave) DeepCopy() *RolloutWave {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPodMetadata) DeepCopyInto(out *RunnerPodMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodMetadata.
+func (in *RunnerPodMetadata) DeepCopy() *RunnerPodMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPodMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPodSpec) DeepCopyInto(out *RunnerPodSpec) {
+	*out = *in
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
 		*out = make([]corev1.Container, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.HostAliases != nil {
-		in, out := &in.HostAliases, &out.HostAliases
-		*out = make([]corev1.HostAlias, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodSpec.
+func (in *RunnerPodSpec) DeepCopy() *RunnerPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPodTemplate) DeepCopyInto(out *RunnerPodTemplate) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodTemplate.
+func (in *RunnerPodTemplate) DeepCopy() *RunnerPodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorGenerator) DeepCopyInto(out *SelectorGenerator) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorGenerator.
+func (in *SelectorGenerator) DeepCopy() *SelectorGenerator {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorGenerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceRevision) DeepCopyInto(out *SourceRevision) {
+	*out = *in
+	out.SourceRef = in.SourceRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceRevision.
+func (in *SourceRevision) DeepCopy() *SourceRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateBackupSpec) DeepCopyInto(out *StateBackupSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateBackupSpec.
+func (in *StateBackupSpec) DeepCopy() *StateBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StateBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateBackupStatus) DeepCopyInto(out *StateBackupStatus) {
+	*out = *in
+	if in.LastBackupAt != nil {
+		in, out := &in.LastBackupAt, &out.LastBackupAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRestoredAt != nil {
+		in, out := &in.LastRestoredAt, &out.LastRestoredAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateBackupStatus.
+func (in *StateBackupStatus) DeepCopy() *StateBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StateBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateMigrationSpec) DeepCopyInto(out *StateMigrationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateMigrationSpec.
+func (in *StateMigrationSpec) DeepCopy() *StateMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StateMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateMigrationStatus) DeepCopyInto(out *StateMigrationStatus) {
+	*out = *in
+	if in.BackendConfigsFrom != nil {
+		in, out := &in.BackendConfigsFrom, &out.BackendConfigsFrom
+		*out = make([]BackendConfigsReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastMigratedAt != nil {
+		in, out := &in.LastMigratedAt, &out.LastMigratedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateMigrationStatus.
+func (in *StateMigrationStatus) DeepCopy() *StateMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StateMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateOperation) DeepCopyInto(out *StateOperation) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateOperation.
+func (in *StateOperation) DeepCopy() *StateOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(StateOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateOperationStatus) DeepCopyInto(out *StateOperationStatus) {
+	*out = *in
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(StateOperation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateOperationStatus.
+func (in *StateOperationStatus) DeepCopy() *StateOperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StateOperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TFStateSpec) DeepCopyInto(out *TFStateSpec) {
+	*out = *in
+	out.LockTimeout = in.LockTimeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TFStateSpec.
+func (in *TFStateSpec) DeepCopy() *TFStateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TFStateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplatingSpec) DeepCopyInto(out *TemplatingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplatingSpec.
+func (in *TemplatingSpec) DeepCopy() *TemplatingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplatingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Terraform) DeepCopyInto(out *Terraform) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Terraform.
+func (in *Terraform) DeepCopy() *Terraform {
+	if in == nil {
+		return nil
+	}
+	out := new(Terraform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Terraform) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformList) DeepCopyInto(out *TerraformList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Terraform, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformList.
+func (in *TerraformList) DeepCopy() *TerraformList {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformSet) DeepCopyInto(out *TerraformSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformSet.
+func (in *TerraformSet) DeepCopy() *TerraformSet {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformSetGenerator) DeepCopyInto(out *TerraformSetGenerator) {
+	*out = *in
+	if in.List != nil {
+		in, out := &in.List, &out.List
+		*out = new(ListGenerator)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(DataGenerator)
+		**out = **in
 	}
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(corev1.SecurityContext)
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(DataGenerator)
+		**out = **in
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(SelectorGenerator)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = new(SelectorGenerator)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodSpec.
-func (in *RunnerPodSpec) DeepCopy() *RunnerPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformSetGenerator.
+func (in *TerraformSetGenerator) DeepCopy() *TerraformSetGenerator {
 	if in == nil {
 		return nil
 	}
-	out := new(RunnerPodSpec)
+	out := new(TerraformSetGenerator)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RunnerPodTemplate) DeepCopyInto(out *RunnerPodTemplate) {
+func (in *TerraformSetList) DeepCopyInto(out *TerraformSetList) {
 	*out = *in
-	in.Metadata.DeepCopyInto(&out.Metadata)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TerraformSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodTemplate.
-func (in *RunnerPodTemplate) DeepCopy() *RunnerPodTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformSetList.
+func (in *TerraformSetList) DeepCopy() *TerraformSetList {
 	if in == nil {
 		return nil
 	}
-	out := new(RunnerPodTemplate)
+	out := new(TerraformSetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TFStateSpec) DeepCopyInto(out *TFStateSpec) {
+func (in *TerraformSetResource) DeepCopyInto(out *TerraformSetResource) {
 	*out = *in
-	out.LockTimeout = in.LockTimeout
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TFStateSpec.
-func (in *TFStateSpec) DeepCopy() *TFStateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformSetResource.
+func (in *TerraformSetResource) DeepCopy() *TerraformSetResource {
 	if in == nil {
 		return nil
 	}
-	out := new(TFStateSpec)
+	out := new(TerraformSetResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Terraform) DeepCopyInto(out *Terraform) {
+func (in *TerraformSetSpec) DeepCopyInto(out *TerraformSetSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Generators != nil {
+		in, out := &in.Generators, &out.Generators
+		*out = make([]TerraformSetGenerator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	out.Interval = in.Interval
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Terraform.
-func (in *Terraform) DeepCopy() *Terraform {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformSetSpec.
+func (in *TerraformSetSpec) DeepCopy() *TerraformSetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Terraform)
+	out := new(TerraformSetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Terraform) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TerraformList) DeepCopyInto(out *TerraformList) {
+func (in *TerraformSetStatus) DeepCopyInto(out *TerraformSetStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Terraform, len(*in))
+	out.ReconcileRequestStatus = in.ReconcileRequestStatus
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Terraforms != nil {
+		in, out := &in.Terraforms, &out.Terraforms
+		*out = make([]TerraformSetResource, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformList.
-func (in *TerraformList) DeepCopy() *TerraformList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformSetStatus.
+func (in *TerraformSetStatus) DeepCopy() *TerraformSetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TerraformList)
+	out := new(TerraformSetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TerraformList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformSetTemplate) DeepCopyInto(out *TerraformSetTemplate) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformSetTemplate.
+func (in *TerraformSetTemplate) DeepCopy() *TerraformSetTemplate {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(TerraformSetTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformSetTemplateMeta) DeepCopyInto(out *TerraformSetTemplateMeta) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformSetTemplateMeta.
+func (in *TerraformSetTemplateMeta) DeepCopy() *TerraformSetTemplateMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformSetTemplateMeta)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -513,6 +1394,38 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.StateMigration != nil {
+		in, out := &in.StateMigration, &out.StateMigration
+		*out = new(StateMigrationSpec)
+		**out = **in
+	}
+	if in.StateBackup != nil {
+		in, out := &in.StateBackup, &out.StateBackup
+		*out = new(StateBackupSpec)
+		**out = **in
+	}
+	if in.Imports != nil {
+		in, out := &in.Imports, &out.Imports
+		*out = make([]ImportSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Moves != nil {
+		in, out := &in.Moves, &out.Moves
+		*out = make([]MoveSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Validate != nil {
+		in, out := &in.Validate, &out.Validate
+		*out = new(ValidateSpec)
+		**out = **in
+	}
+	if in.Test != nil {
+		in, out := &in.Test, &out.Test
+		*out = new(TestSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Cloud != nil {
 		in, out := &in.Cloud, &out.Cloud
 		*out = new(CloudSpec)
@@ -537,6 +1450,11 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Templating != nil {
+		in, out := &in.Templating, &out.Templating
+		*out = new(TemplatingSpec)
+		**out = **in
+	}
 	if in.TfVarsFiles != nil {
 		in, out := &in.TfVarsFiles, &out.TfVarsFiles
 		*out = make([]string, len(*in))
@@ -559,6 +1477,16 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 		**out = **in
 	}
 	out.SourceRef = in.SourceRef
+	if in.Inline != nil {
+		in, out := &in.Inline, &out.Inline
+		*out = new(InlineSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalSources != nil {
+		in, out := &in.AdditionalSources, &out.AdditionalSources
+		*out = make([]AdditionalSource, len(*in))
+		copy(*out, *in)
+	}
 	if in.ReadInputsFromSecrets != nil {
 		in, out := &in.ReadInputsFromSecrets, &out.ReadInputsFromSecrets
 		*out = make([]ReadInputsFromSecretSpec, len(*in))
@@ -569,6 +1497,25 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 		*out = new(WriteOutputsToSecretSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WriteOutputsTo != nil {
+		in, out := &in.WriteOutputsTo, &out.WriteOutputsTo
+		*out = make([]OutputDestination, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RolloutOnOutputsChange != nil {
+		in, out := &in.RolloutOnOutputsChange, &out.RolloutOnOutputsChange
+		*out = make([]OutputsConsumer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DriftPolicy != nil {
+		in, out := &in.DriftPolicy, &out.DriftPolicy
+		*out = new(DriftPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CliConfigSecretRef != nil {
 		in, out := &in.CliConfigSecretRef, &out.CliConfigSecretRef
 		*out = new(corev1.SecretReference)
@@ -619,6 +1566,11 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 		*out = make([]meta.NamespacedObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.DependsOnObjects != nil {
+		in, out := &in.DependsOnObjects, &out.DependsOnObjects
+		*out = make([]ObjectDependency, len(*in))
+		copy(*out, *in)
+	}
 	if in.Enterprise != nil {
 		in, out := &in.Enterprise, &out.Enterprise
 		*out = new(apiextensionsv1.JSON)
@@ -673,6 +1625,11 @@ func (in *TerraformStatus) DeepCopyInto(out *TerraformStatus) {
 		in, out := &in.LastAppliedByDriftDetectionAt, &out.LastAppliedByDriftDetectionAt
 		*out = (*in).DeepCopy()
 	}
+	if in.DriftReport != nil {
+		in, out := &in.DriftReport, &out.DriftReport
+		*out = new(DriftReportReference)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AvailableOutputs != nil {
 		in, out := &in.AvailableOutputs, &out.AvailableOutputs
 		*out = make([]string, len(*in))
@@ -685,6 +1642,40 @@ func (in *TerraformStatus) DeepCopyInto(out *TerraformStatus) {
 		(*in).DeepCopyInto(*out)
 	}
 	out.Lock = in.Lock
+	in.StateMigration.DeepCopyInto(&out.StateMigration)
+	in.StateBackup.DeepCopyInto(&out.StateBackup)
+	in.StateOperation.DeepCopyInto(&out.StateOperation)
+	if in.Imports != nil {
+		in, out := &in.Imports, &out.Imports
+		*out = make([]ImportStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SourceRevisions != nil {
+		in, out := &in.SourceRevisions, &out.SourceRevisions
+		*out = make([]SourceRevision, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemoteOutputs != nil {
+		in, out := &in.RemoteOutputs, &out.RemoteOutputs
+		*out = make([]RemoteOutputsReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.OutputsHistory != nil {
+		in, out := &in.OutputsHistory, &out.OutputsHistory
+		*out = make([]OutputsRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]VariableSchema, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformStatus.
@@ -697,6 +1688,41 @@ func (in *TerraformStatus) DeepCopy() *TerraformStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestSpec) DeepCopyInto(out *TestSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestSpec.
+func (in *TestSpec) DeepCopy() *TestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidateSpec) DeepCopyInto(out *ValidateSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidateSpec.
+func (in *ValidateSpec) DeepCopy() *ValidateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Variable) DeepCopyInto(out *Variable) {
 	*out = *in
@@ -722,9 +1748,39 @@ func (in *Variable) DeepCopy() *Variable {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VariableSchema) DeepCopyInto(out *VariableSchema) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VariableSchema.
+func (in *VariableSchema) DeepCopy() *VariableSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(VariableSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VarsReference) DeepCopyInto(out *VarsReference) {
 	*out = *in
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultVarsSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPVarsSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.VarsKeys != nil {
 		in, out := &in.VarsKeys, &out.VarsKeys
 		*out = make([]string, len(*in))
@@ -742,6 +1798,62 @@ func (in *VarsReference) DeepCopy() *VarsReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuth) DeepCopyInto(out *VaultAuth) {
+	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(meta.SecretKeyReference)
+		**out = **in
+	}
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(VaultKubernetesAuth)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuth.
+func (in *VaultAuth) DeepCopy() *VaultAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKubernetesAuth) DeepCopyInto(out *VaultKubernetesAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubernetesAuth.
+func (in *VaultKubernetesAuth) DeepCopy() *VaultKubernetesAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultKubernetesAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultVarsSource) DeepCopyInto(out *VaultVarsSource) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultVarsSource.
+func (in *VaultVarsSource) DeepCopy() *VaultVarsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultVarsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Webhook) DeepCopyInto(out *Webhook) {
 	*out = *in
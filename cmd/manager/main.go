@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"crypto/rand"
 	"os"
 	"time"
 
@@ -41,6 +42,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/ptr"
 	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -81,6 +83,9 @@ func main() {
 		eventsAddr                string
 		healthAddr                string
 		concurrent                int
+		concurrentPerNamespace    int
+		concurrentPerTenant       int
+		tenantLabel               string
 		requeueDependency         time.Duration
 		clientOptions             client.Options
 		logOptions                logger.Options
@@ -109,6 +114,12 @@ func main() {
 	flag.StringVar(&eventsAddr, "events-addr", "", "The address of the events receiver.")
 	flag.StringVar(&healthAddr, "health-addr", ":9440", "The address the health endpoint binds to.")
 	flag.IntVar(&concurrent, "concurrent", 4, "The number of concurrent terraform reconciles.")
+	flag.IntVar(&concurrentPerNamespace, "concurrent-per-namespace", 0,
+		"The maximum number of concurrent terraform reconciles holding a runner in a namespace (0 for no limit).")
+	flag.IntVar(&concurrentPerTenant, "concurrent-per-tenant", 0,
+		"The maximum number of concurrent terraform reconciles holding a runner in the namespaces of a tenant (0 for no limit).")
+	flag.StringVar(&tenantLabel, "tenant-label", "toolkit.fluxcd.io/tenant",
+		"The label of the namespaces whose value is the tenant the namespace belongs to.")
 	flag.DurationVar(&requeueDependency, "requeue-dependency", 30*time.Second, "The interval at which failing dependencies are reevaluated.")
 	flag.BoolVar(&watchAllNamespaces, "watch-all-namespaces", true,
 		"Watch for custom resources in all namespaces, if set to false it will only watch the runtime namespace.")
@@ -249,8 +260,32 @@ func main() {
 		os.Exit(1)
 	}
 
+	// the cache of the manager is not started yet
+	directClient, err := ctrlclient.New(restConfig, ctrlclient.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to create a client")
+		os.Exit(1)
+	}
+
+	var outputsHashKey []byte
+	if runtimeNamespace != "" {
+		outputsHashKey, err = controllers.LoadOutputsHashKey(signalHandlerContext, directClient, runtimeNamespace)
+		if err != nil {
+			setupLog.Error(err, "unable to load the outputs hash key")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("RUNTIME_NAMESPACE is not set, the hashes of the outputs will change when the controller restarts")
+		outputsHashKey = make([]byte, 32)
+		if _, err := rand.Read(outputsHashKey); err != nil {
+			setupLog.Error(err, "unable to generate the outputs hash key")
+			os.Exit(1)
+		}
+	}
+
 	reconciler := &controllers.TerraformReconciler{
 		Client:                    mgr.GetClient(),
+		APIReader:                 mgr.GetAPIReader(),
 		Scheme:                    mgr.GetScheme(),
 		EventRecorder:             eventRecorder,
 		Metrics:                   metricsH,
@@ -266,16 +301,29 @@ func main() {
 		UsePodSubdomainResolution: usePodSubdomainResolution,
 		Clientset:                 clientset,
 		FieldManager:              "tf-controller",
+		OutputsHashKey:            outputsHashKey,
 		ShutdownTimeout:           gracefulShutdownTimeout,
 		QuotaRetryEnabled:         quotaRetryEnabled,
 		QuotaRetryDelay:           quotaRetryDelay,
 		QuotaRetryJitterFactor:    quotaRetryJitterFactor,
+		MaxConcurrentPerNamespace: concurrentPerNamespace,
+		MaxConcurrentPerTenant:    concurrentPerTenant,
+		TenantLabel:               tenantLabel,
 	}
 
 	if err = reconciler.SetupWithManager(mgr, concurrent, httpRetry); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Terraform")
 		os.Exit(1)
 	}
+
+	if err = (&controllers.TerraformSetReconciler{
+		Client:        mgr.GetClient(),
+		EventRecorder: eventRecorder,
+		FieldManager:  "tf-controller",
+	}).SetupWithManager(mgr, concurrent); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TerraformSet")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if os.Getenv("INSECURE_LOCAL_RUNNER") == "1" {
@@ -54,6 +54,7 @@ func newRootCommand() *cobra.Command {
 	rootCmd.AddCommand(buildCreateCmd(app))
 	rootCmd.AddCommand(buildDeleteCmd(app))
 	rootCmd.AddCommand(buildForceUnlockCmd(app))
+	rootCmd.AddCommand(buildGraphCmd(app))
 	rootCmd.AddCommand(buildInstallCmd(app))
 	rootCmd.AddCommand(buildReconcileCmd(app))
 	rootCmd.AddCommand(buildApprovePlanCmd(app))
@@ -65,6 +66,7 @@ func newRootCommand() *cobra.Command {
 
 	rootCmd.AddCommand(buildGetGroup(app))
 	rootCmd.AddCommand(buildShowGroup(app))
+	rootCmd.AddCommand(buildStateGroup(app))
 
 	rootCmd.AddCommand(buildBreakTheGlassCmd(app))
 
@@ -223,6 +225,7 @@ func buildShowGroup(app *tfctl.CLI) *cobra.Command {
 		Short: "Show a Terraform configuration",
 	}
 	cmd.AddCommand(buildShowPlanCmd(app))
+	cmd.AddCommand(buildShowDriftCmd(app))
 	return cmd
 }
 
@@ -243,6 +246,32 @@ func buildShowPlanCmd(app *tfctl.CLI) *cobra.Command {
 	}
 }
 
+var showDriftExamples = `
+  # Show the report of the drift detected for a Terraform resource
+  tfctl show drift my-resource
+
+  # Show the report in JSON, to alert on drift
+  tfctl show drift my-resource --format json
+`
+
+func buildShowDriftCmd(app *tfctl.CLI) *cobra.Command {
+	showDrift := &cobra.Command{
+		Use:     "drift NAME",
+		Short:   "Show the drift detected for a Terraform resource",
+		Example: strings.Trim(showDriftExamples, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := cmd.Flags().GetString("format")
+			if err != nil {
+				return err
+			}
+			return app.ShowDrift(cmd.Context(), os.Stdout, args[0], format)
+		},
+	}
+	showDrift.Flags().String("format", tfctl.DriftFormatText, "The format of the report, text or json")
+	return showDrift
+}
+
 var approvePlanExamples = `
   # Approve the plan for a Terraform resource
   tfctl approve my-resource -f manifests/my-resource.yaml
@@ -303,18 +332,26 @@ func buildGetTerraformCmd(app *tfctl.CLI) *cobra.Command {
 var deleteExamples = `
   # Delete a Terraform resource
   tfctl delete my-resource
+
+  # Delete a Terraform resource after all the resources depending on it
+  tfctl delete my-resource --cascade
 `
 
 func buildDeleteCmd(app *tfctl.CLI) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "delete NAME",
 		Short:   "Delete a Terraform resource",
-		Example: strings.Trim(getTerraformExamples, "\n"),
+		Example: strings.Trim(deleteExamples, "\n"),
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return app.DeleteTerraform(os.Stdout, args[0])
+			cascade, err := cmd.Flags().GetBool("cascade")
+			if err != nil {
+				return err
+			}
+			return app.DeleteTerraform(os.Stdout, args[0], cascade)
 		},
 	}
+	cmd.Flags().Bool("cascade", false, "Delete the dependants of the Terraform resource in its namespace first, in reverse dependency order")
 	return cmd
 }
 
@@ -374,6 +411,37 @@ func buildForceUnlockCmd(app *tfctl.CLI) *cobra.Command {
 	return forceUnlock
 }
 
+var graphExamples = `
+  # Render the dependency graph of the Terraform resources in the default namespace
+  tfctl graph -n default | dot -Tsvg > graph.svg
+
+  # Render the dependency graph of all the Terraform resources as a Mermaid flowchart
+  tfctl graph -A --format mermaid
+`
+
+func buildGraphCmd(app *tfctl.CLI) *cobra.Command {
+	graph := &cobra.Command{
+		Use:     "graph",
+		Short:   "Render the dependency graph of Terraform resources",
+		Example: strings.Trim(graphExamples, "\n"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := cmd.Flags().GetString("format")
+			if err != nil {
+				return err
+			}
+			all, err := cmd.Flags().GetBool("all-namespaces")
+			if err != nil {
+				return err
+			}
+			return app.Graph(cmd.Context(), os.Stdout, format, all)
+		},
+	}
+	graph.Flags().String("format", tfctl.GraphFormatDOT, "The format of the graph, dot or mermaid")
+	graph.Flags().BoolP("all-namespaces", "A", false, "Render the Terraform resources of all the namespaces")
+	return graph
+}
+
 var replanExamples = `
 	# Replan a Terraform resource
 	tfctl -n default replan my-resource
@@ -392,6 +460,135 @@ func buildReplanCmd(app *tfctl.CLI) *cobra.Command {
 	return replan
 }
 
+func buildStateGroup(app *tfctl.CLI) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "Manage the state of a Terraform resource",
+	}
+	cmd.AddCommand(buildStateListCmd(app))
+	cmd.AddCommand(buildStateMoveCmd(app))
+	cmd.AddCommand(buildStateRemoveCmd(app))
+	cmd.AddCommand(buildStateImportCmd(app))
+	cmd.AddCommand(buildStateListBackupsCmd(app))
+	cmd.AddCommand(buildStateRestoreCmd(app))
+	return cmd
+}
+
+var stateListExamples = `
+  # List the resources in the state of a Terraform resource
+  tfctl state list my-resource
+
+  # List the resources of a module
+  tfctl state list my-resource module.network
+`
+
+func buildStateListCmd(app *tfctl.CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list NAME [ADDRESS...]",
+		Short:   "List the resources in the state of a Terraform resource",
+		Example: strings.Trim(stateListExamples, "\n"),
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.StateList(cmd.Context(), os.Stdout, args[0], args[1:])
+		},
+	}
+}
+
+var stateMoveExamples = `
+  # Rename a resource in the state of a Terraform resource
+  tfctl state mv my-resource aws_instance.web aws_instance.frontend
+`
+
+func buildStateMoveCmd(app *tfctl.CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:     "mv NAME SOURCE DESTINATION",
+		Short:   "Move a resource in the state of a Terraform resource",
+		Example: strings.Trim(stateMoveExamples, "\n"),
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.StateMove(cmd.Context(), os.Stdout, args[0], args[1], args[2])
+		},
+	}
+}
+
+var stateRemoveExamples = `
+  # Stop managing a resource without destroying it
+  tfctl state rm my-resource aws_instance.web
+`
+
+func buildStateRemoveCmd(app *tfctl.CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:     "rm NAME ADDRESS...",
+		Short:   "Remove resources from the state of a Terraform resource",
+		Example: strings.Trim(stateRemoveExamples, "\n"),
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.StateRemove(cmd.Context(), os.Stdout, args[0], args[1:])
+		},
+	}
+}
+
+var stateImportExamples = `
+  # Import an existing resource into the state of a Terraform resource
+  tfctl state import my-resource aws_instance.web i-0123456789abcdef0
+`
+
+func buildStateImportCmd(app *tfctl.CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:     "import NAME ADDRESS ID",
+		Short:   "Import an existing resource into the state of a Terraform resource",
+		Example: strings.Trim(stateImportExamples, "\n"),
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.StateImport(cmd.Context(), os.Stdout, args[0], args[1], args[2])
+		},
+	}
+}
+
+var stateListBackupsExamples = `
+  # List the state backups of a Terraform resource
+  tfctl state list-backups my-resource
+`
+
+func buildStateListBackupsCmd(app *tfctl.CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list-backups NAME",
+		Short:   "List the state backups of a Terraform resource",
+		Example: strings.Trim(stateListBackupsExamples, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.ListStateBackups(cmd.Context(), os.Stdout, args[0])
+		},
+	}
+}
+
+var stateRestoreExamples = `
+  # Restore the state of a Terraform resource from a backup
+  tfctl state restore my-resource --from=tfstate-backup-default-my-resource-42
+
+  # Restore the state from a backup stored in another namespace
+  tfctl state restore my-resource --from=tf-backups/tfstate-backup-default-my-resource-42
+`
+
+func buildStateRestoreCmd(app *tfctl.CLI) *cobra.Command {
+	restore := &cobra.Command{
+		Use:     "restore NAME",
+		Short:   "Restore the state of a Terraform resource from a backup",
+		Example: strings.Trim(stateRestoreExamples, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from := viper.GetString("from")
+			if from == "" {
+				return errors.New("a backup to restore from must be given with --from")
+			}
+			return app.RestoreState(cmd.Context(), os.Stdout, args[0], from)
+		},
+	}
+	restore.Flags().String("from", "", "The backup to restore the state from, as listed by 'tfctl state list-backups'")
+	viper.BindPFlags(restore.Flags())
+	return restore
+}
+
 func buildBreakTheGlassCmd(app *tfctl.CLI) *cobra.Command {
 	breakTheGlass := &cobra.Command{
 		Use:     "break-glass",
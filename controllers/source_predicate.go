@@ -1,6 +1,12 @@
 package controllers
 
 import (
+	"reflect"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
@@ -64,3 +70,112 @@ func (SecretDeletePredicate) Update(e event.UpdateEvent) bool {
 func (SecretDeletePredicate) Generic(e event.GenericEvent) bool {
 	return false
 }
+
+// OutputsChangePredicate passes the creation of output Secrets and ConfigMaps,
+// and the updates changing their data.
+type OutputsChangePredicate struct {
+	predicate.Funcs
+}
+
+// Create implements Predicate.
+func (OutputsChangePredicate) Create(e event.CreateEvent) bool {
+	return true
+}
+
+// Update implements Predicate.
+func (OutputsChangePredicate) Update(e event.UpdateEvent) bool {
+	switch oldObj := e.ObjectOld.(type) {
+	case *corev1.Secret:
+		newObj, ok := e.ObjectNew.(*corev1.Secret)
+		return ok && !reflect.DeepEqual(oldObj.Data, newObj.Data)
+	case *corev1.ConfigMap:
+		newObj, ok := e.ObjectNew.(*corev1.ConfigMap)
+		return ok && !reflect.DeepEqual(oldObj.Data, newObj.Data)
+	}
+
+	return false
+}
+
+// DependencyReadyPredicate passes the updates of Terraform objects which may
+// unblock their dependants: becoming ready, or, while ready, applying a new
+// revision or publishing new outputs.
+type DependencyReadyPredicate struct {
+	predicate.Funcs
+}
+
+// Create implements Predicate.
+func (DependencyReadyPredicate) Create(e event.CreateEvent) bool {
+	return false
+}
+
+// Delete implements Predicate.
+func (DependencyReadyPredicate) Delete(e event.DeleteEvent) bool {
+	return false
+}
+
+// Update implements Predicate.
+func (DependencyReadyPredicate) Update(e event.UpdateEvent) bool {
+	oldTerraform, ok := e.ObjectOld.(*infrav1.Terraform)
+	if !ok {
+		return false
+	}
+	newTerraform, ok := e.ObjectNew.(*infrav1.Terraform)
+	if !ok {
+		return false
+	}
+
+	if !isDependencyReady(newTerraform) {
+		return false
+	}
+
+	return !isDependencyReady(oldTerraform) ||
+		oldTerraform.Status.LastAppliedRevision != newTerraform.Status.LastAppliedRevision ||
+		oldTerraform.Status.LastPlannedRevision != newTerraform.Status.LastPlannedRevision ||
+		!reflect.DeepEqual(latestOutputs(oldTerraform), latestOutputs(newTerraform))
+}
+
+// RolloutProgressPredicate passes the updates of Terraform objects which may
+// let the next objects of a rollout start: finishing or failing a revision,
+// or being deleted.
+type RolloutProgressPredicate struct {
+	predicate.Funcs
+}
+
+// Create implements Predicate.
+func (RolloutProgressPredicate) Create(e event.CreateEvent) bool {
+	return false
+}
+
+// Delete implements Predicate.
+func (RolloutProgressPredicate) Delete(e event.DeleteEvent) bool {
+	return true
+}
+
+// Update implements Predicate.
+func (RolloutProgressPredicate) Update(e event.UpdateEvent) bool {
+	oldTerraform, ok := e.ObjectOld.(*infrav1.Terraform)
+	if !ok {
+		return false
+	}
+	newTerraform, ok := e.ObjectNew.(*infrav1.Terraform)
+	if !ok {
+		return false
+	}
+
+	oldReady := apimeta.FindStatusCondition(oldTerraform.Status.Conditions, meta.ReadyCondition)
+	newReady := apimeta.FindStatusCondition(newTerraform.Status.Conditions, meta.ReadyCondition)
+	if oldReady == nil || newReady == nil {
+		return oldReady != newReady
+	}
+
+	return oldReady.Status != newReady.Status ||
+		oldTerraform.Status.LastAttemptedRevision != newTerraform.Status.LastAttemptedRevision ||
+		oldTerraform.Status.Plan.Pending != newTerraform.Status.Plan.Pending
+}
+
+func latestOutputs(terraform *infrav1.Terraform) *infrav1.OutputsRevision {
+	if len(terraform.Status.OutputsHistory) == 0 {
+		return nil
+	}
+	return &terraform.Status.OutputsHistory[0]
+}
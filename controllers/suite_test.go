@@ -173,6 +173,7 @@ func TestMain(m *testing.M) {
 
 	reconciler = &TerraformReconciler{
 		Client:                    k8sManager.GetClient(),
+		APIReader:                 k8sManager.GetAPIReader(),
 		Scheme:                    k8sManager.GetScheme(),
 		EventRecorder:             k8sManager.GetEventRecorderFor("tf-controller"),
 		StatusPoller:              polling.NewStatusPoller(k8sManager.GetClient(), k8sManager.GetRESTMapper(), polling.Options{}),
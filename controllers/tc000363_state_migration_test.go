@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+)
+
+func Test_000363_state_migration_on_backend_change(t *testing.T) {
+	Spec("A changed backend configuration must require an approved state migration.")
+	g := NewWithT(t)
+
+	reconciler := &TerraformReconciler{}
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tf-state-migration",
+			Namespace: "flux-system",
+		},
+		Spec: infrav1.TerraformSpec{
+			BackendConfig: &infrav1.BackendConfigSpec{
+				SecretSuffix:    "tf-state-migration",
+				InClusterConfig: true,
+				Labels: map[string]string{
+					"team": "platform",
+					"env":  "dev",
+				},
+			},
+		},
+	}
+
+	It("should not require a migration when the state has never been initialized")
+	_, ok := reconciler.pendingStateMigration(terraform)
+	g.Expect(ok).To(BeFalse())
+
+	By("recording the backend configuration after initialization")
+	backendConfig := reconciler.stateBackendConfig(terraform)
+	infrav1.TerraformBackendInitialized(terraform, stateBackendConfigHash(backendConfig, terraform.Spec.BackendConfigsFrom), backendConfig)
+
+	It("should render a stable backend configuration")
+	g.Expect(reconciler.stateBackendConfig(terraform)).To(Equal(backendConfig))
+	_, ok = reconciler.pendingStateMigration(terraform)
+	g.Expect(ok).To(BeFalse())
+
+	By("changing the backend configuration")
+	terraform.Spec.BackendConfig = &infrav1.BackendConfigSpec{
+		CustomConfiguration: `backend "s3" {}`,
+	}
+	migrationID, ok := reconciler.pendingStateMigration(terraform)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(migrationID).To(HavePrefix(infrav1.StateMigrationIDPrefix))
+	g.Expect(migrationID).To(HaveLen(len(infrav1.StateMigrationIDPrefix) + 10))
+
+	It("should not migrate the state without approval")
+	g.Expect(reconciler.shouldMigrateState(terraform, migrationID)).To(BeFalse())
+
+	terraform.Spec.StateMigration = &infrav1.StateMigrationSpec{Approve: "migrate-0000000000"}
+	g.Expect(reconciler.shouldMigrateState(terraform, migrationID)).To(BeFalse())
+
+	It("should migrate the state when the migration is approved")
+	terraform.Spec.StateMigration.Approve = migrationID
+	g.Expect(reconciler.shouldMigrateState(terraform, migrationID)).To(BeTrue())
+
+	terraform.Spec.StateMigration.Approve = infrav1.ApproveStateMigrationAutoValue
+	g.Expect(reconciler.shouldMigrateState(terraform, migrationID)).To(BeTrue())
+
+	It("should require a new migration when the backend config references change")
+	infrav1.TerraformBackendInitialized(terraform, stateBackendConfigHash(reconciler.stateBackendConfig(terraform), nil), reconciler.stateBackendConfig(terraform))
+	terraform.Spec.BackendConfigsFrom = []infrav1.BackendConfigsReference{
+		{Kind: "Secret", Name: "s3-backend"},
+	}
+	_, ok = reconciler.pendingStateMigration(terraform)
+	g.Expect(ok).To(BeTrue())
+
+	It("should not migrate the state when the backend gets disabled")
+	terraform.Spec.BackendConfig = &infrav1.BackendConfigSpec{Disable: true}
+	_, ok = reconciler.pendingStateMigration(terraform)
+	g.Expect(ok).To(BeFalse())
+}
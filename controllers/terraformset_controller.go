@@ -0,0 +1,502 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/runtime/conditions"
+	"github.com/fluxcd/pkg/runtime/patch"
+	"github.com/fluxcd/pkg/runtime/predicates"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	kuberecorder "k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+)
+
+// TerraformSetReconciler reconciles a TerraformSet object
+type TerraformSetReconciler struct {
+	client.Client
+	kuberecorder.EventRecorder
+
+	FieldManager string
+	patchOptions []patch.Option
+}
+
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformsets,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformsets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformsets/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// Reconcile generates the Terraform objects of a TerraformSet, prunes the
+// ones that are no longer generated, and reports their readiness.
+func (r *TerraformSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// The TerraformSet is read unstructured, from the API server rather than
+	// the cache, to render its template as written: the typed template drops
+	// the fields set to their zero value, e.g. a false boolean defaulting to true.
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(infrav1.GroupVersion.WithKind(infrav1.TerraformSetKind))
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	set := &infrav1.TerraformSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, set); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The generated objects are garbage collected with the TerraformSet.
+	if !set.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if set.Spec.Suspend {
+		log.Info("Reconciliation is suspended for this object")
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper := patch.NewSerialPatcher(set, r.Client)
+
+	defer func() {
+		if v, ok := meta.ReconcileAnnotationValue(set.GetAnnotations()); ok {
+			set.Status.SetLastHandledReconcileRequest(v)
+		}
+		set.Status.ObservedGeneration = set.Generation
+
+		if err := patchHelper.Patch(ctx, set, r.patchOptions...); err != nil {
+			retErr = kerrors.NewAggregate([]error{retErr, err})
+		}
+	}()
+
+	tpl, _, err := unstructured.NestedMap(obj.Object, "spec", "template")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	desired, err := r.generate(ctx, set, tpl)
+	if err != nil {
+		msg := fmt.Sprintf("unable to generate the Terraform objects: %s", err)
+		conditions.MarkFalse(set, meta.ReadyCondition, infrav1.TerraformsGenerationFailedReason, "%s", msg)
+		r.Event(set, corev1.EventTypeWarning, infrav1.TerraformsGenerationFailedReason, msg)
+		return ctrl.Result{}, err
+	}
+
+	generated := make([]*infrav1.Terraform, 0, len(desired))
+	for _, terraform := range desired {
+		applied, err := r.apply(ctx, set, terraform)
+		if err != nil {
+			msg := fmt.Sprintf("unable to apply Terraform %s: %s", terraform.Name, err)
+			conditions.MarkFalse(set, meta.ReadyCondition, infrav1.TerraformsGenerationFailedReason, "%s", msg)
+			r.Event(set, corev1.EventTypeWarning, infrav1.TerraformsGenerationFailedReason, msg)
+			return ctrl.Result{}, err
+		}
+		generated = append(generated, applied)
+	}
+
+	if set.Spec.Prune {
+		if err := r.prune(ctx, set, desired); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	aggregateReadiness(set, generated)
+
+	return ctrl.Result{RequeueAfter: set.GetInterval()}, nil
+}
+
+// generatedTerraform is a Terraform object rendered from the template of a
+// TerraformSet. Its spec is applied as rendered, not as typed, so that only the
+// fields of the template are set.
+type generatedTerraform struct {
+	*infrav1.Terraform
+	spec map[string]any
+}
+
+// generate renders the template of the TerraformSet with the parameters of
+// all its generators.
+func (r *TerraformSetReconciler) generate(ctx context.Context, set *infrav1.TerraformSet, tpl map[string]any) ([]*generatedTerraform, error) {
+	var params []map[string]any
+	for i, generator := range set.Spec.Generators {
+		p, err := r.generateParams(ctx, set, generator)
+		if err != nil {
+			return nil, fmt.Errorf("generator %d: %w", i, err)
+		}
+		params = append(params, p...)
+	}
+
+	names := map[string]bool{}
+	terraforms := make([]*generatedTerraform, 0, len(params))
+	for _, p := range params {
+		terraform, err := renderTerraform(set, tpl, p)
+		if err != nil {
+			return nil, err
+		}
+		if names[terraform.Name] {
+			return nil, fmt.Errorf("the name %q is generated more than once", terraform.Name)
+		}
+		names[terraform.Name] = true
+		terraforms = append(terraforms, terraform)
+	}
+
+	return terraforms, nil
+}
+
+func (r *TerraformSetReconciler) generateParams(ctx context.Context, set *infrav1.TerraformSet, generator infrav1.TerraformSetGenerator) ([]map[string]any, error) {
+	switch {
+	case generator.List != nil:
+		return listParams(generator.List.Elements)
+
+	case generator.ConfigMap != nil:
+		var configMap corev1.ConfigMap
+		if err := r.Get(ctx, types.NamespacedName{Namespace: set.Namespace, Name: generator.ConfigMap.Name}, &configMap); err != nil {
+			return nil, err
+		}
+		return dataParams(configMap.Data), nil
+
+	case generator.Secret != nil:
+		var secret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Namespace: set.Namespace, Name: generator.Secret.Name}, &secret); err != nil {
+			return nil, err
+		}
+		return secretParams(&secret), nil
+
+	case generator.Namespaces != nil:
+		selector, err := metav1.LabelSelectorAsSelector(&generator.Namespaces.Selector)
+		if err != nil {
+			return nil, err
+		}
+		var list corev1.NamespaceList
+		if err := r.List(ctx, &list, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		objects := make([]client.Object, 0, len(list.Items))
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+		return objectParams(objects), nil
+
+	case generator.Clusters != nil:
+		selector, err := metav1.LabelSelectorAsSelector(&generator.Clusters.Selector)
+		if err != nil {
+			return nil, err
+		}
+		var list corev1.SecretList
+		if err := r.List(ctx, &list, client.InNamespace(set.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		objects := make([]client.Object, 0, len(list.Items))
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+		return objectParams(objects), nil
+	}
+
+	return nil, fmt.Errorf("no generator is set")
+}
+
+func listParams(elements []apiextensionsv1.JSON) ([]map[string]any, error) {
+	params := make([]map[string]any, 0, len(elements))
+	for i, element := range elements {
+		var p map[string]any
+		if err := json.Unmarshal(element.Raw, &p); err != nil || p == nil {
+			return nil, fmt.Errorf("element %d is not a JSON object", i)
+		}
+		params = append(params, p)
+	}
+	return params, nil
+}
+
+// dataParams generates the parameters of every key of the data of a
+// ConfigMap, in the order of the keys.
+func dataParams(data map[string]string) []map[string]any {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	params := make([]map[string]any, 0, len(keys))
+	for _, key := range keys {
+		var value any = data[key]
+		var object map[string]any
+		if err := json.Unmarshal([]byte(data[key]), &object); err == nil && object != nil {
+			value = object
+		}
+		params = append(params, map[string]any{"key": key, "value": value})
+	}
+	return params
+}
+
+// secretParams generates the parameters of every key of a Secret. The values
+// are left out, so they are not copied to the spec of the generated objects:
+// the template reads them with varsFrom, from the Secret named .secret.
+func secretParams(secret *corev1.Secret) []map[string]any {
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	params := make([]map[string]any, 0, len(keys))
+	for _, key := range keys {
+		params = append(params, map[string]any{"key": key, "secret": secret.Name})
+	}
+	return params
+}
+
+// objectParams generates the parameters of every selected object, in the
+// order of the names.
+func objectParams(objects []client.Object) []map[string]any {
+	sort.Slice(objects, func(i, j int) bool { return objects[i].GetName() < objects[j].GetName() })
+
+	params := make([]map[string]any, 0, len(objects))
+	for _, o := range objects {
+		params = append(params, map[string]any{
+			"name":        o.GetName(),
+			"labels":      stringMap(o.GetLabels()),
+			"annotations": stringMap(o.GetAnnotations()),
+		})
+	}
+	return params
+}
+
+func stringMap(m map[string]string) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// renderTerraform renders the string fields of the template with the
+// parameters, and returns the generated Terraform object.
+func renderTerraform(set *infrav1.TerraformSet, tpl map[string]any, params map[string]any) (*generatedTerraform, error) {
+	left, right := set.GetDelimiters()
+	rendered, err := renderValue(tpl, params, left, right)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(rendered)
+	if err != nil {
+		return nil, err
+	}
+	var result infrav1.TerraformSetTemplate
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("the rendered template is not a valid Terraform: %w", err)
+	}
+
+	if errs := validation.IsDNS1123Subdomain(result.Metadata.Name); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid name %q: %s", result.Metadata.Name, strings.Join(errs, ", "))
+	}
+
+	labels := map[string]string{}
+	for k, v := range result.Metadata.Labels {
+		labels[k] = v
+	}
+	labels[infrav1.TerraformSetLabel] = set.Name
+
+	spec, _ := rendered.(map[string]any)["spec"].(map[string]any)
+
+	return &generatedTerraform{
+		Terraform: &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        result.Metadata.Name,
+				Namespace:   set.Namespace,
+				Labels:      labels,
+				Annotations: result.Metadata.Annotations,
+			},
+			Spec: result.Spec,
+		},
+		spec: spec,
+	}, nil
+}
+
+func renderValue(value any, params map[string]any, left, right string) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.Contains(v, left) {
+			return v, nil
+		}
+		t, err := template.New("").
+			Delims(left, right).
+			Funcs(sprig.HermeticTxtFuncMap()).
+			Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, params); err != nil {
+			return nil, err
+		}
+		// Missing parameters are allowed, so the generators can share a
+		// template with default, but they must not end up in the output.
+		if strings.Contains(buf.String(), "<no value>") {
+			return nil, fmt.Errorf("template %q refers to a missing parameter", v)
+		}
+		return buf.String(), nil
+
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			rendered, err := renderValue(item, params, left, right)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = rendered
+		}
+		return out, nil
+
+	case []any:
+		out := make([]any, 0, len(v))
+		for _, item := range v {
+			rendered, err := renderValue(item, params, left, right)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, rendered)
+		}
+		return out, nil
+	}
+
+	return value, nil
+}
+
+// apply creates or updates a generated Terraform object with a server-side
+// apply, which leaves alone the fields defaulted by the API server and the
+// ones set by others. It refuses to take over an existing object that is not
+// generated by the TerraformSet.
+func (r *TerraformSetReconciler) apply(ctx context.Context, set *infrav1.TerraformSet, desired *generatedTerraform) (*infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	existing := &infrav1.Terraform{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err == nil {
+		if !metav1.IsControlledBy(existing, set) {
+			return nil, fmt.Errorf("the object already exists and is not generated by this TerraformSet")
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(infrav1.GroupVersion.WithKind(infrav1.TerraformKind))
+	obj.SetName(desired.Name)
+	obj.SetNamespace(desired.Namespace)
+	obj.SetLabels(desired.Labels)
+	obj.SetAnnotations(desired.Annotations)
+	if err := controllerutil.SetControllerReference(set, obj, r.Scheme()); err != nil {
+		return nil, err
+	}
+	obj.Object["spec"] = desired.spec
+
+	if err := r.Apply(ctx, client.ApplyConfigurationFromUnstructured(obj), client.FieldOwner(r.FieldManager), client.ForceOwnership); err != nil {
+		return nil, err
+	}
+
+	terraform := &infrav1.Terraform{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, terraform); err != nil {
+		return nil, err
+	}
+
+	if terraform.ResourceVersion != existing.ResourceVersion {
+		log.Info(fmt.Sprintf("Terraform %s applied", terraform.Name))
+	}
+	return terraform, nil
+}
+
+// prune deletes the Terraform objects generated by the TerraformSet that are
+// no longer generated.
+func (r *TerraformSetReconciler) prune(ctx context.Context, set *infrav1.TerraformSet, desired []*generatedTerraform) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	keep := make(map[string]bool, len(desired))
+	for _, terraform := range desired {
+		keep[terraform.Name] = true
+	}
+
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list, client.InNamespace(set.Namespace), client.MatchingLabels{infrav1.TerraformSetLabel: set.Name}); err != nil {
+		return err
+	}
+
+	for i := range list.Items {
+		terraform := &list.Items[i]
+		if keep[terraform.Name] || !metav1.IsControlledBy(terraform, set) || !terraform.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, terraform); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("unable to prune Terraform %s: %w", terraform.Name, err)
+		}
+		log.Info(fmt.Sprintf("Terraform %s pruned", terraform.Name))
+		r.Eventf(set, corev1.EventTypeNormal, "Pruned", "Terraform %s is no longer generated and was deleted", terraform.Name)
+	}
+
+	return nil
+}
+
+// aggregateReadiness reports the readiness of the generated Terraform objects
+// in the status of the TerraformSet. It is ready when all of them are ready.
+func aggregateReadiness(set *infrav1.TerraformSet, generated []*infrav1.Terraform) {
+	resources := make([]infrav1.TerraformSetResource, 0, len(generated))
+	var notReady []string
+	for _, terraform := range generated {
+		resource := infrav1.TerraformSetResource{Name: terraform.Name, Ready: metav1.ConditionUnknown}
+		if ready := conditions.Get(terraform, meta.ReadyCondition); ready != nil {
+			resource.Ready = ready.Status
+			resource.Message = ready.Message
+		}
+		if resource.Ready != metav1.ConditionTrue {
+			notReady = append(notReady, terraform.Name)
+		}
+		resources = append(resources, resource)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].Name < resources[j].Name })
+	sort.Strings(notReady)
+
+	set.Status.Terraforms = resources
+	set.Status.ReadyCount = fmt.Sprintf("%d/%d", len(resources)-len(notReady), len(resources))
+
+	if len(notReady) > 0 {
+		conditions.MarkFalse(set, meta.ReadyCondition, infrav1.TerraformsNotReadyReason,
+			"%d of %d Terraform objects are not ready: %s", len(notReady), len(resources), strings.Join(notReady, ", "))
+		return
+	}
+	conditions.MarkTrue(set, meta.ReadyCondition, meta.SucceededReason, "%d Terraform objects are ready", len(resources))
+}
+
+func (r *TerraformSetReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconciles int) error {
+	r.patchOptions = []patch.Option{patch.WithOwnedConditions{Conditions: []string{meta.ReadyCondition}}, patch.WithFieldOwner(r.FieldManager)}
+	recoverPanic := true
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1.TerraformSet{}, builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicates.ReconcileRequestedPredicate{}),
+		)).
+		Owns(&infrav1.Terraform{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RecoverPanic:            &recoverPanic,
+		}).
+		Complete(r)
+}
@@ -0,0 +1,262 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/runtime/conditions"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func terraformSetForTest() *infrav1.TerraformSet {
+	return &infrav1.TerraformSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "buckets", Namespace: "flux-system", UID: "set-uid", Generation: 1},
+		Spec: infrav1.TerraformSetSpec{
+			Generators: []infrav1.TerraformSetGenerator{
+				{List: &infrav1.ListGenerator{Elements: []apiextensionsv1.JSON{
+					{Raw: []byte(`{"account": "dev", "region": "eu-west-1"}`)},
+					{Raw: []byte(`{"account": "prod", "region": "us-east-1"}`)},
+				}}},
+				{ConfigMap: &infrav1.DataGenerator{Name: "accounts"}},
+				{Namespaces: &infrav1.SelectorGenerator{Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"tenant": "true"},
+				}}},
+			},
+			Template: infrav1.TerraformSetTemplate{
+				Metadata: infrav1.TerraformSetTemplateMeta{
+					Name:   `bucket-{{ .account | default .key | default .name }}`,
+					Labels: map[string]string{"account": `{{ .account | default .key | default .name }}`},
+				},
+				Spec: infrav1.TerraformSpec{
+					Path: "./buckets",
+					SourceRef: infrav1.CrossNamespaceSourceReference{
+						Kind: "GitRepository",
+						Name: "infra",
+					},
+					Vars: []infrav1.Variable{{
+						Name:  "region",
+						Value: &apiextensionsv1.JSON{Raw: []byte(`"{{ if .value }}{{ .value.region }}{{ else }}{{ .region | default \"eu-central-1\" }}{{ end }}"`)},
+					}},
+				},
+			},
+			Prune: true,
+		},
+	}
+}
+
+// templateOf returns the template of the TerraformSet as read unstructured.
+func templateOf(t *testing.T, set *infrav1.TerraformSet) map[string]any {
+	t.Helper()
+	tpl, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&set.Spec.Template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tpl
+}
+
+func TestTerraformSetGenerate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+	set := terraformSetForTest()
+	accounts := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "accounts", Namespace: "flux-system"},
+		Data:       map[string]string{"staging": `{"region": "ap-south-1"}`},
+	}
+	tenant := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"tenant": "true"}}}
+	other := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}}
+
+	r := &TerraformSetReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(accounts, tenant, other).Build(),
+	}
+
+	terraforms, err := r.generate(t.Context(), set, templateOf(t, set))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(terraforms).To(HaveLen(4))
+
+	names := []string{}
+	regions := []string{}
+	for _, terraform := range terraforms {
+		g.Expect(terraform.Namespace).To(Equal("flux-system"))
+		g.Expect(terraform.Labels).To(HaveKeyWithValue(infrav1.TerraformSetLabel, "buckets"))
+		names = append(names, terraform.Name)
+		regions = append(regions, string(terraform.Spec.Vars[0].Value.Raw))
+	}
+	g.Expect(names).To(Equal([]string{"bucket-dev", "bucket-prod", "bucket-staging", "bucket-team-a"}))
+	g.Expect(regions).To(Equal([]string{`"eu-west-1"`, `"us-east-1"`, `"ap-south-1"`, `"eu-central-1"`}))
+	g.Expect(terraforms[0].Labels).To(HaveKeyWithValue("account", "dev"))
+
+	// the names must be unique
+	set.Spec.Generators = append(set.Spec.Generators, set.Spec.Generators[0])
+	_, err = r.generate(t.Context(), set, templateOf(t, set))
+	g.Expect(err).To(MatchError(ContainSubstring(`the name "bucket-dev" is generated more than once`)))
+
+	// the ${{ }} templates of the values are kept with other delimiters
+	set = terraformSetForTest()
+	set.Spec.Generators = set.Spec.Generators[:1]
+	set.Spec.Template.Metadata.Name = "bucket-[[ .account ]]"
+	set.Spec.Template.Spec.Values = &apiextensionsv1.JSON{Raw: []byte(`{"vpc": "${{ .inputs.vpc_id }}", "account": "[[ .account ]]"}`)}
+	set.Spec.LeftDelimiter, set.Spec.RightDelimiter = "[[", "]]"
+	terraforms, err = r.generate(t.Context(), set, templateOf(t, set))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(terraforms[0].Name).To(Equal("bucket-dev"))
+	g.Expect(terraforms[0].Spec.Values.Raw).To(MatchJSON(`{"vpc": "${{ .inputs.vpc_id }}", "account": "dev"}`))
+
+	// the functions reading the environment are not available
+	set = terraformSetForTest()
+	set.Spec.Generators = set.Spec.Generators[:1]
+	set.Spec.Template.Metadata.Name = `bucket-{{ env "HOME" }}`
+	_, err = r.generate(t.Context(), set, templateOf(t, set))
+	g.Expect(err).To(MatchError(ContainSubstring(`function "env" not defined`)))
+}
+
+func TestTerraformSetGenerateFromSecret(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "flux-system"},
+		Data:       map[string][]byte{"dev": []byte("s3cr3t")},
+	}
+	r := &TerraformSetReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(credentials).Build(),
+	}
+
+	set := terraformSetForTest()
+	set.Spec.Generators = []infrav1.TerraformSetGenerator{{Secret: &infrav1.DataGenerator{Name: "credentials"}}}
+	set.Spec.Template.Spec.Vars = nil
+	set.Spec.Template.Spec.VarsFrom = []infrav1.VarsReference{{
+		Kind:     "Secret",
+		Name:     "{{ .secret }}",
+		VarsKeys: []string{"{{ .key }}:token"},
+	}}
+
+	terraforms, err := r.generate(t.Context(), set, templateOf(t, set))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(terraforms).To(HaveLen(1))
+	g.Expect(terraforms[0].Name).To(Equal("bucket-dev"))
+	g.Expect(terraforms[0].Spec.VarsFrom).To(Equal([]infrav1.VarsReference{{
+		Kind:     "Secret",
+		Name:     "credentials",
+		VarsKeys: []string{"dev:token"},
+	}}))
+
+	// the values of the Secret are never rendered into the spec
+	set.Spec.Template.Spec.VarsFrom = nil
+	set.Spec.Template.Spec.Vars = []infrav1.Variable{{
+		Name:  "token",
+		Value: &apiextensionsv1.JSON{Raw: []byte(`"{{ .value }}"`)},
+	}}
+	_, err = r.generate(t.Context(), set, templateOf(t, set))
+	g.Expect(err).To(MatchError(ContainSubstring("refers to a missing parameter")))
+}
+
+func TestTerraformSetReconcile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	set := terraformSetForTest()
+	set.Spec.Generators = set.Spec.Generators[:1]
+	unmanaged := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: "flux-system", Labels: map[string]string{infrav1.TerraformSetLabel: "buckets"}}}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(set, unmanaged).
+		WithStatusSubresource(&infrav1.TerraformSet{}, &infrav1.Terraform{}).
+		Build()
+	r := &TerraformSetReconciler{Client: c, EventRecorder: record.NewFakeRecorder(10), FieldManager: "tf-controller"}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(set)}
+
+	_, err := r.Reconcile(t.Context(), req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var list infrav1.TerraformList
+	g.Expect(c.List(t.Context(), &list, client.MatchingLabels{infrav1.TerraformSetLabel: "buckets"})).To(Succeed())
+	g.Expect(list.Items).To(HaveLen(3))
+
+	dev := &infrav1.Terraform{}
+	g.Expect(c.Get(t.Context(), client.ObjectKey{Namespace: "flux-system", Name: "bucket-dev"}, dev)).To(Succeed())
+	g.Expect(dev.OwnerReferences).To(HaveLen(1))
+	g.Expect(dev.OwnerReferences[0].Name).To(Equal("buckets"))
+
+	g.Expect(c.Get(t.Context(), req.NamespacedName, set)).To(Succeed())
+	g.Expect(set.Status.ReadyCount).To(Equal("0/2"))
+	g.Expect(conditions.IsFalse(set, meta.ReadyCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(set, meta.ReadyCondition)).To(Equal(infrav1.TerraformsNotReadyReason))
+
+	// the fields that are not in the template are left alone
+	dev.Spec.ApprovePlan = "auto"
+	g.Expect(c.Update(t.Context(), dev, client.FieldOwner("kubectl-edit"))).To(Succeed())
+	_, err = r.Reconcile(t.Context(), req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.Get(t.Context(), client.ObjectKeyFromObject(dev), dev)).To(Succeed())
+	g.Expect(dev.Spec.ApprovePlan).To(Equal("auto"))
+	g.Expect(dev.Spec.Path).To(Equal("./buckets"))
+
+	// the readiness of the generated objects is aggregated
+	for _, name := range []string{"bucket-dev", "bucket-prod"} {
+		terraform := &infrav1.Terraform{}
+		g.Expect(c.Get(t.Context(), client.ObjectKey{Namespace: "flux-system", Name: name}, terraform)).To(Succeed())
+		conditions.MarkTrue(terraform, meta.ReadyCondition, infrav1.TFExecApplySucceedReason, "Applied successfully")
+		g.Expect(c.Status().Update(t.Context(), terraform)).To(Succeed())
+	}
+	_, err = r.Reconcile(t.Context(), req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.Get(t.Context(), req.NamespacedName, set)).To(Succeed())
+	g.Expect(set.Status.ReadyCount).To(Equal("2/2"))
+	g.Expect(conditions.IsTrue(set, meta.ReadyCondition)).To(BeTrue())
+
+	// the objects that are no longer generated are pruned, the others are kept
+	set.Spec.Generators[0].List.Elements = set.Spec.Generators[0].List.Elements[:1]
+	g.Expect(c.Update(t.Context(), set)).To(Succeed())
+	_, err = r.Reconcile(t.Context(), req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.List(t.Context(), &list, client.MatchingLabels{infrav1.TerraformSetLabel: "buckets"})).To(Succeed())
+	g.Expect(list.Items).To(HaveLen(2))
+	g.Expect([]string{list.Items[0].Name, list.Items[1].Name}).To(ConsistOf("bucket-dev", "unmanaged"))
+
+	// an existing object is not taken over
+	g.Expect(c.Get(t.Context(), req.NamespacedName, set)).To(Succeed())
+	set.Spec.Template.Metadata.Name = "unmanaged"
+	g.Expect(c.Update(t.Context(), set)).To(Succeed())
+	_, err = r.Reconcile(t.Context(), req)
+	g.Expect(err).To(MatchError(ContainSubstring("not generated by this TerraformSet")))
+}
+
+func TestTerraformSetGenerateKeepsTheTemplateAsWritten(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	set := terraformSetForTest()
+	set.Spec.Generators = set.Spec.Generators[:1]
+	tpl := templateOf(t, set)
+	g.Expect(unstructured.SetNestedField(tpl, false, "spec", "upgradeOnInit")).To(Succeed())
+
+	r := &TerraformSetReconciler{}
+	terraforms, err := r.generate(t.Context(), set, tpl)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(terraforms).To(HaveLen(2))
+
+	// a false boolean defaulting to true is applied, not dropped
+	upgradeOnInit, found, err := unstructured.NestedBool(terraforms[0].spec, "upgradeOnInit")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(upgradeOnInit).To(BeFalse())
+}
@@ -50,6 +50,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	kuberecorder "k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -60,6 +61,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/flux-iac/tofu-controller/api/graph"
 	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
 	"github.com/flux-iac/tofu-controller/mtls"
 	"github.com/flux-iac/tofu-controller/utils"
@@ -76,6 +78,13 @@ type TerraformReconciler struct {
 	patchOptions      []patch.Option
 	requeueDependency time.Duration
 
+	// OutputsHashKey is the key the hashes of the outputs are computed with.
+	OutputsHashKey []byte
+
+	// APIReader reads the objects the controller does not watch, like the
+	// Deployments consuming the outputs, without starting an informer for them.
+	APIReader client.Reader
+
 	// Quota retry configuration
 	QuotaRetryEnabled      bool
 	QuotaRetryDelay        time.Duration
@@ -94,6 +103,17 @@ type TerraformReconciler struct {
 	UsePodSubdomainResolution bool
 	Clientset                 *kubernetes.Clientset
 
+	// Concurrency quotas of the reconciliations holding a runner, per
+	// namespace and per tenant, 0 meaning no quota. The tenant of an object is
+	// the value of the TenantLabel label of its namespace.
+	MaxConcurrentPerNamespace int
+	MaxConcurrentPerTenant    int
+	TenantLabel               string
+	concurrency               *concurrencyLimiter
+
+	// Objects let through by the rollouts of source revisions.
+	rollouts rolloutTracker
+
 	// Graceful shutdown fields
 	ShutdownTimeout       time.Duration
 	shutdownStarted       atomic.Bool
@@ -103,10 +123,13 @@ type TerraformReconciler struct {
 //+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraforms,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraforms/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraforms/finalizers,verbs=get;create;update;patch;delete
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=rolloutpolicies,verbs=get;list;watch
 //+kubebuilder:rbac:groups=source.toolkit.fluxcd.io,resources=buckets;gitrepositories;ocirepositories,verbs=get;list;watch
 //+kubebuilder:rbac:groups=source.toolkit.fluxcd.io,resources=buckets/status;gitrepositories/status;ocirepositories/status,verbs=get
 //+kubebuilder:rbac:groups="",resources=configmaps;secrets;serviceaccounts,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create;update;delete
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -201,6 +224,25 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	// Examine if the object is under deletion
 	if isBeingDeleted(terraform) {
+		if isCascadeDeletion(terraform) {
+			msg, err := r.cascadeDeletion(ctx, terraform)
+			if err != nil {
+				log.Error(err, "unable to delete the dependants")
+				return ctrl.Result{}, err
+			}
+
+			if msg != "" {
+				log.Info(msg)
+				terraform = infrav1.TerraformNotReady(terraform, "", infrav1.CascadingDeletionReason, msg)
+				if err := patchHelper.Patch(ctx, terraform, r.patchOptions...); err != nil {
+					log.Error(err, "unable to update status")
+					return ctrl.Result{Requeue: true}, err
+				}
+
+				return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+			}
+		}
+
 		dependants := []string{}
 		for _, finalizer := range terraform.GetFinalizers() {
 			if after, ok := strings.CutPrefix(finalizer, infrav1.TFDependencyOfPrefix); ok {
@@ -271,8 +313,25 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		conditions.MarkUnknown(terraform, meta.ReadyCondition, meta.ProgressingReason, "Reconciliation in progress")
 	}
 
+	// an invalid schedule is not recoverable until the object changes
+	if _, err := parseSchedule(terraform); err != nil {
+		conditions.MarkStalled(terraform, infrav1.InvalidScheduleReason, "%s", err)
+		conditions.MarkFalse(terraform, meta.ReadyCondition, infrav1.InvalidScheduleReason, "%s", err)
+		conditions.Delete(terraform, meta.ReconcilingCondition)
+		r.Eventf(terraform, corev1.EventTypeWarning, infrav1.InvalidScheduleReason, "%s", err.Error())
+
+		return ctrl.Result{}, reconcile.TerminalError(err)
+	}
+	if conditions.HasAnyReason(terraform, meta.StalledCondition, infrav1.InvalidScheduleReason) {
+		conditions.Delete(terraform, meta.StalledCondition)
+	}
+
 	// Check whether we need to reconcile the release at this time
 	shouldReconcile, reason, requeueAfter := r.shouldReconcile(terraform, sourceObj)
+	upstreamOutputsChanged := r.upstreamOutputsChanged(ctx, terraform)
+	if !shouldReconcile && upstreamOutputsChanged {
+		shouldReconcile, reason = true, "outputs of a Terraform referenced by varsFrom have changed"
+	}
 	if !shouldReconcile {
 		log.Info("Skipping reconciliation",
 			"reason", reason,
@@ -289,11 +348,63 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	traceLog.Info("Proceeding with reconciliation", "reason", reason)
 
+	// wait for the turn of the object in the rollout of a new source revision
+	if !isBeingDeleted(terraform) {
+		reason, msg, err := r.checkRollout(ctx, terraform, sourceObj)
+		if err != nil {
+			log.Error(err, "unable to check the rollout policy")
+			return ctrl.Result{}, err
+		}
+		if reason != "" {
+			if reason == infrav1.RolloutHaltedReason {
+				terraform = infrav1.TerraformRolloutHalted(terraform, msg)
+			} else {
+				terraform = infrav1.TerraformRolloutPending(terraform, msg)
+			}
+			if err := patchHelper.Patch(ctx, terraform, r.patchOptions...); err != nil {
+				log.Error(err, "unable to update status for rollout")
+				return ctrl.Result{Requeue: true}, err
+			}
+			log.Info(msg)
+
+			// the object is enqueued as soon as the rollout progresses, see
+			// requestsForRolloutProgressOf
+			return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+		}
+		defer func() { r.releaseRollout(terraform, sourceObj) }()
+	}
+
 	// check dependencies, if not being deleted
-	if len(terraform.Spec.DependsOn) > 0 && !isBeingDeleted(terraform) {
-		if err := r.checkDependencies(ctx, terraform, sourceObj); err != nil {
+	if (len(terraform.GetDependsOn()) > 0 || len(terraform.Spec.DependsOnObjects) > 0) && !isBeingDeleted(terraform) {
+		var cycle []types.NamespacedName
+		if len(terraform.GetDependsOn()) > 0 {
+			cycle, err = r.dependencyCycle(ctx, terraform)
+			if err != nil {
+				log.Error(err, "unable to build the dependency graph")
+				return ctrl.Result{}, err
+			}
+		}
+		if cycle != nil {
+			msg := fmt.Sprintf("dependency cycle detected: %s", graph.FormatCycle(cycle))
+			terraform = infrav1.TerraformDependencyCycle(terraform, sourceObj.GetArtifact().Revision, msg)
+			if err := patchHelper.Patch(ctx, terraform, r.patchOptions...); err != nil {
+				log.Error(err, "unable to update status for dependency cycle")
+				return ctrl.Result{Requeue: true}, err
+			}
+			log.Info(msg)
+			r.Event(terraform, corev1.EventTypeWarning, infrav1.DependencyCycleDetectedReason, msg)
+
+			// the cycle may be broken by a change of another object in it
+			return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+		}
+
+		err := r.checkDependencies(ctx, terraform, sourceObj)
+		if err == nil {
+			err = r.checkObjectDependencies(ctx, terraform)
+		}
+		if err != nil {
 			if acl.IsAccessDenied(err) {
-				traceLog.Info("The cross-namespace dependency was denied by reconciler.NoCrossNamespaceRefs")
+				traceLog.Info("The dependency was denied by the reconciler")
 
 				terraform = infrav1.TerraformNotReady(terraform, sourceObj.GetArtifact().Revision, infrav1.AccessDeniedReason, err.Error())
 				if err := patchHelper.Patch(ctx, terraform, r.patchOptions...); err != nil {
@@ -313,7 +424,8 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 				return ctrl.Result{Requeue: true}, err
 			}
 			// we can't rely on exponential backoff because it will prolong the execution too much,
-			// instead we requeue on a fix interval.
+			// instead we requeue on a fix interval. This is a fallback, as the object is enqueued
+			// as soon as a dependency becomes ready, see requestsForDependencyChangeOf.
 			msg := fmt.Sprintf("Dependencies do not meet ready condition, retrying in %s", terraform.GetRetryInterval().String())
 			log.Info(msg)
 			r.Eventf(terraform, corev1.EventTypeNormal, infrav1.DependencyNotReadyReason, "%s", msg)
@@ -323,10 +435,15 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		log.Info("All dependencies are ready, proceeding with reconciliation")
 	}
 
-	if conditions.HasAnyReason(terraform, meta.ReadyCondition, infrav1.AccessDeniedReason, infrav1.DependencyNotReadyReason) {
+	if conditions.HasAnyReason(terraform, meta.ReadyCondition, infrav1.AccessDeniedReason, infrav1.DependencyNotReadyReason, infrav1.DependencyCycleDetectedReason,
+		infrav1.RolloutPendingReason, infrav1.RolloutHaltedReason) {
 		conditions.MarkUnknown(terraform, meta.ReadyCondition, meta.ProgressingReason, "Reconciliation in progress")
 	}
 
+	if conditions.HasAnyReason(terraform, meta.StalledCondition, infrav1.DependencyCycleDetectedReason) {
+		conditions.Delete(terraform, meta.StalledCondition)
+	}
+
 	// Skip update the status if the ready condition is still unknown
 	// so that the Plan prompt is still shown.
 	ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
@@ -425,16 +542,78 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 
 		// case 5:
-		// return early if it's manually mode and pending
+		// if the outputs of a Terraform referenced by .spec.varsFrom have
+		// changed while the plan was pending approval, we should clear the
+		// Pending Plan to trigger re-plan with the new variables.
+		//
+		if upstreamOutputsChanged &&
+			terraform.Status.Plan.Pending != "" &&
+			!r.shouldApply(terraform) {
+			traceLog.Info("Upstream outputs have changed while the plan was pending approval, clearing pending plan to trigger re-plan")
+			terraform.Status.Plan.Pending = ""
+			if err := patchHelper.Patch(ctx, terraform, r.patchOptions...); err != nil {
+				log.Error(err, "unable to update status to clear pending plan (upstream outputs changed)")
+				return ctrl.Result{Requeue: true}, err
+			}
+		}
+
+		// case 6:
+		// return early if it's manually mode and pending,
+		// unless a state restore or a state operation has been requested
 		//
 		traceLog.Info("Check for pending plan, forceOrAutoApply and shouldApply")
+		_, restoreRequested := stateRestoreRequested(terraform)
+		_, stateOperationRequested, _ := terraform.GetStateOperation()
 		if terraform.Status.Plan.Pending != "" &&
+			!restoreRequested &&
+			!stateOperationRequested &&
 			!r.forceOrAutoApply(terraform) &&
 			!r.shouldApply(terraform) {
 			log.Info("reconciliation is stopped to wait for a manual approve")
 			return ctrl.Result{}, nil
 		}
+
+		// case 7:
+		// return early if the backend configuration has changed,
+		// and the state migration is not approved yet
+		//
+		traceLog.Info("Check for pending state migration")
+		if migrationID, ok := r.pendingStateMigration(terraform); ok && !r.shouldMigrateState(terraform, migrationID) {
+			msg := fmt.Sprintf("Backend configuration changed: set stateMigration.approve: \"%s\" to migrate the state.", migrationID)
+			if terraform.Status.StateMigration.Pending != migrationID {
+				r.Eventf(terraform, corev1.EventTypeNormal, infrav1.StateMigrationPendingReason, "%s", msg)
+			}
+
+			terraform = infrav1.TerraformStateMigrationPending(terraform, sourceObj.GetArtifact().Revision, migrationID, msg)
+			conditions.Delete(terraform, meta.ReconcilingCondition)
+			if err := patchHelper.Patch(ctx, terraform, r.patchOptions...); err != nil {
+				log.Error(err, "unable to update status for pending state migration")
+				return ctrl.Result{Requeue: true}, err
+			}
+
+			log.Info("reconciliation is stopped to wait for the state migration approval")
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// Wait for a slot within the concurrency quotas of the namespace and the
+	// tenant, before holding a runner.
+	release, quotaMsg, err := r.acquireConcurrencySlot(ctx, terraform)
+	if err != nil {
+		log.Error(err, "unable to acquire a concurrency slot")
+		return ctrl.Result{}, err
+	}
+	if release == nil {
+		msg := fmt.Sprintf("Waiting for a concurrency slot: %s", quotaMsg)
+		log.Info(msg)
+		conditions.MarkReconciling(terraform, infrav1.ConcurrencyQuotaReachedReason, "%s", msg)
+		if err := patchHelper.Patch(ctx, terraform, r.patchOptions...); err != nil {
+			log.Error(err, "unable to update status for concurrency quota")
+			return ctrl.Result{Requeue: true}, err
+		}
+		return ctrl.Result{RequeueAfter: wait.Jitter(r.getQuotaRetryDelay(), r.QuotaRetryJitterFactor)}, nil
 	}
+	defer release()
 
 	// Create Runner Pod.
 	// Wait for the Runner Pod to start.
@@ -622,6 +801,13 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, nil
 	}
 
+	// next reconcile is at the next time of .Spec.Schedule
+	if terraform.Spec.Schedule != "" {
+		next := nextReconcileAt(terraform, terraform.Status.LastSuccessfulReconcileAt.Time)
+		log.Info("requeue at the next scheduled time", "schedule", terraform.Spec.Schedule, "next", next)
+		return ctrl.Result{RequeueAfter: time.Until(next)}, nil
+	}
+
 	// next reconcile is .Spec.Interval in the future
 	log.Info("requeue after interval", "interval", terraform.Spec.Interval.Duration.String())
 	return ctrl.Result{RequeueAfter: terraform.Spec.Interval.Duration}, nil
@@ -646,6 +832,16 @@ func (r *TerraformReconciler) shouldReconcile(terraform *infrav1.Terraform, sour
 		}
 	}
 
+	// reconcile if a state restore has been requested
+	if _, ok := stateRestoreRequested(terraform); ok {
+		return true, "state restore requested", 0
+	}
+
+	// reconcile if a state operation has been requested
+	if _, ok, _ := terraform.GetStateOperation(); ok {
+		return true, "state operation requested", 0
+	}
+
 	// reconcile if we have never planned
 	if terraform.Status.LastPlanAt == nil {
 		return true, "never planned before", 0
@@ -666,8 +862,9 @@ func (r *TerraformReconciler) shouldReconcile(terraform *infrav1.Terraform, sour
 		return true, "source revision has changed since last reconciliation attempt", 0
 	}
 
-	// reconcile if we were last blocked on a not ready dependency
-	if conditions.HasAnyReason(terraform, meta.ReadyCondition, infrav1.DependencyNotReadyReason) {
+	// reconcile if we were last blocked on a not ready dependency, or on a
+	// dependency cycle, which may have been broken since
+	if conditions.HasAnyReason(terraform, meta.ReadyCondition, infrav1.DependencyNotReadyReason, infrav1.DependencyCycleDetectedReason) {
 		return true, "previously blocked on not ready dependency", 0
 	}
 
@@ -686,8 +883,11 @@ func (r *TerraformReconciler) shouldReconcile(terraform *infrav1.Terraform, sour
 		return true, "never successfuly reconciled before", 0
 	}
 
-	nextReconcile := terraform.Status.LastSuccessfulReconcileAt.Add(terraform.Spec.Interval.Duration)
+	nextReconcile := nextReconcileAt(terraform, terraform.Status.LastSuccessfulReconcileAt.Time)
 	requeueAfter := time.Until(nextReconcile)
+	if requeueAfter > 0 && terraform.Spec.Schedule != "" {
+		return false, "next scheduled reconciliation is not due yet", requeueAfter
+	}
 	if requeueAfter > 0 {
 		return false, "interval has not elapsed since last successful reconciliation", requeueAfter
 	}
@@ -719,6 +919,24 @@ func (r *TerraformReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentRe
 		return fmt.Errorf("failed setting index fields: %w", err)
 	}
 
+	// Index the Terraforms by the Terraforms they read variables from.
+	if err := mgr.GetCache().IndexField(context.TODO(), &infrav1.Terraform{}, infrav1.VarsFromTerraformIndexKey,
+		r.IndexVarsFromTerraform); err != nil {
+		return fmt.Errorf("failed setting index fields: %w", err)
+	}
+
+	// Index the Terraforms by the ConfigMaps holding their inline sources.
+	if err := mgr.GetCache().IndexField(context.TODO(), &infrav1.Terraform{}, infrav1.InlineConfigMapIndexKey,
+		r.IndexInlineConfigMap); err != nil {
+		return fmt.Errorf("failed setting index fields: %w", err)
+	}
+
+	// Index the Terraforms by the Terraforms they depend on.
+	if err := mgr.GetCache().IndexField(context.TODO(), &infrav1.Terraform{}, infrav1.DependsOnIndexKey,
+		r.IndexDependsOn); err != nil {
+		return fmt.Errorf("failed setting index fields: %w", err)
+	}
+
 	// Configure the retryable http client used for fetching artifacts.
 	// By default, it retries 10 times within a 3.5 minutes window.
 	httpClient := retryablehttp.NewClient()
@@ -731,6 +949,18 @@ func (r *TerraformReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentRe
 	r.requeueDependency = 30 * time.Second
 	recoverPanic := true
 
+	if r.MaxConcurrentPerNamespace > 0 || r.MaxConcurrentPerTenant > 0 {
+		r.concurrency = newConcurrencyLimiter(r.MaxConcurrentPerNamespace, r.MaxConcurrentPerTenant)
+	}
+
+	options := controller.Options{
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		RecoverPanic:            &recoverPanic,
+	}
+	if ptr.Deref(mgr.GetControllerOptions().UsePriorityQueue, true) {
+		options.NewQueue = r.newPriorityQueue(mgr.GetLogger())
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1.Terraform{}, builder.WithPredicates(
 			predicate.Or(predicate.GenerationChangedPredicate{}, predicates.ReconcileRequestedPredicate{}),
@@ -755,17 +985,38 @@ func (r *TerraformReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentRe
 			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &infrav1.Terraform{}, handler.OnlyControllerOwner()),
 			builder.WithPredicates(SecretDeletePredicate{}),
 		).
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: maxConcurrentReconciles,
-			RecoverPanic:            &recoverPanic,
-		}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForOutputsChangeOf),
+			builder.WithPredicates(OutputsChangePredicate{}),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForOutputsChangeOf),
+			builder.WithPredicates(OutputsChangePredicate{}),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForInlineConfigMapChange),
+		).
+		Watches(
+			&infrav1.Terraform{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForDependencyChangeOf),
+			builder.WithPredicates(DependencyReadyPredicate{}),
+		).
+		Watches(
+			&infrav1.Terraform{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForRolloutProgressOf),
+			builder.WithPredicates(RolloutProgressPredicate{}),
+		).
+		WithOptions(options).
 		Complete(r)
 }
 
 func (r *TerraformReconciler) checkDependencies(ctx context.Context, terraform *infrav1.Terraform, source sourcev1.Source) error {
 	finalizerKey := infrav1.TFDependencyOfPrefix + terraform.GetName()
 
-	for _, d := range terraform.Spec.DependsOn {
+	for _, d := range terraform.GetDependsOn() {
 		dependencyName := types.NamespacedName{
 			Namespace: d.Namespace,
 			Name:      d.Name,
@@ -797,14 +1048,16 @@ func (r *TerraformReconciler) checkDependencies(ctx context.Context, terraform *
 		}
 
 		// Check whether the dependent Terraform is ready
-		if tDep.Generation != tDep.Status.ObservedGeneration || !conditions.IsTrue(tDep, meta.ReadyCondition) {
+		if !isDependencyReady(tDep) {
 			return fmt.Errorf("dependency '%s' is not ready", dependencyName)
 		}
 
 		// Compare the source revisions
 		sourceRevision := source.GetArtifact().Revision
 
-		if tDep.Spec.SourceRef.Name == terraform.Spec.SourceRef.Name &&
+		if terraform.Spec.Inline == nil && tDep.Spec.Inline == nil &&
+			len(terraform.Spec.AdditionalSources) == 0 && len(tDep.Spec.AdditionalSources) == 0 &&
+			tDep.Spec.SourceRef.Name == terraform.Spec.SourceRef.Name &&
 			tDep.Spec.SourceRef.Namespace == terraform.Spec.SourceRef.Namespace &&
 			tDep.Spec.SourceRef.Kind == terraform.Spec.SourceRef.Kind &&
 			sourceRevision != tDep.Status.LastAppliedRevision &&
@@ -854,7 +1107,8 @@ func (r *TerraformReconciler) requestsForRevisionChangeOf(indexKey string) handl
 		for _, d := range list.Items {
 			// If the revision of the artifact equals to the last attempted revision,
 			// we should not make a request for this Terraform
-			if repo.GetArtifact().Revision == d.Status.LastAttemptedRevision {
+			if repo.GetArtifact().Revision == d.Status.LastAttemptedRevision ||
+				sourceRevisionAttempted(&d, obj, repo.GetArtifact().Revision) {
 				continue
 			}
 			dd = append(dd, d.DeepCopy())
@@ -876,8 +1130,24 @@ func (r *TerraformReconciler) requestsForRevisionChangeOf(indexKey string) handl
 
 func (r *TerraformReconciler) getSource(ctx context.Context, terraform *infrav1.Terraform) (sourcev1.Source, error) {
 	var sourceObj sourcev1.Source
+	var err error
+
+	if terraform.Spec.Inline != nil {
+		sourceObj, err = r.getInlineSource(ctx, terraform)
+	} else {
+		sourceObj, err = r.getSourceByRef(ctx, terraform, terraform.Spec.SourceRef)
+	}
+	if err != nil || len(terraform.Spec.AdditionalSources) == 0 {
+		return sourceObj, err
+	}
 
-	name, namespace := terraform.Spec.SourceRef.Name, terraform.Spec.SourceRef.Namespace
+	return r.composeSources(ctx, terraform, sourceObj)
+}
+
+func (r *TerraformReconciler) getSourceByRef(ctx context.Context, terraform *infrav1.Terraform, ref infrav1.CrossNamespaceSourceReference) (sourcev1.Source, error) {
+	var sourceObj sourcev1.Source
+
+	name, namespace := ref.Name, ref.Namespace
 	if namespace == "" {
 		namespace = terraform.GetNamespace()
 	}
@@ -889,11 +1159,11 @@ func (r *TerraformReconciler) getSource(ctx context.Context, terraform *infrav1.
 
 	if r.NoCrossNamespaceRefs && sourceReference.Namespace != terraform.GetNamespace() {
 		return nil, acl.AccessDeniedError(
-			fmt.Sprintf("cannot access %s/%s, cross-namespace references have been disabled", terraform.Spec.SourceRef.Kind, sourceReference),
+			fmt.Sprintf("cannot access %s/%s, cross-namespace references have been disabled", ref.Kind, sourceReference),
 		)
 	}
 
-	switch terraform.Spec.SourceRef.Kind {
+	switch ref.Kind {
 	case sourcev1.GitRepositoryKind:
 		var repository sourcev1.GitRepository
 		err := r.Get(ctx, sourceReference, &repository)
@@ -926,7 +1196,7 @@ func (r *TerraformReconciler) getSource(ctx context.Context, terraform *infrav1.
 		sourceObj = &repository
 	default:
 		return sourceObj, fmt.Errorf("source `%s` kind '%s' not supported",
-			terraform.Spec.SourceRef.Name, terraform.Spec.SourceRef.Kind)
+			ref.Name, ref.Kind)
 	}
 
 	return sourceObj, nil
@@ -978,14 +1248,22 @@ func (r *TerraformReconciler) IndexBy(kind string) func(o client.Object) []strin
 			panic(fmt.Sprintf("Expected a Terraform, got %T", o))
 		}
 
-		if terraform.Spec.SourceRef.Kind == kind {
-			namespace := terraform.GetNamespace()
-			if terraform.Spec.SourceRef.Namespace != "" {
-				namespace = terraform.Spec.SourceRef.Namespace
+		refs := []infrav1.CrossNamespaceSourceReference{terraform.Spec.SourceRef}
+		for _, additional := range terraform.Spec.AdditionalSources {
+			refs = append(refs, additional.SourceRef)
+		}
+
+		var keys []string
+		for _, ref := range refs {
+			if ref.Kind == kind {
+				namespace := terraform.GetNamespace()
+				if ref.Namespace != "" {
+					namespace = ref.Namespace
+				}
+				keys = append(keys, fmt.Sprintf("%s/%s", namespace, ref.Name))
 			}
-			return []string{fmt.Sprintf("%s/%s", namespace, terraform.Spec.SourceRef.Name)}
 		}
 
-		return nil
+		return keys
 	}
 }
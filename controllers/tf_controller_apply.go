@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -10,12 +11,25 @@ import (
 
 	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
 	"github.com/flux-iac/tofu-controller/runner"
+	tfjson "github.com/hashicorp/terraform-json"
 	"google.golang.org/grpc/status"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 func (r *TerraformReconciler) forceOrAutoApply(terraform *infrav1.Terraform) bool {
-	return terraform.Spec.Force || terraform.Spec.ApprovePlan == infrav1.ApprovePlanAutoValue
+	if terraform.Spec.Force {
+		return true
+	}
+
+	// the drift policy decides for the plans remediating drift
+	switch terraform.Status.Plan.DriftAction {
+	case infrav1.DriftActionAutoRemediate:
+		return true
+	case infrav1.DriftActionRequireApproval:
+		return false
+	}
+
+	return terraform.Spec.ApprovePlan == infrav1.ApprovePlanAutoValue
 }
 
 func (r *TerraformReconciler) shouldApply(terraform *infrav1.Terraform) bool {
@@ -28,6 +42,12 @@ func (r *TerraformReconciler) shouldApply(terraform *infrav1.Terraform) bool {
 		return false
 	}
 
+	if terraform.Status.Plan.DriftAction == infrav1.DriftActionAutoRemediate && terraform.Status.Plan.Pending != "" {
+		return true
+	} else if terraform.Status.Plan.DriftAction == infrav1.DriftActionRequireApproval && terraform.Spec.ApprovePlan == infrav1.ApprovePlanAutoValue {
+		return false
+	}
+
 	if terraform.Spec.ApprovePlan == "" {
 		return false
 	} else if terraform.Spec.ApprovePlan == infrav1.ApprovePlanAutoValue && terraform.Status.Plan.Pending != "" {
@@ -76,6 +96,28 @@ func (r *TerraformReconciler) apply(ctx context.Context, patchHelper *patch.Seri
 
 	log.Info(fmt.Sprintf("load tf plan: %s", loadTFPlanReply.Message))
 
+	// Without a backend, the configuration is applied rather than the plan, so
+	// all its import blocks are.
+	var importedAddresses []string
+	if len(terraform.PendingImports()) > 0 && !terraform.Status.Plan.IsDestroyPlan {
+		if r.backendCompletelyDisable(terraform) {
+			for _, spec := range terraform.PendingImports() {
+				importedAddresses = append(importedAddresses, spec.Address)
+			}
+		} else if importedAddresses, err = r.plannedImports(ctx, runnerClient, tfInstance, TFPlanName); err != nil {
+			// the imports stay pending, to be planned again
+			log.Error(err, "unable to read the imports of the plan")
+		}
+	}
+
+	if r.shouldBackupState(terraform) {
+		terraform, err = r.backupState(ctx, runnerClient, terraform, tfInstance, revision)
+		if err != nil {
+			log.Error(err, "error backing up state before apply")
+			return terraform, err
+		}
+	}
+
 	terraform = infrav1.TerraformApplying(terraform, revision, "Apply started")
 	if err := patchHelper.Patch(ctx, terraform, r.patchOptions...); err != nil {
 		log.Error(err, "error recording apply status: %s", err)
@@ -206,5 +248,39 @@ func (r *TerraformReconciler) apply(ctx context.Context, patchHelper *patch.Seri
 
 	terraform = infrav1.TerraformApplied(terraform, revision, msg, isDestroyApplied, inventoryEntries)
 
+	// also clears the imports once removed from .spec.imports
+	if !isDestroyApplied {
+		terraform = infrav1.TerraformImportsApplied(terraform, revision, importedAddresses)
+	}
+
 	return terraform, nil
 }
+
+// plannedImports returns the addresses of the resources the plan saved in the
+// given file imports, or already finds in the state. A targeted plan leaves the
+// other import blocks out.
+func (r *TerraformReconciler) plannedImports(ctx context.Context, runnerClient runner.RunnerClient, tfInstance string, filename string) ([]string, error) {
+	reply, err := runnerClient.ShowPlanFile(ctx, &runner.ShowPlanFileRequest{
+		TfInstance: tfInstance,
+		Filename:   filename,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to show the plan: %w", err)
+	}
+
+	var plan tfjson.Plan
+	if err := json.Unmarshal(reply.JsonOutput, &plan); err != nil {
+		return nil, fmt.Errorf("unable to parse the plan: %w", err)
+	}
+
+	var addresses []string
+	for _, rc := range plan.ResourceChanges {
+		if rc.Change == nil {
+			continue
+		}
+		if rc.Change.Importing != nil || !rc.Change.Actions.Create() {
+			addresses = append(addresses, rc.Address)
+		}
+	}
+	return addresses, nil
+}
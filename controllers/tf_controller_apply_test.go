@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flux-iac/tofu-controller/runner"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+type mockRunnerClientForPlannedImports struct {
+	runner.RunnerClient
+}
+
+func (m *mockRunnerClientForPlannedImports) ShowPlanFile(context.Context, *runner.ShowPlanFileRequest, ...grpc.CallOption) (*runner.ShowPlanFileReply, error) {
+	return &runner.ShowPlanFileReply{
+		JsonOutput: []byte(`{
+  "format_version": "1.2",
+  "resource_changes": [
+    {"address": "aws_instance.web", "mode": "managed", "type": "aws_instance", "change": {"actions": ["no-op"], "importing": {"id": "i-0123456789abcdef0"}}},
+    {"address": "aws_iam_role.ci", "mode": "managed", "type": "aws_iam_role", "change": {"actions": ["no-op"]}},
+    {"address": "aws_s3_bucket.new", "mode": "managed", "type": "aws_s3_bucket", "change": {"actions": ["create"]}}
+  ]
+}`),
+	}, nil
+}
+
+func TestPlannedImports(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	r := &TerraformReconciler{}
+	addresses, err := r.plannedImports(t.Context(), &mockRunnerClientForPlannedImports{}, "1", "tfplan")
+	g.Expect(err).ToNot(HaveOccurred())
+	// the resources created by the plan are not imported
+	g.Expect(addresses).To(Equal([]string{"aws_instance.web", "aws_iam_role.ci"}))
+}
@@ -3,7 +3,9 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 
 	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
@@ -49,7 +51,17 @@ func (r *TerraformReconciler) setupTerraform(ctx context.Context, patchHelper *p
 	}
 
 	// download artifact and extract files
-	buf, err := r.downloadAsBytes(sourceObj.GetArtifact())
+	buf, err := r.artifactBytes(sourceObj)
+	if err != nil {
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.ArtifactFailedReason,
+			err.Error(),
+		), tfInstance, tmpDir, err
+	}
+
+	additionalArtifacts, sourceRevisions, err := r.additionalArtifacts(sourceObj, terraform)
 	if err != nil {
 		return infrav1.TerraformNotReady(
 			terraform,
@@ -60,10 +72,11 @@ func (r *TerraformReconciler) setupTerraform(ctx context.Context, patchHelper *p
 	}
 
 	uploadAndExtractReply, err := runnerClient.UploadAndExtract(ctx, &runner.UploadAndExtractRequest{
-		Namespace: terraform.Namespace,
-		Name:      terraform.Name,
-		TarGz:     buf.Bytes(),
-		Path:      terraform.Spec.Path,
+		Namespace:           terraform.Namespace,
+		Name:                terraform.Name,
+		TarGz:               buf.Bytes(),
+		Path:                terraform.Spec.Path,
+		AdditionalArtifacts: additionalArtifacts,
 	})
 	if err != nil {
 		return infrav1.TerraformNotReady(
@@ -73,61 +86,11 @@ func (r *TerraformReconciler) setupTerraform(ctx context.Context, patchHelper *p
 			err.Error(),
 		), tfInstance, tmpDir, err
 	}
+	terraform.Status.SourceRevisions = sourceRevisions
 	workingDir := uploadAndExtractReply.WorkingDir
 	tmpDir = uploadAndExtractReply.TmpDir
 
-	var backendConfig string
-	DisableTFK8SBackend := os.Getenv("DISABLE_TF_K8S_BACKEND") == "1"
-
-	if terraform.Spec.BackendConfig != nil && terraform.Spec.BackendConfig.CustomConfiguration != "" {
-		backendConfig = fmt.Sprintf(`
-terraform {
-  %v
-}
-`,
-			terraform.Spec.BackendConfig.CustomConfiguration)
-	} else if terraform.Spec.BackendConfig != nil {
-		backendConfig = fmt.Sprintf(`
-terraform {
-  backend "kubernetes" {
-    secret_suffix     = "%s"
-    in_cluster_config = %v
-    config_path       = "%s"
-    namespace         = "%s"
-    labels            = {
-      %s
-    }
-  }
-}
-`,
-			terraform.Spec.BackendConfig.SecretSuffix,
-			terraform.Spec.BackendConfig.InClusterConfig,
-			terraform.Spec.BackendConfig.ConfigPath,
-			terraform.Namespace,
-			getLabelsAsHCL(terraform.Spec.BackendConfig.Labels, 6))
-	} else if DisableTFK8SBackend && terraform.Spec.BackendConfig == nil {
-		backendConfig = `
-terraform {
-  backend "local" { }
-}`
-	} else if terraform.Spec.BackendConfig == nil {
-		// TODO must be tested in cluster only
-		backendConfig = fmt.Sprintf(`
-terraform {
-  backend "kubernetes" {
-    secret_suffix     = "%s"
-    in_cluster_config = true
-    namespace         = "%s"
-    labels            = {
-      %s
-    }
-  }
-}
-`,
-			terraform.Name,
-			terraform.Namespace,
-			getLabelsAsHCL(nil, 6))
-	}
+	backendConfig := r.backendConfig(terraform)
 
 	if r.backendCompletelyDisable(terraform) {
 		log.Info("backendConfig is completely disabled. When Spec.Cloud is not nil, backendConfig is disabled by default too.")
@@ -322,8 +285,19 @@ terraform {
 		}
 	}
 
+	upstreamOutputs, upstreamOutputsHash, err := r.upstreamOutputs(ctx, terraform)
+	if err != nil {
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.VarsGenerationFailedReason,
+			err.Error(),
+		), tfInstance, tmpDir, err
+	}
+
 	generateVarsForTFReply, err := runnerClient.GenerateVarsForTF(ctx, &runner.GenerateVarsForTFRequest{
-		WorkingDir: workingDir,
+		WorkingDir:       workingDir,
+		TerraformOutputs: upstreamOutputs,
 	})
 	if err != nil {
 		// transient error?
@@ -335,6 +309,7 @@ terraform {
 		), tfInstance, tmpDir, err
 	}
 	log.Info(fmt.Sprintf("generate vars from tf: %s", generateVarsForTFReply.Message))
+	terraform.Status.UpstreamOutputsHash = upstreamOutputsHash
 
 	log.Info("generated var files from spec")
 
@@ -353,6 +328,32 @@ terraform {
 
 	log.Info("generated template")
 
+	if len(terraform.Spec.Imports) > 0 || len(terraform.Spec.Moves) > 0 {
+		generateImportsAndMovesReply, err := runnerClient.GenerateImportsAndMoves(ctx, &runner.GenerateImportsAndMovesRequest{
+			WorkingDir: workingDir,
+		})
+		if err != nil {
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.ImportsGenerationFailedReason,
+				err.Error(),
+			), tfInstance, tmpDir, err
+		}
+		log.Info(fmt.Sprintf("generate imports and moves: %s", generateImportsAndMovesReply.Message), "imports", generateImportsAndMovesReply.ImportAddresses)
+	}
+
+	// A changed backend configuration only reaches this point once its state
+	// migration has been approved, as the deletion waits for the approval too.
+	migrationID, migrationRequired := r.pendingStateMigration(terraform)
+	if migrationRequired && r.shouldMigrateState(terraform, migrationID) {
+		terraform, err = r.migrateState(ctx, runnerClient, terraform, tfInstance, workingDir, revision, migrationID)
+		if err != nil {
+			return terraform, tfInstance, tmpDir, err
+		}
+		migrationRequired = false
+	}
+
 	// TODO we currently use a fork version of TFExec to workaround the forceCopy bug
 	// https://github.com/hashicorp/terraform-exec/issues/262
 
@@ -389,6 +390,11 @@ terraform {
 
 	log.Info("tfexec initialized terraform")
 
+	if !migrationRequired {
+		stateBackendConfig := r.stateBackendConfig(terraform)
+		terraform = infrav1.TerraformBackendInitialized(terraform, stateBackendConfigHash(stateBackendConfig, terraform.Spec.BackendConfigsFrom), stateBackendConfig)
+	}
+
 	workspaceRequest := &runner.WorkspaceRequest{
 		TfInstance: tfInstance,
 		// Terraform:  terraformBytes,
@@ -438,14 +444,73 @@ terraform {
 	return terraform, tfInstance, tmpDir, nil
 }
 
+// backendConfig renders the backend_override.tf for the Terraform object.
+func (r *TerraformReconciler) backendConfig(terraform *infrav1.Terraform) string {
+	var backendConfig string
+	DisableTFK8SBackend := os.Getenv("DISABLE_TF_K8S_BACKEND") == "1"
+
+	if terraform.Spec.BackendConfig != nil && terraform.Spec.BackendConfig.CustomConfiguration != "" {
+		backendConfig = fmt.Sprintf(`
+terraform {
+  %v
+}
+`,
+			terraform.Spec.BackendConfig.CustomConfiguration)
+	} else if terraform.Spec.BackendConfig != nil {
+		backendConfig = fmt.Sprintf(`
+terraform {
+  backend "kubernetes" {
+    secret_suffix     = "%s"
+    in_cluster_config = %v
+    config_path       = "%s"
+    namespace         = "%s"
+    labels            = {
+      %s
+    }
+  }
+}
+`,
+			terraform.Spec.BackendConfig.SecretSuffix,
+			terraform.Spec.BackendConfig.InClusterConfig,
+			terraform.Spec.BackendConfig.ConfigPath,
+			terraform.Namespace,
+			getLabelsAsHCL(terraform.Spec.BackendConfig.Labels, 6))
+	} else if DisableTFK8SBackend && terraform.Spec.BackendConfig == nil {
+		backendConfig = `
+terraform {
+  backend "local" { }
+}`
+	} else if terraform.Spec.BackendConfig == nil {
+		// TODO must be tested in cluster only
+		backendConfig = fmt.Sprintf(`
+terraform {
+  backend "kubernetes" {
+    secret_suffix     = "%s"
+    in_cluster_config = true
+    namespace         = "%s"
+    labels            = {
+      %s
+    }
+  }
+}
+`,
+			terraform.Name,
+			terraform.Namespace,
+			getLabelsAsHCL(nil, 6))
+	}
+
+	return backendConfig
+}
+
 func getLabelsAsHCL(labels map[string]string, indent int) string {
 	var result string
-	for k, v := range labels {
+	// sort the keys, so that the rendered backend config is stable
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
 		// print space for indentation
 		for range indent {
 			result += " "
 		}
-		result = result + fmt.Sprintf("%q = %q\n", k, v)
+		result = result + fmt.Sprintf("%q = %q\n", k, labels[k])
 	}
 
 	return strings.TrimSpace(result)
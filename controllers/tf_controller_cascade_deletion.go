@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/flux-iac/tofu-controller/api/graph"
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// isCascadeDeletion reports whether the dependants of the object are deleted
+// with it.
+func isCascadeDeletion(terraform *infrav1.Terraform) bool {
+	return terraform.GetAnnotations()[infrav1.CascadeDeletionAnnotation] == "true"
+}
+
+// cascadeDeletion deletes the dependants of an object being deleted, in
+// reverse dependency order: a dependant is deleted once all of its own
+// dependants are gone, that is once their destroy, if any, has finished. It
+// returns a message describing the progress, or an empty message when no
+// dependant is left.
+//
+// Only the dependants in the namespace of the object are deleted. The
+// dependants in other namespaces are reported, and block the deletion of the
+// objects they depend on.
+func (r *TerraformReconciler) cascadeDeletion(ctx context.Context, terraform *infrav1.Terraform) (string, error) {
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list, client.InNamespace(terraform.Namespace)); err != nil {
+		return "", err
+	}
+
+	g := graph.New(list.Items)
+	root := client.ObjectKeyFromObject(terraform)
+	remaining := g.DependantsOf(root)
+
+	external, err := r.crossNamespaceDependants(ctx, append([]types.NamespacedName{root}, remaining...))
+	if err != nil {
+		return "", err
+	}
+	var skipped []string
+	for _, dependants := range external {
+		for _, d := range dependants {
+			skipped = append(skipped, d.String())
+		}
+	}
+	sort.Strings(skipped)
+	skipped = slices.Compact(skipped)
+
+	var skippedMsg string
+	if len(skipped) > 0 {
+		skippedMsg = fmt.Sprintf("dependants in other namespaces are not deleted: %s", strings.Join(skipped, ", "))
+		r.Eventf(terraform, corev1.EventTypeWarning, infrav1.CascadingDeletionReason,
+			"Dependants in other namespaces are not deleted: %s", strings.Join(skipped, ", "))
+	}
+
+	if len(remaining) == 0 {
+		if skippedMsg != "" {
+			return "Cascading deletion blocked, " + skippedMsg, nil
+		}
+		return "", nil
+	}
+
+	isRemaining := make(map[types.NamespacedName]bool, len(remaining))
+	for _, node := range remaining {
+		isRemaining[node] = true
+	}
+
+	var deleting []string
+	for _, node := range remaining {
+		dependant := g.Terraform(node)
+		if !dependant.DeletionTimestamp.IsZero() {
+			deleting = append(deleting, node.String())
+			continue
+		}
+
+		// the dependants in other namespaces are never deleted
+		waiting := len(external[node]) > 0
+		for _, d := range g.Dependants(node) {
+			if isRemaining[d] {
+				waiting = true
+				break
+			}
+		}
+		if waiting {
+			continue
+		}
+
+		if err := r.Delete(ctx, dependant); apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return "", fmt.Errorf("unable to delete dependant %s: %w", node, err)
+		}
+		r.Eventf(terraform, corev1.EventTypeNormal, infrav1.CascadingDeletionReason, "Deleting dependant %s", node)
+		deleting = append(deleting, node.String())
+	}
+
+	if len(deleting) == 0 {
+		if skippedMsg != "" {
+			return fmt.Sprintf("Cascading deletion blocked, %d dependants remaining, %s", len(remaining), skippedMsg), nil
+		}
+		return fmt.Sprintf("Cascading deletion blocked, %d dependants remaining in a dependency cycle", len(remaining)), nil
+	}
+
+	msg := fmt.Sprintf("Cascading deletion in progress, %d dependants remaining, deleting %s", len(remaining), strings.Join(deleting, ", "))
+	if skippedMsg != "" {
+		msg += ", " + skippedMsg
+	}
+	return msg, nil
+}
+
+// crossNamespaceDependants returns, by node, the objects depending on the
+// nodes from another namespace.
+func (r *TerraformReconciler) crossNamespaceDependants(ctx context.Context, nodes []types.NamespacedName) (map[types.NamespacedName][]types.NamespacedName, error) {
+	dependants := map[types.NamespacedName][]types.NamespacedName{}
+	for _, node := range nodes {
+		var list infrav1.TerraformList
+		if err := r.List(ctx, &list, client.MatchingFields{infrav1.DependsOnIndexKey: node.String()}); err != nil {
+			return nil, err
+		}
+		for _, t := range list.Items {
+			if t.Namespace != node.Namespace {
+				dependants[node] = append(dependants[node], client.ObjectKeyFromObject(&t))
+			}
+		}
+	}
+	return dependants, nil
+}
@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestCascadeDeletion(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	terraform := func(name string, dependsOn ...string) *infrav1.Terraform {
+		obj := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  "infra",
+			Finalizers: []string{infrav1.TerraformFinalizer},
+		}}
+		for _, d := range dependsOn {
+			obj.Spec.DependsOn = append(obj.Spec.DependsOn, meta.NamespacedObjectReference{Name: d})
+		}
+		return obj
+	}
+
+	// network <- app <- frontend, network <- dns
+	network := terraform("network")
+	network.Annotations = map[string]string{infrav1.CascadeDeletionAnnotation: "true"}
+	objects := []client.Object{network, terraform("app", "network"), terraform("frontend", "app"), terraform("dns", "network"), terraform("unrelated")}
+
+	r := &TerraformReconciler{EventRecorder: record.NewFakeRecorder(10)}
+	r.Client = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithIndex(&infrav1.Terraform{}, infrav1.DependsOnIndexKey, r.IndexDependsOn).
+		Build()
+	g.Expect(isCascadeDeletion(network)).To(BeTrue())
+
+	isDeleting := func(name string) bool {
+		obj := &infrav1.Terraform{}
+		g.Expect(r.Get(t.Context(), client.ObjectKey{Namespace: "infra", Name: name}, obj)).To(Succeed())
+		return !obj.DeletionTimestamp.IsZero()
+	}
+	finalize := func(name string) {
+		obj := &infrav1.Terraform{}
+		g.Expect(r.Get(t.Context(), client.ObjectKey{Namespace: "infra", Name: name}, obj)).To(Succeed())
+		controllerutil.RemoveFinalizer(obj, infrav1.TerraformFinalizer)
+		g.Expect(r.Update(t.Context(), obj)).To(Succeed())
+	}
+
+	// the dependants without dependants are deleted first
+	msg, err := r.cascadeDeletion(t.Context(), network)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(msg).To(Equal("Cascading deletion in progress, 3 dependants remaining, deleting infra/frontend, infra/dns"))
+	g.Expect(isDeleting("frontend")).To(BeTrue())
+	g.Expect(isDeleting("dns")).To(BeTrue())
+	g.Expect(isDeleting("app")).To(BeFalse())
+	g.Expect(isDeleting("unrelated")).To(BeFalse())
+
+	// app waits for frontend to be finalized
+	finalize("dns")
+	msg, err = r.cascadeDeletion(t.Context(), network)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(msg).To(Equal("Cascading deletion in progress, 2 dependants remaining, deleting infra/frontend"))
+	g.Expect(isDeleting("app")).To(BeFalse())
+
+	finalize("frontend")
+	msg, err = r.cascadeDeletion(t.Context(), network)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(msg).To(Equal("Cascading deletion in progress, 1 dependants remaining, deleting infra/app"))
+	g.Expect(isDeleting("app")).To(BeTrue())
+
+	finalize("app")
+	msg, err = r.cascadeDeletion(t.Context(), network)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(msg).To(BeEmpty())
+}
+
+func TestCascadeDeletionSkipsOtherNamespaces(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	// network <- app <- apps/frontend, network <- dns
+	network := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "network", Namespace: "infra"}}
+	network.Annotations = map[string]string{infrav1.CascadeDeletionAnnotation: "true"}
+	app := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "infra"}}
+	app.Spec.DependsOn = []meta.NamespacedObjectReference{{Name: "network"}}
+	frontend := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "apps"}}
+	frontend.Spec.DependsOn = []meta.NamespacedObjectReference{{Name: "app", Namespace: "infra"}}
+	dns := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "infra"}}
+	dns.Spec.DependsOn = []meta.NamespacedObjectReference{{Name: "network"}}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &TerraformReconciler{EventRecorder: recorder}
+	r.Client = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(network, app, frontend, dns).
+		WithIndex(&infrav1.Terraform{}, infrav1.DependsOnIndexKey, r.IndexDependsOn).
+		Build()
+
+	msg, err := r.cascadeDeletion(t.Context(), network)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(msg).To(Equal("Cascading deletion in progress, 2 dependants remaining, deleting infra/dns, " +
+		"dependants in other namespaces are not deleted: apps/frontend"))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring("Dependants in other namespaces are not deleted: apps/frontend")))
+
+	// app is kept, as the dependant in the other namespace depends on it
+	g.Expect(r.Get(t.Context(), client.ObjectKeyFromObject(frontend), &infrav1.Terraform{})).To(Succeed())
+	g.Expect(r.Get(t.Context(), client.ObjectKeyFromObject(app), &infrav1.Terraform{})).To(Succeed())
+	g.Expect(r.Get(t.Context(), client.ObjectKeyFromObject(dns), &infrav1.Terraform{})).ToNot(Succeed())
+
+	msg, err = r.cascadeDeletion(t.Context(), network)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(msg).To(Equal("Cascading deletion blocked, 1 dependants remaining, dependants in other namespaces are not deleted: apps/frontend"))
+}
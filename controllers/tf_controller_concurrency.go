@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// concurrencyLimiter counts the reconciliations holding a runner, per
+// namespace and per tenant, so that a source change fanning out to many
+// objects of a namespace or a tenant does not take all the workers.
+type concurrencyLimiter struct {
+	maxPerNamespace int
+	maxPerTenant    int
+
+	mu         sync.Mutex
+	namespaces map[string]int
+	tenants    map[string]int
+}
+
+func newConcurrencyLimiter(maxPerNamespace, maxPerTenant int) *concurrencyLimiter {
+	return &concurrencyLimiter{
+		maxPerNamespace: maxPerNamespace,
+		maxPerTenant:    maxPerTenant,
+		namespaces:      map[string]int{},
+		tenants:         map[string]int{},
+	}
+}
+
+// tryAcquire takes a slot in the namespace and in the tenant, if any. It
+// returns the function releasing the slot, or nil and the quota reached.
+func (l *concurrencyLimiter) tryAcquire(namespace, tenant string) (func(), string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.maxPerNamespace > 0 && l.namespaces[namespace] >= l.maxPerNamespace {
+		return nil, fmt.Sprintf("namespace %s reached its quota of %d concurrent reconciliations", namespace, l.maxPerNamespace)
+	}
+	if tenant != "" && l.maxPerTenant > 0 && l.tenants[tenant] >= l.maxPerTenant {
+		return nil, fmt.Sprintf("tenant %s reached its quota of %d concurrent reconciliations", tenant, l.maxPerTenant)
+	}
+
+	l.namespaces[namespace]++
+	if tenant != "" {
+		l.tenants[tenant]++
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+
+			if l.namespaces[namespace]--; l.namespaces[namespace] == 0 {
+				delete(l.namespaces, namespace)
+			}
+			if tenant != "" {
+				if l.tenants[tenant]--; l.tenants[tenant] == 0 {
+					delete(l.tenants, tenant)
+				}
+			}
+		})
+	}, ""
+}
+
+// getQuotaRetryDelay returns the base delay before retrying a reconciliation
+// blocked by a quota.
+func (r *TerraformReconciler) getQuotaRetryDelay() time.Duration {
+	if r.QuotaRetryDelay > 0 {
+		return r.QuotaRetryDelay
+	}
+	return 5 * time.Second
+}
+
+// acquireConcurrencySlot takes a slot for the reconciliation of the object,
+// when concurrency quotas are configured. It returns the function releasing
+// the slot, or nil and the quota reached.
+func (r *TerraformReconciler) acquireConcurrencySlot(ctx context.Context, terraform *infrav1.Terraform) (func(), string, error) {
+	if r.concurrency == nil {
+		return func() {}, "", nil
+	}
+
+	var tenant string
+	if r.MaxConcurrentPerTenant > 0 && r.TenantLabel != "" {
+		namespace := &corev1.Namespace{}
+		if err := r.Get(ctx, types.NamespacedName{Name: terraform.GetNamespace()}, namespace); err != nil {
+			return nil, "", fmt.Errorf("unable to get the tenant of namespace %s: %w", terraform.GetNamespace(), err)
+		}
+		tenant = namespace.GetLabels()[r.TenantLabel]
+	}
+
+	release, msg := r.concurrency.tryAcquire(terraform.GetNamespace(), tenant)
+	return release, msg, nil
+}
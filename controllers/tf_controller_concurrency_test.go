@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConcurrencyLimiter(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	l := newConcurrencyLimiter(2, 3)
+
+	releaseA1, _ := l.tryAcquire("team-a-dev", "team-a")
+	g.Expect(releaseA1).ToNot(BeNil())
+	releaseA2, _ := l.tryAcquire("team-a-dev", "team-a")
+	g.Expect(releaseA2).ToNot(BeNil())
+
+	release, msg := l.tryAcquire("team-a-dev", "team-a")
+	g.Expect(release).To(BeNil())
+	g.Expect(msg).To(Equal("namespace team-a-dev reached its quota of 2 concurrent reconciliations"))
+
+	releaseA3, _ := l.tryAcquire("team-a-prod", "team-a")
+	g.Expect(releaseA3).ToNot(BeNil())
+	release, msg = l.tryAcquire("team-a-prod", "team-a")
+	g.Expect(release).To(BeNil())
+	g.Expect(msg).To(Equal("tenant team-a reached its quota of 3 concurrent reconciliations"))
+
+	// the namespaces without tenant have the namespace quota only
+	release, _ = l.tryAcquire("sandbox", "")
+	g.Expect(release).ToNot(BeNil())
+
+	// releasing twice frees a single slot
+	releaseA1()
+	releaseA1()
+	release, _ = l.tryAcquire("team-a-prod", "team-a")
+	g.Expect(release).ToNot(BeNil())
+	release, _ = l.tryAcquire("team-a-prod", "team-a")
+	g.Expect(release).To(BeNil())
+}
+
+func TestAcquireConcurrencySlot(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a-dev", Labels: map[string]string{"toolkit.fluxcd.io/tenant": "team-a"}}}
+	r := &TerraformReconciler{MaxConcurrentPerTenant: 1, TenantLabel: "toolkit.fluxcd.io/tenant"}
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace).Build()
+
+	terraform := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a-dev"}}
+
+	// no quota without limiter
+	release, _, err := r.acquireConcurrencySlot(t.Context(), terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(release).ToNot(BeNil())
+
+	r.concurrency = newConcurrencyLimiter(0, 1)
+	release, _, err = r.acquireConcurrencySlot(t.Context(), terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(release).ToNot(BeNil())
+
+	blocked, msg, err := r.acquireConcurrencySlot(t.Context(), terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(blocked).To(BeNil())
+	g.Expect(msg).To(ContainSubstring("tenant team-a"))
+
+	release()
+	release, _, err = r.acquireConcurrencySlot(t.Context(), terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(release).ToNot(BeNil())
+}
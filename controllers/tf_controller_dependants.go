@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/runtime/conditions"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// isDependencyReady reports whether a Terraform object is ready for its
+// dependants, that is ready at its latest generation.
+func isDependencyReady(terraform *infrav1.Terraform) bool {
+	return terraform.Generation == terraform.Status.ObservedGeneration && conditions.IsTrue(terraform, meta.ReadyCondition)
+}
+
+// IndexDependsOn indexes a Terraform object by the Terraform objects it
+// depends on, with .spec.dependsOn or .spec.varsFrom.
+func (r *TerraformReconciler) IndexDependsOn(o client.Object) []string {
+	terraform, ok := o.(*infrav1.Terraform)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Terraform, got %T", o))
+	}
+
+	var keys []string
+	for _, d := range terraform.GetDependsOn() {
+		namespace := d.Namespace
+		if namespace == "" {
+			namespace = terraform.GetNamespace()
+		}
+		keys = append(keys, types.NamespacedName{Namespace: namespace, Name: d.Name}.String())
+	}
+	return keys
+}
+
+// requestsForDependencyChangeOf maps a Terraform object to its dependants,
+// so they proceed as soon as it is ready, instead of at their retry interval.
+func (r *TerraformReconciler) requestsForDependencyChangeOf(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := ctrl.LoggerFrom(ctx)
+
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list, client.MatchingFields{
+		infrav1.DependsOnIndexKey: client.ObjectKeyFromObject(obj).String(),
+	}); err != nil {
+		log.Error(err, "failed to list objects for dependency change")
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, 0, len(list.Items))
+	for _, t := range list.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&t)})
+	}
+	return reqs
+}
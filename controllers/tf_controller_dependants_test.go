@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestDependencyReadyPredicate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	notReady := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "network", Namespace: "flux-system", Generation: 2}}
+	notReady.Status.ObservedGeneration = 2
+	notReady.Status.Conditions = []metav1.Condition{{Type: meta.ReadyCondition, Status: metav1.ConditionFalse}}
+
+	ready := notReady.DeepCopy()
+	ready.Status.Conditions[0].Status = metav1.ConditionTrue
+	ready.Status.LastAppliedRevision = "main@sha1:a"
+
+	update := func(old, new *infrav1.Terraform) bool {
+		return DependencyReadyPredicate{}.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: new})
+	}
+
+	g.Expect(update(notReady, ready)).To(BeTrue())
+	g.Expect(update(ready, notReady)).To(BeFalse())
+	g.Expect(update(ready, ready.DeepCopy())).To(BeFalse())
+
+	// ready at an older generation only
+	stale := ready.DeepCopy()
+	stale.Generation = 3
+	g.Expect(update(notReady, stale)).To(BeFalse())
+
+	applied := ready.DeepCopy()
+	applied.Status.LastAppliedRevision = "main@sha1:b"
+	g.Expect(update(ready, applied)).To(BeTrue())
+
+	published := ready.DeepCopy()
+	published.Status.OutputsHistory = []infrav1.OutputsRevision{{Revision: "main@sha1:a", Hashes: map[string]string{"vpc_id": "1"}}}
+	g.Expect(update(ready, published)).To(BeTrue())
+}
+
+func TestRequestsForDependencyChangeOf(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	network := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "network", Namespace: "infra"}}
+	app := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "infra"}}
+	app.Spec.DependsOn = []meta.NamespacedObjectReference{{Name: "network"}}
+	dns := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "platform"}}
+	dns.Spec.VarsFrom = []infrav1.VarsReference{{Kind: infrav1.TerraformKind, Name: "network", Namespace: "infra"}}
+	other := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "platform"}}
+	other.Spec.DependsOn = []meta.NamespacedObjectReference{{Name: "network"}}
+
+	r := &TerraformReconciler{}
+	r.Client = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(network, app, dns, other).
+		WithIndex(&infrav1.Terraform{}, infrav1.DependsOnIndexKey, r.IndexDependsOn).
+		Build()
+
+	reqs := r.requestsForDependencyChangeOf(t.Context(), network)
+	g.Expect(reqs).To(ConsistOf(
+		reconcile.Request{NamespacedName: client.ObjectKeyFromObject(app)},
+		reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dns)},
+	))
+}
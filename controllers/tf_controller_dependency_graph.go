@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/flux-iac/tofu-controller/api/graph"
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// dependencyCycle returns the dependency cycle the object is part of, if any.
+// Such an object never becomes ready, as it waits for itself. All the objects
+// of the cycle depend on the object, so the graph is only built from its
+// dependants, found with the DependsOnIndexKey index.
+func (r *TerraformReconciler) dependencyCycle(ctx context.Context, terraform *infrav1.Terraform) ([]types.NamespacedName, error) {
+	// The cache may not have the latest spec of the object yet.
+	key := client.ObjectKeyFromObject(terraform)
+	items := []infrav1.Terraform{*terraform}
+
+	seen := map[types.NamespacedName]bool{key: true}
+	queue := []types.NamespacedName{key}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		opts := []client.ListOption{client.MatchingFields{infrav1.DependsOnIndexKey: node.String()}}
+		if r.NoCrossNamespaceRefs {
+			// cross-namespace dependencies are denied, so they cannot be part of a cycle
+			opts = append(opts, client.InNamespace(node.Namespace))
+		}
+
+		var list infrav1.TerraformList
+		if err := r.List(ctx, &list, opts...); err != nil {
+			return nil, err
+		}
+		for _, t := range list.Items {
+			dependant := client.ObjectKeyFromObject(&t)
+			if seen[dependant] {
+				continue
+			}
+			seen[dependant] = true
+			items = append(items, t)
+			queue = append(queue, dependant)
+		}
+	}
+
+	return graph.New(items).CycleOf(key), nil
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDependencyCycle(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	// network -> dns (platform) -> app -> network
+	network := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "network", Namespace: "infra"}}
+	network.Spec.DependsOn = []meta.NamespacedObjectReference{{Name: "dns", Namespace: "platform"}}
+	dns := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "platform"}}
+	dns.Spec.DependsOn = []meta.NamespacedObjectReference{{Name: "app", Namespace: "infra"}}
+	app := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "infra"}}
+	app.Spec.DependsOn = []meta.NamespacedObjectReference{{Name: "network"}}
+	unrelated := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "infra"}}
+	unrelated.Spec.DependsOn = []meta.NamespacedObjectReference{{Name: "app"}}
+
+	r := &TerraformReconciler{}
+	r.Client = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(network, dns, app, unrelated).
+		WithIndex(&infrav1.Terraform{}, infrav1.DependsOnIndexKey, r.IndexDependsOn).
+		Build()
+
+	cycle, err := r.dependencyCycle(t.Context(), network)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cycle).To(Equal([]types.NamespacedName{
+		{Namespace: "infra", Name: "network"},
+		{Namespace: "platform", Name: "dns"},
+		{Namespace: "infra", Name: "app"},
+		{Namespace: "infra", Name: "network"},
+	}))
+
+	// the cross-namespace dependency is denied, it does not close the cycle
+	r.NoCrossNamespaceRefs = true
+	cycle, err = r.dependencyCycle(t.Context(), network)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cycle).To(BeNil())
+
+	// the spec of the object is more recent than the cache
+	r.NoCrossNamespaceRefs = false
+	network = network.DeepCopy()
+	network.Spec.DependsOn = nil
+	cycle, err = r.dependencyCycle(t.Context(), network)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cycle).To(BeNil())
+}
@@ -7,6 +7,7 @@ import (
 
 	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
 	"github.com/flux-iac/tofu-controller/runner"
+	tfjson "github.com/hashicorp/terraform-json"
 	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -63,7 +64,9 @@ func (r *TerraformReconciler) shouldDetectDrift(terraform *infrav1.Terraform, re
 	return false
 }
 
-func (r *TerraformReconciler) detectDrift(ctx context.Context, terraform *infrav1.Terraform, tfInstance string, runnerClient runner.RunnerClient, revision string, sourceRefRootDir string) (*infrav1.Terraform, error) {
+// detectDrift plans for drift, and returns the action of the drift policy for
+// the detected drift, if the object has a drift policy.
+func (r *TerraformReconciler) detectDrift(ctx context.Context, terraform *infrav1.Terraform, tfInstance string, runnerClient runner.RunnerClient, revision string, sourceRefRootDir string) (*infrav1.Terraform, infrav1.DriftAction, error) {
 
 	log := ctrl.LoggerFrom(ctx)
 
@@ -110,8 +113,16 @@ func (r *TerraformReconciler) detectDrift(ctx context.Context, terraform *infrav
 			revision,
 			infrav1.DriftDetectionFailedReason,
 			err.Error(),
-		), err
+		), "", err
 	}
+
+	// the failures after the plan are reported like the ones of the plan
+	failed := func(err error) (*infrav1.Terraform, infrav1.DriftAction, error) {
+		msg := fmt.Sprintf("Drift detection error: %s", err.Error())
+		r.Eventf(terraform, corev1.EventTypeWarning, infrav1.DriftDetectionFailedReason, "%s", msg)
+		return infrav1.TerraformNotReady(terraform, revision, infrav1.DriftDetectionFailedReason, err.Error()), "", err
+	}
+
 	drifted := planReply.Drifted
 	log.Info(fmt.Sprintf("plan for drift: %s found drift: %v", planReply.Message, planReply.Drifted))
 
@@ -125,12 +136,7 @@ func (r *TerraformReconciler) detectDrift(ctx context.Context, terraform *infrav
 				Filename:   driftFilename,
 			})
 			if err != nil {
-				return infrav1.TerraformNotReady(
-					terraform,
-					revision,
-					infrav1.DriftDetectionFailedReason,
-					err.Error(),
-				), err
+				return failed(err)
 			}
 			rawOutput = showPlanFileRawReply.RawOutput
 			log.Info(fmt.Sprintf("show plan: %s", showPlanFileRawReply.RawOutput))
@@ -139,14 +145,59 @@ func (r *TerraformReconciler) detectDrift(ctx context.Context, terraform *infrav
 		// Clean up the message for Terraform v1.1.9.
 		rawOutput = strings.Replace(rawOutput, "You can apply this plan to save these new output values to the Terraform\nstate, without changing any real infrastructure.", "", 1)
 
+		var driftPlan *tfjson.Plan
+		if !r.backendCompletelyDisable(terraform) {
+			driftPlan, err = r.showDriftPlan(ctx, runnerClient, tfInstance, driftFilename)
+			if err != nil {
+				return failed(err)
+			}
+		}
+
 		msg := fmt.Sprintf("Drift detected.\n%s", rawOutput)
+
+		var driftAction infrav1.DriftAction
+		var resources []driftedResource
+		if terraform.Spec.DriftPolicy != nil {
+			driftAction, resources = r.evaluateDriftPolicy(terraform, driftPlan)
+
+			// the drift of the ignored resources does not count
+			if driftAction == infrav1.DriftActionIgnore {
+				log.Info(fmt.Sprintf("drift of %d resources ignored by the drift policy", len(resources)))
+				if err := r.deleteDriftReport(ctx, terraform); err != nil {
+					return failed(err)
+				}
+				terraform = infrav1.TerraformNoDrift(terraform, revision, infrav1.NoDriftReason, fmt.Sprintf("No drift, %d changed resources ignored by the drift policy", len(resources)))
+				return terraform, driftAction, nil
+			}
+
+			if drifted := formatDriftedResources(resources); drifted != "" {
+				msg = fmt.Sprintf("Drift detected in %s, handled with the %s action of the drift policy.\n%s", drifted, driftAction, rawOutput)
+			} else {
+				msg = fmt.Sprintf("Drift detected, handled with the %s action of the drift policy.\n%s", driftAction, rawOutput)
+			}
+		}
+
+		// without the drift plan, when the backend is disabled, there is no report
+		if driftPlan != nil {
+			err = r.writeDriftReport(ctx, terraform, newDriftReport(driftPlan, revision, resources))
+		} else {
+			err = r.deleteDriftReport(ctx, terraform)
+		}
+		if err != nil {
+			return failed(err)
+		}
+
 		r.Eventf(terraform, corev1.EventTypeWarning, infrav1.DriftDetectedReason, "%s", msg)
 
 		// If drift detected & we use the auto mode, then we continue
 		terraform = infrav1.TerraformDriftDetected(terraform, revision, infrav1.DriftDetectedReason, rawOutput)
-		return terraform, fmt.Errorf(infrav1.DriftDetectedReason)
+		return terraform, driftAction, fmt.Errorf(infrav1.DriftDetectedReason)
+	}
+
+	if err := r.deleteDriftReport(ctx, terraform); err != nil {
+		return failed(err)
 	}
 
 	terraform = infrav1.TerraformNoDrift(terraform, revision, infrav1.NoDriftReason, "No drift")
-	return terraform, nil
+	return terraform, "", nil
 }
@@ -0,0 +1,223 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/flux-iac/tofu-controller/runner"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// driftActionPrecedence orders the drift actions from the least to the most
+// cautious. A plan is applied as a whole, so the drift is handled with the
+// most cautious action of its resources.
+var driftActionPrecedence = map[infrav1.DriftAction]int{
+	infrav1.DriftActionIgnore:          0,
+	infrav1.DriftActionAutoRemediate:   1,
+	infrav1.DriftActionRequireApproval: 2,
+	infrav1.DriftActionNotify:          3,
+}
+
+// driftedResource is a resource changed by the drift plan, with the action of
+// the drift policy for it.
+type driftedResource struct {
+	address string
+	action  infrav1.DriftAction
+}
+
+// defaultDriftAction returns the action of the resources matched by no rule.
+func (r *TerraformReconciler) defaultDriftAction(terraform *infrav1.Terraform) infrav1.DriftAction {
+	if terraform.Spec.DriftPolicy != nil && terraform.Spec.DriftPolicy.DefaultAction != "" {
+		return terraform.Spec.DriftPolicy.DefaultAction
+	}
+	if terraform.Spec.Force || terraform.Spec.ApprovePlan == infrav1.ApprovePlanAutoValue {
+		return infrav1.DriftActionAutoRemediate
+	}
+	return infrav1.DriftActionRequireApproval
+}
+
+// showDriftPlan returns the drift plan saved in the given file.
+func (r *TerraformReconciler) showDriftPlan(ctx context.Context, runnerClient runner.RunnerClient, tfInstance string, filename string) (*tfjson.Plan, error) {
+	reply, err := runnerClient.ShowPlanFile(ctx, &runner.ShowPlanFileRequest{
+		TfInstance: tfInstance,
+		Filename:   filename,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to show the drift plan: %w", err)
+	}
+
+	var plan tfjson.Plan
+	if err := json.Unmarshal(reply.JsonOutput, &plan); err != nil {
+		return nil, fmt.Errorf("unable to parse the drift plan: %w", err)
+	}
+	return &plan, nil
+}
+
+// evaluateDriftPolicy returns the action of every resource changed by the
+// plan, and the action for the whole plan. A plan changing no resource, only
+// outputs, gets the default action, as well as a missing plan when the
+// backend is disabled.
+func (r *TerraformReconciler) evaluateDriftPolicy(terraform *infrav1.Terraform, plan *tfjson.Plan) (infrav1.DriftAction, []driftedResource) {
+	defaultAction := r.defaultDriftAction(terraform)
+	if plan == nil {
+		return defaultAction, nil
+	}
+
+	var resources []driftedResource
+	for _, rc := range plan.ResourceChanges {
+		if !isResourceChanged(rc) {
+			continue
+		}
+
+		action := defaultAction
+		if rule := matchDriftRule(terraform.Spec.DriftPolicy, rc); rule != nil {
+			if rule.Action != "" {
+				action = rule.Action
+			}
+			if len(rule.IgnoreAttributes) > 0 && rc.Change.Actions.Update() &&
+				len(withoutIgnoredAttributes(changedAttributes(rc.Change.Before, rc.Change.After), rule.IgnoreAttributes)) == 0 {
+				action = infrav1.DriftActionIgnore
+			}
+		}
+		resources = append(resources, driftedResource{address: rc.Address, action: action})
+	}
+
+	if len(resources) == 0 {
+		return defaultAction, nil
+	}
+
+	action := infrav1.DriftActionIgnore
+	for _, resource := range resources {
+		if driftActionPrecedence[resource.action] > driftActionPrecedence[action] {
+			action = resource.action
+		}
+	}
+	return action, resources
+}
+
+// isResourceChanged reports whether the plan changes a managed resource.
+func isResourceChanged(rc *tfjson.ResourceChange) bool {
+	return rc.Change != nil && rc.Mode != tfjson.DataResourceMode && !rc.Change.Actions.NoOp() && !rc.Change.Actions.Read()
+}
+
+// matchDriftRule returns the first rule of the policy matching the resource.
+func matchDriftRule(policy *infrav1.DriftPolicy, rc *tfjson.ResourceChange) *infrav1.DriftRule {
+	if policy == nil {
+		return nil
+	}
+
+	for i, rule := range policy.Rules {
+		if len(rule.Types) > 0 && !slices.Contains(rule.Types, rc.Type) {
+			continue
+		}
+		if len(rule.Addresses) > 0 && !matchesAnyAddress(rule.Addresses, rc.Address) {
+			continue
+		}
+		return &policy.Rules[i]
+	}
+	return nil
+}
+
+// matchesAnyAddress matches an address against patterns where * matches any
+// sequence of characters. The other characters, like the brackets of the
+// instance keys, are matched literally.
+func matchesAnyAddress(patterns []string, address string) bool {
+	for _, pattern := range patterns {
+		expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+		if ok, _ := regexp.MatchString(expr, address); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// changedAttributes returns the paths of the attributes which differ between
+// the before and the after values of a resource, like tags.env or
+// ingress.0.cidr_blocks.
+func changedAttributes(before, after interface{}) []string {
+	var changed []string
+	walkChangedAttributes(nil, before, after, func(path []string, _, _ interface{}) {
+		changed = append(changed, strings.Join(path, "."))
+	})
+	return changed
+}
+
+// walkChangedAttributes calls fn with the path and the values of the
+// attributes which differ between before and after, in the order of their
+// paths. Maps, and lists of the same length, are walked into; other values
+// are compared as a whole, apart from the values redacted by the runner.
+func walkChangedAttributes(path []string, before, after interface{}, fn func(path []string, before, after interface{})) {
+	at := func(key string) []string {
+		return append(slices.Clone(path), key)
+	}
+
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if beforeIsMap && afterIsMap {
+		keys := map[string]bool{}
+		for k := range beforeMap {
+			keys[k] = true
+		}
+		for k := range afterMap {
+			keys[k] = true
+		}
+
+		for _, k := range slices.Sorted(maps.Keys(keys)) {
+			walkChangedAttributes(at(k), beforeMap[k], afterMap[k], fn)
+		}
+		return
+	}
+
+	beforeList, beforeIsList := before.([]interface{})
+	afterList, afterIsList := after.([]interface{})
+	if beforeIsList && afterIsList && len(beforeList) == len(afterList) {
+		for i := range beforeList {
+			walkChangedAttributes(at(strconv.Itoa(i)), beforeList[i], afterList[i], fn)
+		}
+		return
+	}
+
+	// two redacted values may differ, the attribute is reported as changed
+	if !reflect.DeepEqual(before, after) || (before == runner.RedactedValue && after == runner.RedactedValue) {
+		fn(path, before, after)
+	}
+}
+
+// withoutIgnoredAttributes filters out the paths of the ignored attributes,
+// and of their nested attributes.
+func withoutIgnoredAttributes(paths []string, ignored []string) []string {
+	var kept []string
+	for _, path := range paths {
+		isIgnored := false
+		for _, attribute := range ignored {
+			if path == attribute || strings.HasPrefix(path, attribute+".") {
+				isIgnored = true
+				break
+			}
+		}
+		if !isIgnored {
+			kept = append(kept, path)
+		}
+	}
+	return kept
+}
+
+// formatDriftedResources lists the resources which are not ignored, with
+// their action.
+func formatDriftedResources(resources []driftedResource) string {
+	var parts []string
+	for _, resource := range resources {
+		if resource.action != infrav1.DriftActionIgnore {
+			parts = append(parts, fmt.Sprintf("%s (%s)", resource.address, resource.action))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
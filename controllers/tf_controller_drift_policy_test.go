@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/flux-iac/tofu-controller/runner"
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/runtime/conditions"
+	tfjson "github.com/hashicorp/terraform-json"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"k8s.io/client-go/tools/record"
+)
+
+type mockRunnerClientForDriftPlan struct {
+	runner.RunnerClient
+
+	jsonOutput string
+}
+
+func (m *mockRunnerClientForDriftPlan) Plan(context.Context, *runner.PlanRequest, ...grpc.CallOption) (*runner.PlanReply, error) {
+	return &runner.PlanReply{Drifted: true}, nil
+}
+
+func (m *mockRunnerClientForDriftPlan) ShowPlanFileRaw(context.Context, *runner.ShowPlanFileRawRequest, ...grpc.CallOption) (*runner.ShowPlanFileRawReply, error) {
+	return &runner.ShowPlanFileRawReply{RawOutput: "~ update in-place"}, nil
+}
+
+func (m *mockRunnerClientForDriftPlan) ShowPlanFile(context.Context, *runner.ShowPlanFileRequest, ...grpc.CallOption) (*runner.ShowPlanFileReply, error) {
+	return &runner.ShowPlanFileReply{JsonOutput: []byte(m.jsonOutput)}, nil
+}
+
+func TestEvaluateDriftPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	change := func(address, resourceType string, actions tfjson.Actions, before, after map[string]interface{}) *tfjson.ResourceChange {
+		return &tfjson.ResourceChange{
+			Address: address,
+			Mode:    tfjson.ManagedResourceMode,
+			Type:    resourceType,
+			Change:  &tfjson.Change{Actions: actions, Before: before, After: after},
+		}
+	}
+	update := tfjson.Actions{tfjson.ActionUpdate}
+
+	asg := change("aws_autoscaling_group.web", "aws_autoscaling_group", update,
+		map[string]interface{}{"desired_capacity": 2.0, "tags": map[string]interface{}{"env": "prod", "scaled": "a"}},
+		map[string]interface{}{"desired_capacity": 4.0, "tags": map[string]interface{}{"env": "prod", "scaled": "b"}},
+	)
+	bucket := change("module.logs.aws_s3_bucket.this[\"eu\"]", "aws_s3_bucket", update,
+		map[string]interface{}{"versioning": []interface{}{map[string]interface{}{"enabled": true}}},
+		map[string]interface{}{"versioning": []interface{}{map[string]interface{}{"enabled": false}}},
+	)
+	instance := change("aws_instance.web", "aws_instance", tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}, nil, nil)
+	unchanged := change("aws_vpc.main", "aws_vpc", tfjson.Actions{tfjson.ActionNoop}, nil, nil)
+
+	r := &TerraformReconciler{}
+	evaluate := func(policy infrav1.DriftPolicy, approvePlan string, changes ...*tfjson.ResourceChange) (infrav1.DriftAction, []driftedResource) {
+		terraform := &infrav1.Terraform{Spec: infrav1.TerraformSpec{ApprovePlan: approvePlan, DriftPolicy: &policy}}
+		return r.evaluateDriftPolicy(terraform, &tfjson.Plan{ResourceChanges: changes})
+	}
+
+	// without rules, the drift is remediated as per approvePlan
+	action, resources := evaluate(infrav1.DriftPolicy{}, "auto", asg, unchanged)
+	g.Expect(action).To(Equal(infrav1.DriftActionAutoRemediate))
+	g.Expect(resources).To(Equal([]driftedResource{{address: "aws_autoscaling_group.web", action: infrav1.DriftActionAutoRemediate}}))
+
+	action, _ = evaluate(infrav1.DriftPolicy{}, "", asg)
+	g.Expect(action).To(Equal(infrav1.DriftActionRequireApproval))
+
+	// a resource changing only ignored attributes is ignored
+	scaling := infrav1.DriftRule{Types: []string{"aws_autoscaling_group"}, IgnoreAttributes: []string{"desired_capacity", "tags.scaled"}}
+	action, _ = evaluate(infrav1.DriftPolicy{Rules: []infrav1.DriftRule{scaling}}, "auto", asg)
+	g.Expect(action).To(Equal(infrav1.DriftActionIgnore))
+
+	// but not when another attribute changed too
+	scaling.IgnoreAttributes = []string{"desired_capacity"}
+	action, _ = evaluate(infrav1.DriftPolicy{Rules: []infrav1.DriftRule{scaling}}, "auto", asg)
+	g.Expect(action).To(Equal(infrav1.DriftActionAutoRemediate))
+
+	// the most cautious action wins, and the first matching rule decides
+	policy := infrav1.DriftPolicy{
+		DefaultAction: infrav1.DriftActionAutoRemediate,
+		Rules: []infrav1.DriftRule{
+			{Addresses: []string{"module.logs.aws_s3_bucket.*"}, Action: infrav1.DriftActionRequireApproval},
+			{Types: []string{"aws_s3_bucket", "aws_instance"}, Action: infrav1.DriftActionNotify},
+		},
+	}
+	action, resources = evaluate(policy, "auto", asg, bucket)
+	g.Expect(action).To(Equal(infrav1.DriftActionRequireApproval))
+	g.Expect(formatDriftedResources(resources)).To(Equal(`aws_autoscaling_group.web (auto-remediate), module.logs.aws_s3_bucket.this["eu"] (require-approval)`))
+
+	action, _ = evaluate(policy, "auto", asg, bucket, instance)
+	g.Expect(action).To(Equal(infrav1.DriftActionNotify))
+
+	// a plan changing only outputs gets the default action
+	action, resources = evaluate(infrav1.DriftPolicy{DefaultAction: infrav1.DriftActionNotify}, "auto", unchanged)
+	g.Expect(action).To(Equal(infrav1.DriftActionNotify))
+	g.Expect(resources).To(BeEmpty())
+}
+
+func TestEvaluateDriftPolicyOfRedactedPlan(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// the password comes from a Secret, the runner redacted it in the plan
+	runnerClient := &mockRunnerClientForDriftPlan{jsonOutput: `{
+  "format_version": "1.2",
+  "resource_changes": [
+    {"address": "aws_db_instance.main", "mode": "managed", "type": "aws_db_instance", "change": {
+      "actions": ["update"],
+      "before": {"allocated_storage": 20, "password": "***", "tags": {"owner": "a"}},
+      "after": {"allocated_storage": 20, "password": "***", "tags": {"owner": "b"}},
+      "before_sensitive": {"password": true},
+      "after_sensitive": {"password": true}
+    }}
+  ]
+}`}
+
+	r := &TerraformReconciler{}
+	plan, err := r.showDriftPlan(t.Context(), runnerClient, "1", "tfplan-drift")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// the redacted password may have drifted too, so the resource is not ignored
+	g.Expect(changedAttributes(plan.ResourceChanges[0].Change.Before, plan.ResourceChanges[0].Change.After)).To(Equal([]string{"password", "tags.owner"}))
+
+	terraform := &infrav1.Terraform{Spec: infrav1.TerraformSpec{ApprovePlan: "auto", DriftPolicy: &infrav1.DriftPolicy{
+		Rules: []infrav1.DriftRule{{Types: []string{"aws_db_instance"}, IgnoreAttributes: []string{"tags"}}},
+	}}}
+	action, resources := r.evaluateDriftPolicy(terraform, plan)
+	g.Expect(action).To(Equal(infrav1.DriftActionAutoRemediate))
+	g.Expect(resources).To(Equal([]driftedResource{{address: "aws_db_instance.main", action: infrav1.DriftActionAutoRemediate}}))
+
+	// ignoring the password too ignores the resource
+	terraform.Spec.DriftPolicy.Rules[0].IgnoreAttributes = []string{"tags", "password"}
+	action, _ = r.evaluateDriftPolicy(terraform, plan)
+	g.Expect(action).To(Equal(infrav1.DriftActionIgnore))
+}
+
+func TestDetectDriftReportsFailuresAfterThePlan(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	recorder := record.NewFakeRecorder(10)
+	r := &TerraformReconciler{EventRecorder: recorder}
+
+	// the drift plan cannot be parsed
+	terraform, _, err := r.detectDrift(t.Context(), &infrav1.Terraform{}, "1", &mockRunnerClientForDriftPlan{jsonOutput: "{"}, "main@sha1:1234", "/tmp/source")
+	g.Expect(err).To(MatchError(ContainSubstring("unable to parse the drift plan")))
+	g.Expect(conditions.GetReason(terraform, meta.ReadyCondition)).To(Equal(infrav1.DriftDetectionFailedReason))
+	g.Expect(recorder.Events).To(Receive(HavePrefix("Warning DriftDetectionFailed Drift detection error: unable to parse the drift plan")))
+}
+
+func TestChangedAttributes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	before := map[string]interface{}{
+		"name": "web",
+		"tags": map[string]interface{}{"env": "prod"},
+		"ingress": []interface{}{
+			map[string]interface{}{"cidr_blocks": []interface{}{"10.0.0.0/8"}},
+		},
+		"rules": []interface{}{"a"},
+	}
+	after := map[string]interface{}{
+		"name": "web",
+		"tags": map[string]interface{}{"env": "prod", "owner": "team"},
+		"ingress": []interface{}{
+			map[string]interface{}{"cidr_blocks": []interface{}{"0.0.0.0/0"}},
+		},
+		"rules": []interface{}{"a", "b"},
+	}
+
+	changed := changedAttributes(before, after)
+	g.Expect(changed).To(Equal([]string{"ingress.0.cidr_blocks.0", "rules", "tags.owner"}))
+	g.Expect(withoutIgnoredAttributes(changed, []string{"ingress", "tags.owner"})).To(Equal([]string{"rules"}))
+	g.Expect(withoutIgnoredAttributes(changed, []string{"rule", "tags.own"})).To(Equal(changed))
+}
+
+func TestShouldApplyDriftRemediation(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	r := &TerraformReconciler{}
+	terraform := func(approvePlan string, action infrav1.DriftAction) *infrav1.Terraform {
+		return &infrav1.Terraform{
+			Spec: infrav1.TerraformSpec{ApprovePlan: approvePlan},
+			Status: infrav1.TerraformStatus{
+				Plan: infrav1.PlanStatus{Pending: "plan-main-1234", DriftAction: action},
+			},
+		}
+	}
+
+	// auto-remediate applies the plan even in the manual mode
+	g.Expect(r.forceOrAutoApply(terraform("", infrav1.DriftActionAutoRemediate))).To(BeTrue())
+	g.Expect(r.shouldApply(terraform("", infrav1.DriftActionAutoRemediate))).To(BeTrue())
+
+	// require-approval waits for the plan id even in the auto mode
+	g.Expect(r.forceOrAutoApply(terraform("auto", infrav1.DriftActionRequireApproval))).To(BeFalse())
+	g.Expect(r.shouldApply(terraform("auto", infrav1.DriftActionRequireApproval))).To(BeFalse())
+	g.Expect(r.shouldApply(terraform("plan-main-1234", infrav1.DriftActionRequireApproval))).To(BeTrue())
+
+	// plans not remediating drift follow approvePlan
+	g.Expect(r.shouldApply(terraform("auto", ""))).To(BeTrue())
+	g.Expect(r.shouldApply(terraform("", ""))).To(BeFalse())
+}
@@ -0,0 +1,221 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/flux-iac/tofu-controller/api/drift"
+	"github.com/flux-iac/tofu-controller/api/plan"
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	tfjson "github.com/hashicorp/terraform-json"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// newDriftReport builds the report of the drift plan. The resources ignored
+// by the drift policy are left out, and the sensitive values are masked.
+func newDriftReport(plan *tfjson.Plan, revision string, resources []driftedResource) *drift.Report {
+	actions := map[string]infrav1.DriftAction{}
+	for _, resource := range resources {
+		actions[resource.address] = resource.action
+	}
+
+	report := &drift.Report{
+		Revision:   revision,
+		DetectedAt: metav1.Now(),
+	}
+
+	for _, rc := range plan.ResourceChanges {
+		if !isResourceChanged(rc) || actions[rc.Address] == infrav1.DriftActionIgnore {
+			continue
+		}
+
+		resource := drift.Resource{
+			Address:     rc.Address,
+			Type:        rc.Type,
+			DriftAction: string(actions[rc.Address]),
+		}
+		for _, action := range rc.Change.Actions {
+			resource.Actions = append(resource.Actions, string(action))
+		}
+
+		// the attributes of the resources to create or to delete are not listed
+		_, beforeIsMap := rc.Change.Before.(map[string]interface{})
+		_, afterIsMap := rc.Change.After.(map[string]interface{})
+		if beforeIsMap && afterIsMap {
+			walkChangedAttributes(nil, rc.Change.Before, rc.Change.After, func(path []string, before, after interface{}) {
+				beforeSensitive := marksAt(rc.Change.BeforeSensitive, path)
+				afterSensitive := marksAt(rc.Change.AfterSensitive, path)
+				resource.Attributes = append(resource.Attributes, drift.Attribute{
+					Path:      strings.Join(path, "."),
+					Before:    maskValue(before, beforeSensitive, drift.SensitiveValue),
+					After:     maskValue(maskValue(after, afterSensitive, drift.SensitiveValue), marksAt(rc.Change.AfterUnknown, path), drift.UnknownValue),
+					Sensitive: hasMark(beforeSensitive) || hasMark(afterSensitive),
+				})
+			})
+		}
+
+		report.Resources = append(report.Resources, resource)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(plan.OutputChanges)) {
+		if change := plan.OutputChanges[name]; change != nil && !change.Actions.NoOp() {
+			report.Outputs = append(report.Outputs, name)
+		}
+	}
+
+	return report
+}
+
+// marksAt returns the marks of the value at the path, in the sensitive or
+// unknown marks of a resource: true when the whole value is marked, or the
+// marks of its nested values.
+func marksAt(marks interface{}, path []string) interface{} {
+	for _, key := range path {
+		switch m := marks.(type) {
+		case bool:
+			return m
+		case map[string]interface{}:
+			marks = m[key]
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i >= len(m) {
+				return nil
+			}
+			marks = m[i]
+		default:
+			return nil
+		}
+	}
+	return marks
+}
+
+// hasMark reports whether the value, or any of its nested values, is marked.
+func hasMark(marks interface{}) bool {
+	switch m := marks.(type) {
+	case bool:
+		return m
+	case map[string]interface{}:
+		for _, nested := range m {
+			if hasMark(nested) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, nested := range m {
+			if hasMark(nested) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// maskValue replaces the marked values, nested or not, with the replacement.
+func maskValue(value interface{}, marks interface{}, replacement string) interface{} {
+	switch m := marks.(type) {
+	case bool:
+		if m {
+			return replacement
+		}
+	case map[string]interface{}:
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		masked := make(map[string]interface{}, len(values))
+		for k, v := range values {
+			masked[k] = maskValue(v, m[k], replacement)
+		}
+		// the values known after apply are missing from the plan
+		for k, nested := range m {
+			if _, ok := values[k]; !ok && nested == true {
+				masked[k] = replacement
+			}
+		}
+		return masked
+	case []interface{}:
+		values, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+		masked := make([]interface{}, len(values))
+		for i, v := range values {
+			if i < len(m) {
+				masked[i] = maskValue(v, m[i], replacement)
+			} else {
+				masked[i] = v
+			}
+		}
+		return masked
+	}
+	return value
+}
+
+// writeDriftReport writes the report to the drift report Secret of the object,
+// controlled by the object, and references it from the status. An existing
+// Secret of the same name which is not controlled by the object is left
+// untouched.
+func (r *TerraformReconciler) writeDriftReport(ctx context.Context, terraform *infrav1.Terraform, report *drift.Report) error {
+	data, err := report.Encode()
+	if err != nil {
+		return fmt.Errorf("unable to encode the drift report: %w", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      drift.SecretName(terraform.Name, terraform.WorkspaceName()),
+			Namespace: terraform.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if secret.ResourceVersion != "" && !metav1.IsControlledBy(secret, terraform) {
+			return fmt.Errorf("secret %s already exists and is not managed by this object", secret.Name)
+		}
+
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[drift.TFDriftReportNameLabel] = plan.SafeLabelValue(terraform.Name)
+		secret.Data = map[string][]byte{drift.ReportKey: []byte(data)}
+		return controllerutil.SetControllerReference(terraform, secret, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("unable to write the drift report: %w", err)
+	}
+
+	terraform.Status.DriftReport = &infrav1.DriftReportReference{
+		SecretName:       secret.Name,
+		Revision:         report.Revision,
+		DetectedAt:       &report.DetectedAt,
+		DriftedResources: len(report.Resources),
+	}
+	return nil
+}
+
+// deleteDriftReport deletes the drift report referenced from the status, if
+// any and if it is controlled by the object.
+func (r *TerraformReconciler) deleteDriftReport(ctx context.Context, terraform *infrav1.Terraform) error {
+	if terraform.Status.DriftReport == nil {
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Status.DriftReport.SecretName}
+	if err := r.Get(ctx, key, secret); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("unable to get the drift report: %w", err)
+	} else if err == nil && metav1.IsControlledBy(secret, terraform) {
+		if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete the drift report: %w", err)
+		}
+	}
+
+	terraform.Status.DriftReport = nil
+	return nil
+}
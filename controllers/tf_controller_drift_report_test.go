@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/flux-iac/tofu-controller/api/drift"
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	tfjson "github.com/hashicorp/terraform-json"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewDriftReport(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "aws_db_instance.main",
+				Mode:    tfjson.ManagedResourceMode,
+				Type:    "aws_db_instance",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionUpdate},
+					Before: map[string]interface{}{
+						"password": "old-secret",
+						"tags":     map[string]interface{}{"env": "dev"},
+						"config":   []interface{}{"a", "b"},
+						"arn":      "arn:1",
+					},
+					After: map[string]interface{}{
+						"password": "new-secret",
+						"tags":     map[string]interface{}{"env": "prod"},
+						"config":   []interface{}{"a"},
+					},
+					BeforeSensitive: map[string]interface{}{"password": true},
+					AfterSensitive:  map[string]interface{}{"password": true, "config": []interface{}{true}},
+					AfterUnknown:    map[string]interface{}{"arn": true},
+				},
+			},
+			{
+				Address: "aws_autoscaling_group.web",
+				Mode:    tfjson.ManagedResourceMode,
+				Type:    "aws_autoscaling_group",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionUpdate}},
+			},
+			{
+				Address: "aws_s3_bucket.logs",
+				Mode:    tfjson.ManagedResourceMode,
+				Type:    "aws_s3_bucket",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}, After: map[string]interface{}{"bucket": "logs"}},
+			},
+			{
+				Address: "data.aws_caller_identity.current",
+				Mode:    tfjson.DataResourceMode,
+				Type:    "aws_caller_identity",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionRead}},
+			},
+		},
+		OutputChanges: map[string]*tfjson.Change{
+			"url":  {Actions: tfjson.Actions{tfjson.ActionUpdate}},
+			"name": {Actions: tfjson.Actions{tfjson.ActionNoop}},
+		},
+	}
+
+	report := newDriftReport(plan, "main@sha1:1234", []driftedResource{
+		{address: "aws_db_instance.main", action: infrav1.DriftActionRequireApproval},
+		{address: "aws_autoscaling_group.web", action: infrav1.DriftActionIgnore},
+		{address: "aws_s3_bucket.logs", action: infrav1.DriftActionAutoRemediate},
+	})
+
+	g.Expect(report.Revision).To(Equal("main@sha1:1234"))
+	g.Expect(report.Outputs).To(Equal([]string{"url"}))
+	g.Expect(report.Resources).To(Equal([]drift.Resource{
+		{
+			Address:     "aws_db_instance.main",
+			Type:        "aws_db_instance",
+			Actions:     []string{"update"},
+			DriftAction: "require-approval",
+			Attributes: []drift.Attribute{
+				{Path: "arn", Before: "arn:1", After: drift.UnknownValue},
+				{Path: "config", Before: []interface{}{"a", "b"}, After: []interface{}{drift.SensitiveValue}, Sensitive: true},
+				{Path: "password", Before: drift.SensitiveValue, After: drift.SensitiveValue, Sensitive: true},
+				{Path: "tags.env", Before: "dev", After: "prod"},
+			},
+		},
+		{
+			Address:     "aws_s3_bucket.logs",
+			Type:        "aws_s3_bucket",
+			Actions:     []string{"create"},
+			DriftAction: "auto-remediate",
+		},
+	}))
+}
+
+func TestWriteAndDeleteDriftReport(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello-world", Namespace: "flux-system", UID: "uid"},
+	}
+
+	r := &TerraformReconciler{Scheme: scheme}
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(terraform).Build()
+
+	report := &drift.Report{Revision: "main@sha1:1234", Resources: []drift.Resource{{Address: "aws_s3_bucket.logs"}}}
+	g.Expect(r.writeDriftReport(t.Context(), terraform, report)).To(Succeed())
+	g.Expect(terraform.Status.DriftReport.SecretName).To(Equal("tfdrift-default-hello-world"))
+	g.Expect(terraform.Status.DriftReport.DriftedResources).To(Equal(1))
+
+	// the report is updated by the next detection
+	report.Resources = append(report.Resources, drift.Resource{Address: "aws_instance.web"})
+	g.Expect(r.writeDriftReport(t.Context(), terraform, report)).To(Succeed())
+
+	secret := &corev1.Secret{}
+	g.Expect(r.Get(t.Context(), client.ObjectKey{Namespace: "flux-system", Name: "tfdrift-default-hello-world"}, secret)).To(Succeed())
+	g.Expect(metav1.IsControlledBy(secret, terraform)).To(BeTrue())
+	written, err := drift.NewFromSecret(secret)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(written.Resources).To(HaveLen(2))
+
+	g.Expect(r.deleteDriftReport(t.Context(), terraform)).To(Succeed())
+	g.Expect(terraform.Status.DriftReport).To(BeNil())
+	g.Expect(r.Get(t.Context(), client.ObjectKeyFromObject(secret), secret)).ToNot(Succeed())
+}
+
+func TestWriteDriftReportLeavesOtherSecretsUntouched(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello-world", Namespace: "flux-system", UID: "uid"},
+	}
+	other := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tfdrift-default-hello-world", Namespace: "flux-system"},
+		Data:       map[string][]byte{"password": []byte("s3cr3t")},
+	}
+
+	r := &TerraformReconciler{Scheme: scheme}
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(terraform, other).Build()
+
+	report := &drift.Report{Revision: "main@sha1:1234", Resources: []drift.Resource{{Address: "aws_s3_bucket.logs"}}}
+	g.Expect(r.writeDriftReport(t.Context(), terraform, report)).To(MatchError(ContainSubstring("is not managed by this object")))
+	g.Expect(terraform.Status.DriftReport).To(BeNil())
+
+	// a stale reference does not delete it either
+	terraform.Status.DriftReport = &infrav1.DriftReportReference{SecretName: other.Name}
+	g.Expect(r.deleteDriftReport(t.Context(), terraform)).To(Succeed())
+	g.Expect(terraform.Status.DriftReport).To(BeNil())
+
+	secret := &corev1.Secret{}
+	g.Expect(r.Get(t.Context(), client.ObjectKeyFromObject(other), secret)).To(Succeed())
+	g.Expect(secret.Data).To(Equal(other.Data))
+	g.Expect(secret.OwnerReferences).To(BeEmpty())
+}
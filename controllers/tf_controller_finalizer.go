@@ -9,11 +9,14 @@ import (
 
 	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
 	"github.com/flux-iac/tofu-controller/runner"
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/runtime/conditions"
 	"github.com/fluxcd/pkg/runtime/logger"
 	"github.com/fluxcd/pkg/runtime/patch"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,6 +46,24 @@ func (r *TerraformReconciler) finalize(ctx context.Context, patchHelper *patch.S
 			}
 		}
 
+		// The resources are only known to the state in the previous backend
+		// until the migration is approved: destroying them against the new,
+		// empty backend would orphan them.
+		if migrationID, ok := r.pendingStateMigration(terraform); ok && !r.shouldMigrateState(terraform, migrationID) {
+			msg := fmt.Sprintf("Deletion in progress, but blocked. Backend configuration changed: set stateMigration.approve: \"%s\" to migrate the state before destroying the resources.", migrationID)
+			if terraform.Status.StateMigration.Pending != migrationID || !conditions.HasAnyReason(terraform, meta.ReadyCondition, infrav1.StateMigrationPendingReason) {
+				r.Eventf(terraform, corev1.EventTypeWarning, infrav1.StateMigrationPendingReason, "%s", msg)
+			}
+
+			terraform = infrav1.TerraformStateMigrationPending(terraform, "", migrationID, msg)
+			if err := patchHelper.Patch(ctx, terraform, r.patchOptions...); err != nil {
+				log.Error(err, "unable to update status for pending state migration")
+				return terraform, controllerruntime.Result{Requeue: true}, err
+			}
+
+			return terraform, controllerruntime.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+		}
+
 		// TODO There's a case of sourceObj got deleted before finalize is called.
 		revision := sourceObj.GetArtifact().Revision
 		traceLog.Info("Setup the terraform instance")
@@ -106,6 +127,9 @@ func (r *TerraformReconciler) finalize(ctx context.Context, patchHelper *patch.S
 
 	}
 
+	traceLog.Info("Delete the outputs written to remote clusters")
+	r.deleteRemoteOutputs(ctx, terraform, runnerClient)
+
 	traceLog.Info("Check if we are writing output to secrets")
 	outputSecretName := ""
 	hasSpecifiedOutputSecret := terraform.Spec.WriteOutputsToSecret != nil && terraform.Spec.WriteOutputsToSecret.Name != ""
@@ -153,7 +177,7 @@ func (r *TerraformReconciler) finalize(ctx context.Context, patchHelper *patch.S
 
 	// Remove the dependant finalizer from every dependency
 	dependantFinalizer := infrav1.TFDependencyOfPrefix + terraform.GetName()
-	for _, d := range terraform.Spec.DependsOn {
+	for _, d := range terraform.GetDependsOn() {
 		if d.Namespace == "" {
 			d.Namespace = terraform.GetNamespace()
 		}
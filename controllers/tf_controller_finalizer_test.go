@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/flux-iac/tofu-controller/runner"
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/runtime/conditions"
+	"github.com/fluxcd/pkg/runtime/patch"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// mockRunnerClientForFinalize panics on any RPC: the deletion must not reach
+// the runner.
+type mockRunnerClientForFinalize struct {
+	runner.RunnerClient
+}
+
+func TestFinalizeWaitsForTheStateMigration(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "helloworld",
+			Namespace:         "flux-system",
+			Finalizers:        []string{infrav1.TerraformFinalizer},
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+		Spec: infrav1.TerraformSpec{
+			DestroyResourcesOnDeletion: true,
+			BackendConfig:              &infrav1.BackendConfigSpec{CustomConfiguration: `backend "s3" {}`},
+		},
+	}
+
+	r := &TerraformReconciler{EventRecorder: record.NewFakeRecorder(10), Scheme: scheme}
+
+	// the state was initialized in the Kubernetes backend
+	previous := &infrav1.Terraform{Spec: infrav1.TerraformSpec{BackendConfig: &infrav1.BackendConfigSpec{SecretSuffix: "helloworld", InClusterConfig: true}}}
+	backendConfig := r.stateBackendConfig(previous)
+	infrav1.TerraformBackendInitialized(terraform, stateBackendConfigHash(backendConfig, nil), backendConfig)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(terraform).WithStatusSubresource(terraform).Build()
+	r.Client = c
+	g.Expect(c.Get(t.Context(), client.ObjectKeyFromObject(terraform), terraform)).To(Succeed())
+
+	sourceObj := &inlineSource{artifact: &meta.Artifact{Revision: "inline@sha256:1234"}}
+	migrationID, _ := r.pendingStateMigration(terraform)
+
+	finalized, result, err := r.finalize(t.Context(), patch.NewSerialPatcher(terraform, c), terraform, &mockRunnerClientForFinalize{}, sourceObj, "loop")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+	g.Expect(conditions.GetReason(finalized, meta.ReadyCondition)).To(Equal(infrav1.StateMigrationPendingReason))
+	g.Expect(finalized.Status.StateMigration.Pending).To(Equal(migrationID))
+	g.Expect(r.EventRecorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(migrationID)))
+
+	// the finalizer is kept until the migration is approved
+	stored := &infrav1.Terraform{}
+	g.Expect(c.Get(t.Context(), client.ObjectKeyFromObject(terraform), stored)).To(Succeed())
+	g.Expect(stored.Finalizers).To(ContainElement(infrav1.TerraformFinalizer))
+	g.Expect(conditions.GetReason(stored, meta.ReadyCondition)).To(Equal(infrav1.StateMigrationPendingReason))
+}
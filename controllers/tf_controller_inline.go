@@ -0,0 +1,181 @@
+package controllers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"time"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// inlineSource is the source of a Terraform object holding its module files
+// with .spec.inline. Its artifact is packaged by the controller, instead of
+// being downloaded from the source-controller.
+type inlineSource struct {
+	metav1.TypeMeta
+
+	artifact *meta.Artifact
+	tarGz    []byte
+}
+
+var _ sourcev1.Source = &inlineSource{}
+
+func (s *inlineSource) GetRequeueAfter() time.Duration {
+	return 0
+}
+
+func (s *inlineSource) GetArtifact() *meta.Artifact {
+	return s.artifact
+}
+
+func (s *inlineSource) DeepCopyObject() runtime.Object {
+	return &inlineSource{
+		TypeMeta: s.TypeMeta,
+		artifact: s.artifact.DeepCopy(),
+		tarGz:    bytes.Clone(s.tarGz),
+	}
+}
+
+// getInlineSource reads the files of .spec.inline, and packages them the
+// way the source-controller packages artifacts.
+func (r *TerraformReconciler) getInlineSource(ctx context.Context, terraform *infrav1.Terraform) (sourcev1.Source, error) {
+	files := map[string]string{}
+
+	if ref := terraform.Spec.Inline.ConfigMapRef; ref != nil {
+		var configMap corev1.ConfigMap
+		key := types.NamespacedName{Namespace: terraform.Namespace, Name: ref.Name}
+		if err := r.Get(ctx, key, &configMap); err != nil {
+			return nil, err
+		}
+		for name, content := range configMap.Data {
+			files[name] = content
+		}
+	}
+
+	for name, content := range terraform.Spec.Inline.Files {
+		files[name] = content
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files found in the inline source")
+	}
+
+	tarGz, err := packInlineFiles(files)
+	if err != nil {
+		return nil, fmt.Errorf("unable to package the inline source: %w", err)
+	}
+
+	size := int64(len(tarGz))
+	return &inlineSource{
+		artifact: &meta.Artifact{
+			Path:           fmt.Sprintf("inline/%s/%s.tar.gz", terraform.Namespace, terraform.Name),
+			Revision:       inlineRevision(files),
+			Digest:         fmt.Sprintf("sha256:%x", sha256.Sum256(tarGz)),
+			LastUpdateTime: metav1.Now(),
+			Size:           &size,
+		},
+		tarGz: tarGz,
+	}, nil
+}
+
+// inlineRevision identifies the content of the files, so the revision only
+// changes with the files, like the revision of a Git commit.
+func inlineRevision(files map[string]string) string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\x00%d\x00%s", name, len(files[name]), files[name])
+	}
+	return fmt.Sprintf("inline@sha256:%x", h.Sum(nil))
+}
+
+// packInlineFiles writes the files at the root of a tarball. The file names
+// must be valid ConfigMap keys, so they cannot escape the working directory.
+func packInlineFiles(files map[string]string) ([]byte, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if errs := validation.IsConfigMapKey(name); len(errs) > 0 || name == "." || name == ".." {
+			return nil, fmt.Errorf("invalid file name %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, name := range names {
+		content := []byte(files[name])
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (r *TerraformReconciler) IndexInlineConfigMap(o client.Object) []string {
+	terraform, ok := o.(*infrav1.Terraform)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Terraform, got %T", o))
+	}
+
+	if terraform.Spec.Inline == nil || terraform.Spec.Inline.ConfigMapRef == nil {
+		return nil
+	}
+	return []string{types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Spec.Inline.ConfigMapRef.Name}.String()}
+}
+
+// requestsForInlineConfigMapChange maps a ConfigMap to the Terraform objects
+// holding their module files in it.
+func (r *TerraformReconciler) requestsForInlineConfigMapChange(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := ctrl.LoggerFrom(ctx)
+
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list, client.MatchingFields{
+		infrav1.InlineConfigMapIndexKey: client.ObjectKeyFromObject(obj).String(),
+	}); err != nil {
+		log.Error(err, "failed to list objects for inline source change")
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, 0, len(list.Items))
+	for _, t := range list.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&t)})
+	}
+	return reqs
+}
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fluxcd/pkg/tar"
+	. "github.com/onsi/gomega"
+)
+
+func TestPackInlineFiles(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	files := map[string]string{
+		"main.tf":          `resource "null_resource" "glue" {}`,
+		"outputs.tf.json":  `{"output": {"id": {"value": "${null_resource.glue.id}"}}}`,
+		"terraform.tfvars": `region = "eu-west-1"`,
+	}
+
+	tarGz, err := packInlineFiles(files)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// the artifact is extracted by the runner as a source-controller artifact
+	dir := t.TempDir()
+	g.Expect(tar.Untar(bytes.NewReader(tarGz), dir)).To(Succeed())
+	for name, content := range files {
+		extracted, err := os.ReadFile(filepath.Join(dir, name))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(extracted)).To(Equal(content))
+	}
+
+	// the package and the revision only depend on the files
+	again, err := packInlineFiles(files)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(again).To(Equal(tarGz))
+
+	revision := inlineRevision(files)
+	g.Expect(revision).To(HavePrefix("inline@sha256:"))
+	files["main.tf"] = `resource "null_resource" "glue2" {}`
+	g.Expect(inlineRevision(files)).ToNot(Equal(revision))
+
+	_, err = packInlineFiles(map[string]string{"../main.tf": ""})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid file name")))
+}
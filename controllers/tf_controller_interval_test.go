@@ -295,6 +295,40 @@ func TestShouldReconcileWhenDependencyNotReady(t *testing.T) {
 	g.Expect(requeueAfter).To(Equal(time.Duration(0)))
 }
 
+func TestShouldReconcileWhenDependencyCycleDetected(t *testing.T) {
+	Spec("This spec covers reconciling when a dependency cycle was detected.")
+	It("should return true without delay, as the cycle may have been broken.")
+
+	g := NewWithT(t)
+	reconciler := &TerraformReconciler{}
+
+	tf := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation: 1,
+		},
+		Spec: infrav1.TerraformSpec{
+			Interval: metav1.Duration{Duration: 24 * time.Hour},
+		},
+		Status: infrav1.TerraformStatus{
+			LastPlanAt:         &metav1.Time{Time: time.Now()},
+			ObservedGeneration: 1,
+			Conditions: []metav1.Condition{
+				{
+					Message: "dependency cycle detected: flux-system/a -> flux-system/b -> flux-system/a",
+					Reason:  infrav1.DependencyCycleDetectedReason,
+					Type:    meta.ReadyCondition,
+					Status:  metav1.ConditionFalse,
+				},
+			},
+		},
+	}
+
+	shouldReconcile, reason, requeueAfter := reconciler.shouldReconcile(tf, nil)
+	g.Expect(shouldReconcile).To(BeTrue())
+	g.Expect(reason).To(Equal("previously blocked on not ready dependency"))
+	g.Expect(requeueAfter).To(Equal(time.Duration(0)))
+}
+
 func TestShouldReconcileWhenSourceRevisionChanges(t *testing.T) {
 	Spec("This spec covers reconciling when the source revision has changed.")
 	It("should return true without delay.")
@@ -451,3 +485,75 @@ func TestShouldReconcileWaitsForRetryIntervalOnFailure(t *testing.T) {
 	g.Expect(requeueAfter).To(BeNumerically(">", 19*time.Minute))
 	g.Expect(requeueAfter).To(BeNumerically("<=", 20*time.Minute))
 }
+
+func TestShouldReconcileFollowsSchedule(t *testing.T) {
+	Spec("This spec covers the periodic reconciliations at the times of spec.schedule.")
+	It("should skip reconciliation until the next scheduled time, whatever the interval.")
+
+	g := NewWithT(t)
+	reconciler := &TerraformReconciler{}
+
+	// reconciled a minute ago, the schedule is due in about an hour
+	lastReconcile := time.Now().Add(-time.Minute)
+	next := lastReconcile.In(time.UTC).Truncate(time.Hour).Add(2 * time.Hour)
+	tf := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation: 1,
+		},
+		Spec: infrav1.TerraformSpec{
+			Interval: metav1.Duration{Duration: time.Minute},
+			Schedule: next.Format("4 15 * * *"),
+		},
+		Status: infrav1.TerraformStatus{
+			LastPlanAt:                &metav1.Time{Time: lastReconcile},
+			LastSuccessfulReconcileAt: &metav1.Time{Time: lastReconcile},
+			ObservedGeneration:        1,
+		},
+	}
+
+	shouldReconcile, reason, requeueAfter := reconciler.shouldReconcile(tf, nil)
+	g.Expect(shouldReconcile).To(BeFalse())
+	g.Expect(reason).To(Equal("next scheduled reconciliation is not due yet"))
+	g.Expect(requeueAfter).To(BeNumerically("~", time.Until(next), time.Second))
+
+	// the scheduled time has passed
+	tf.Status.LastSuccessfulReconcileAt = &metav1.Time{Time: next.Add(-25 * time.Hour)}
+	shouldReconcile, _, _ = reconciler.shouldReconcile(tf, nil)
+	g.Expect(shouldReconcile).To(BeTrue())
+}
+
+func TestParseSchedule(t *testing.T) {
+	Spec("This spec covers the parsing of spec.schedule and spec.timeZone.")
+	It("should compute the next times in the time zone, and reject invalid values.")
+
+	g := NewWithT(t)
+
+	tf := &infrav1.Terraform{Spec: infrav1.TerraformSpec{Schedule: "0 6 * * 1-5", TimeZone: "Europe/Paris"}}
+	schedule, err := parseSchedule(tf)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Friday 2024-03-01 at 07:00 in Paris, the next weekday at 06:00 is Monday
+	paris, err := time.LoadLocation("Europe/Paris")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(schedule.Next(time.Date(2024, 3, 1, 7, 0, 0, 0, paris))).To(BeTemporally("==", time.Date(2024, 3, 4, 6, 0, 0, 0, paris)))
+
+	tf.Spec = infrav1.TerraformSpec{Schedule: "@daily"}
+	schedule, err = parseSchedule(tf)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(schedule.Next(time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC))).To(BeTemporally("==", time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)))
+
+	tf.Spec = infrav1.TerraformSpec{}
+	g.Expect(parseSchedule(tf)).To(BeNil())
+
+	tf.Spec = infrav1.TerraformSpec{Schedule: "0 6 * *"}
+	_, err = parseSchedule(tf)
+	g.Expect(err).To(MatchError(ContainSubstring(`invalid schedule "0 6 * *"`)))
+
+	tf.Spec = infrav1.TerraformSpec{Schedule: "CRON_TZ=Europe/Paris 0 6 * * *"}
+	_, err = parseSchedule(tf)
+	g.Expect(err).To(MatchError(ContainSubstring("set the time zone with .spec.timeZone")))
+
+	tf.Spec = infrav1.TerraformSpec{Schedule: "0 6 * * *", TimeZone: "Mars/Olympus"}
+	_, err = parseSchedule(tf)
+	g.Expect(err).To(MatchError(ContainSubstring(`invalid time zone "Mars/Olympus"`)))
+}
@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/runtime/acl"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// objectDependencyPollInterval is the interval of the status poller. It only
+// matters if the first poll is not over, as the poll stops once the status of
+// every dependency is known.
+const objectDependencyPollInterval = 2 * time.Second
+
+// objectDependency is an entry of .spec.dependsOnObjects resolved to the
+// identifier of its object.
+type objectDependency struct {
+	id        object.ObjMetadata
+	ref       string
+	readyExpr cel.Program
+}
+
+// compileReadyExpr compiles a readyExpr, which must return a boolean. The
+// Terraform object is declared as `self` and the dependency as `dep`.
+func compileReadyExpr(expr string) (cel.Program, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("self", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("dep", cel.MapType(cel.StringType, cel.DynType)),
+		cel.CrossTypeNumericComparisons(true),
+		cel.OptionalTypes(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ast, issues := env.Compile(expr)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("the expression must return a bool, not %s", ast.OutputType())
+	}
+	// as the Kubernetes API server does, check the context every 100 iterations
+	return env.Program(ast, cel.InterruptCheckFrequency(100))
+}
+
+// checkObjectDependencies computes with kstatus the status of the objects of
+// .spec.dependsOnObjects, and returns an error for the first one that is not
+// current, i.e. not reconciled and ready. The readyExpr of a dependency
+// replaces its kstatus check, once the object is found. It is not allowed on
+// Secrets and ConfigMaps.
+func (r *TerraformReconciler) checkObjectDependencies(ctx context.Context, terraform *infrav1.Terraform) error {
+	if len(terraform.Spec.DependsOnObjects) == 0 {
+		return nil
+	}
+
+	deps := make([]objectDependency, 0, len(terraform.Spec.DependsOnObjects))
+	identifiers := make(object.ObjMetadataSet, 0, len(terraform.Spec.DependsOnObjects))
+	for _, d := range terraform.Spec.DependsOnObjects {
+		gv, err := schema.ParseGroupVersion(d.APIVersion)
+		if err != nil {
+			return fmt.Errorf("invalid apiVersion of dependency '%s': %w", d.String(), err)
+		}
+		id := object.ObjMetadata{
+			GroupKind: schema.GroupKind{Group: gv.Group, Kind: d.Kind},
+			Name:      d.Name,
+		}
+
+		// the result of a readyExpr, or its evaluation error, would disclose
+		// data the controller can read but the object's author may not
+		if d.ReadyExpr != "" && id.GroupKind.Group == "" && (id.GroupKind.Kind == "Secret" || id.GroupKind.Kind == "ConfigMap") {
+			return acl.AccessDeniedError(
+				fmt.Sprintf("cannot evaluate the readyExpr of %s, Secrets and ConfigMaps cannot be read by a readyExpr", d.String()),
+			)
+		}
+
+		mapping, err := r.RESTMapper().RESTMapping(id.GroupKind, gv.Version)
+		if err != nil {
+			return fmt.Errorf("unable to find the kind of dependency '%s': %w", d.String(), err)
+		}
+		if mapping.Scope.Name() == apimeta.RESTScopeNameNamespace {
+			id.Namespace = d.Namespace
+			if id.Namespace == "" {
+				id.Namespace = terraform.GetNamespace()
+			}
+			if r.NoCrossNamespaceRefs && id.Namespace != terraform.GetNamespace() {
+				return acl.AccessDeniedError(
+					fmt.Sprintf("cannot access %s, cross-namespace references have been disabled", d.String()),
+				)
+			}
+		}
+
+		dep := objectDependency{id: id, ref: d.String()}
+		if d.ReadyExpr != "" {
+			if dep.readyExpr, err = compileReadyExpr(d.ReadyExpr); err != nil {
+				return fmt.Errorf("invalid readyExpr of dependency '%s': %w", d.String(), err)
+			}
+		}
+		deps = append(deps, dep)
+
+		if !identifiers.Contains(id) {
+			identifiers = append(identifiers, id)
+		}
+	}
+
+	// The first poll sends an update for every object, the poll is stopped then.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	statuses := make(map[object.ObjMetadata]*event.ResourceStatus, len(identifiers))
+	for e := range r.StatusPoller.Poll(ctx, identifiers, polling.PollOptions{PollInterval: objectDependencyPollInterval}) {
+		if e.Type == event.ErrorEvent {
+			return fmt.Errorf("unable to compute the status of the dependencies: %w", e.Error)
+		}
+		if e.Type == event.ResourceUpdateEvent {
+			statuses[e.Resource.Identifier] = e.Resource
+		}
+		if len(statuses) == len(identifiers) {
+			break
+		}
+	}
+	if len(statuses) < len(identifiers) {
+		return fmt.Errorf("unable to compute the status of the dependencies: %w", ctx.Err())
+	}
+
+	var self map[string]any
+	for _, dep := range deps {
+		s := statuses[dep.id]
+		if s.Status == status.NotFoundStatus {
+			return fmt.Errorf("dependency '%s' not found", dep.ref)
+		}
+
+		if dep.readyExpr != nil {
+			if s.Resource == nil {
+				return fmt.Errorf("unable to read dependency '%s', its status is %s", dep.ref, s.Status)
+			}
+			if self == nil {
+				var err error
+				if self, err = runtime.DefaultUnstructuredConverter.ToUnstructured(terraform); err != nil {
+					return err
+				}
+			}
+			ready, _, err := dep.readyExpr.ContextEval(ctx, map[string]any{"self": self, "dep": s.Resource.Object})
+			if err != nil {
+				return fmt.Errorf("unable to evaluate the readyExpr of dependency '%s': %w", dep.ref, err)
+			}
+			if ready != types.True {
+				return fmt.Errorf("dependency '%s' is not ready according to its readyExpr", dep.ref)
+			}
+			continue
+		}
+
+		switch s.Status {
+		case status.CurrentStatus:
+			continue
+		default:
+			msg := fmt.Sprintf("dependency '%s' is not ready, its status is %s", dep.ref, s.Status)
+			if s.Error != nil {
+				msg += ": " + s.Error.Error()
+			} else if s.Message != "" {
+				msg += ": " + s.Message
+			}
+			return fmt.Errorf("%s", msg)
+		}
+	}
+
+	return nil
+}
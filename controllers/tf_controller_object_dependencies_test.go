@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/runtime/acl"
+	. "github.com/onsi/gomega"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/clusterreader"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/engine"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckObjectDependencies(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	kustomizationGVK := schema.GroupVersionKind{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"}
+	providerGVK := schema.GroupVersionKind{Group: "pkg.crossplane.io", Version: "v1", Kind: "Provider"}
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	mapper := apimeta.NewDefaultRESTMapper([]schema.GroupVersion{kustomizationGVK.GroupVersion(), providerGVK.GroupVersion()})
+	mapper.Add(kustomizationGVK, apimeta.RESTScopeNamespace)
+	mapper.Add(providerGVK, apimeta.RESTScopeRoot)
+
+	object := func(gvk schema.GroupVersionKind, namespace, name string, ready metav1.ConditionStatus) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		u.SetGeneration(1)
+		g.Expect(unstructured.SetNestedField(u.Object, int64(1), "status", "observedGeneration")).To(Succeed())
+		g.Expect(unstructured.SetNestedSlice(u.Object, []any{
+			map[string]any{"type": "Ready", "status": string(ready), "reason": "Test", "message": "Reconciliation in progress"},
+		}, "status", "conditions")).To(Succeed())
+		return u
+	}
+
+	operator := object(kustomizationGVK, "flux-system", "cloud-operator", metav1.ConditionTrue)
+	provider := object(providerGVK, "", "provider-aws", metav1.ConditionTrue)
+	crds := object(kustomizationGVK, "flux-system", "crds", metav1.ConditionFalse)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(operator, provider, crds).Build()
+	// the fake client does not support the empty field selector of the caching cluster reader
+	r := &TerraformReconciler{StatusPoller: polling.NewStatusPoller(c, mapper, polling.Options{
+		ClusterReaderFactory: engine.ClusterReaderFactoryFunc(clusterreader.NewDirectClusterReader),
+	})}
+	r.Client = c
+
+	terraform := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "network", Namespace: "flux-system"}}
+	terraform.Spec.DependsOnObjects = []infrav1.ObjectDependency{
+		{APIVersion: "kustomize.toolkit.fluxcd.io/v1", Kind: "Kustomization", Name: "cloud-operator"},
+		{APIVersion: "pkg.crossplane.io/v1", Kind: "Provider", Name: "provider-aws", Namespace: "ignored"},
+	}
+	g.Expect(r.checkObjectDependencies(t.Context(), terraform)).To(Succeed())
+
+	terraform.Spec.DependsOnObjects = append(terraform.Spec.DependsOnObjects,
+		infrav1.ObjectDependency{APIVersion: "kustomize.toolkit.fluxcd.io/v1", Kind: "Kustomization", Name: "crds"})
+	g.Expect(r.checkObjectDependencies(t.Context(), terraform)).To(MatchError(
+		"dependency 'Kustomization/crds' is not ready, its status is InProgress: Reconciliation in progress"))
+
+	terraform.Spec.DependsOnObjects[2].Name = "missing"
+	g.Expect(r.checkObjectDependencies(t.Context(), terraform)).To(MatchError("dependency 'Kustomization/missing' not found"))
+
+	terraform.Spec.DependsOnObjects[2] = infrav1.ObjectDependency{APIVersion: "example.com/v1", Kind: "Unknown", Name: "unknown"}
+	g.Expect(r.checkObjectDependencies(t.Context(), terraform)).To(MatchError(ContainSubstring("unable to find the kind of dependency 'Unknown/unknown'")))
+
+	// readyExpr replaces kstatus, with the dependency as dep and the Terraform object as self
+	terraform.Spec.DependsOnObjects[2] = infrav1.ObjectDependency{
+		APIVersion: "kustomize.toolkit.fluxcd.io/v1", Kind: "Kustomization", Name: "crds",
+		ReadyExpr: "dep.status.conditions.exists(c, c.type == 'Ready' && c.reason == 'Test') && dep.metadata.namespace == self.metadata.namespace",
+	}
+	g.Expect(r.checkObjectDependencies(t.Context(), terraform)).To(Succeed())
+
+	terraform.Spec.DependsOnObjects[2].ReadyExpr = "dep.status.observedGeneration > 1"
+	g.Expect(r.checkObjectDependencies(t.Context(), terraform)).To(MatchError(
+		"dependency 'Kustomization/crds' is not ready according to its readyExpr"))
+
+	terraform.Spec.DependsOnObjects[2].ReadyExpr = "dep.status.missing == true"
+	g.Expect(r.checkObjectDependencies(t.Context(), terraform)).To(MatchError(
+		ContainSubstring("unable to evaluate the readyExpr of dependency 'Kustomization/crds'")))
+
+	terraform.Spec.DependsOnObjects[2].ReadyExpr = "dep.metadata.name"
+	g.Expect(r.checkObjectDependencies(t.Context(), terraform)).To(MatchError(
+		ContainSubstring("invalid readyExpr of dependency 'Kustomization/crds'")))
+
+	terraform.Spec.DependsOnObjects[2].Name = "missing"
+	terraform.Spec.DependsOnObjects[2].ReadyExpr = "true"
+	g.Expect(r.checkObjectDependencies(t.Context(), terraform)).To(MatchError("dependency 'Kustomization/missing' not found"))
+
+	// the data of Secrets and ConfigMaps is not exposed to a readyExpr
+	for _, kind := range []string{"Secret", "ConfigMap"} {
+		terraform.Spec.DependsOnObjects[2] = infrav1.ObjectDependency{APIVersion: "v1", Kind: kind, Name: "credentials", ReadyExpr: "dep.data.password == 'hunter2'"}
+		err := r.checkObjectDependencies(t.Context(), terraform)
+		g.Expect(acl.IsAccessDenied(err)).To(BeTrue())
+		g.Expect(err).To(MatchError(ContainSubstring("cannot evaluate the readyExpr of " + kind + "/credentials")))
+	}
+
+	r.NoCrossNamespaceRefs = true
+	terraform.Spec.DependsOnObjects[2] = infrav1.ObjectDependency{APIVersion: "kustomize.toolkit.fluxcd.io/v1", Kind: "Kustomization", Name: "apps", Namespace: "apps"}
+	g.Expect(acl.IsAccessDenied(r.checkObjectDependencies(t.Context(), terraform))).To(BeTrue())
+}
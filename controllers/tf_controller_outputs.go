@@ -1,11 +1,16 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"text/template"
 
+	"github.com/Masterminds/sprig/v3"
 	"github.com/flux-iac/tofu-controller/api/typeinfo"
 	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
 	"github.com/flux-iac/tofu-controller/runner"
@@ -17,6 +22,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func convertOutputs(outputs map[string]*runner.OutputMeta) map[string]tfexec.OutputMeta {
@@ -56,14 +62,41 @@ func (r *TerraformReconciler) outputsMayBeDrifted(ctx context.Context, terraform
 			return true, nil
 		}
 
-		return false, err
+		if err != nil {
+			return false, err
+		}
+	}
+
+	for _, destination := range terraform.Spec.WriteOutputsTo {
+		// destinations in remote clusters are only checked by the runner
+		if destination.KubeConfig != nil {
+			continue
+		}
+
+		key := types.NamespacedName{Namespace: terraform.Namespace, Name: destination.Name}
+
+		var obj client.Object = &corev1.Secret{}
+		if destination.Kind == "ConfigMap" {
+			obj = &corev1.ConfigMap{}
+		}
+
+		if err := r.Get(ctx, key, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+	}
+
+	if len(terraform.Spec.RolloutOnOutputsChange) > 0 {
+		return r.consumersOutOfDate(ctx, terraform)
 	}
 
 	return false, nil
 }
 
 func (r *TerraformReconciler) shouldWriteOutputs(terraform *infrav1.Terraform, outputs map[string]tfexec.OutputMeta) bool {
-	if terraform.Spec.WriteOutputsToSecret != nil && len(outputs) > 0 {
+	if (terraform.Spec.WriteOutputsToSecret != nil || len(terraform.Spec.WriteOutputsTo) > 0) && len(outputs) > 0 {
 		return true
 	}
 
@@ -84,18 +117,37 @@ func (r *TerraformReconciler) processOutputs(ctx context.Context, patchHelper *p
 		return terraform, err
 	}
 
+	terraform = r.recordOutputs(terraform, outputs, revision)
+
 	if r.shouldWriteOutputs(terraform, outputs) {
-		terraform, err = r.writeOutput(ctx, terraform, runnerClient, outputs, revision)
-		if err != nil {
-			return terraform, err
+		if terraform.Spec.WriteOutputsToSecret != nil {
+			terraform, err = r.writeOutput(ctx, terraform, runnerClient, outputs, revision)
+			if err != nil {
+				return terraform, err
+			}
 		}
 
-		if err := patchHelper.Patch(ctx, terraform, r.patchOptions...); err != nil {
-			log.Error(err, "unable to update status after writing outputs")
+		if len(terraform.Spec.WriteOutputsTo) > 0 {
+			terraform, err = r.writeOutputsTo(ctx, terraform, runnerClient, outputs, revision)
+			if err != nil {
+				return terraform, err
+			}
+		}
+	}
+
+	// consumers are rolled out once the outputs they read are written
+	if len(terraform.Spec.RolloutOnOutputsChange) > 0 {
+		terraform, err = r.rolloutConsumers(ctx, terraform, revision)
+		if err != nil {
 			return terraform, err
 		}
 	}
 
+	if err := patchHelper.Patch(ctx, terraform, r.patchOptions...); err != nil {
+		log.Error(err, "unable to update status after writing outputs")
+		return terraform, err
+	}
+
 	return terraform, nil
 }
 
@@ -132,7 +184,6 @@ func (r *TerraformReconciler) writeOutput(ctx context.Context, terraform *infrav
 	log := ctrl.LoggerFrom(ctx)
 
 	wots := terraform.Spec.WriteOutputsToSecret
-	data := map[string][]byte{}
 
 	var filteredOutputs map[string]tfexec.OutputMeta
 	if len(wots.Outputs) == 0 {
@@ -150,22 +201,9 @@ func (r *TerraformReconciler) writeOutput(ctx context.Context, terraform *infrav
 		}
 	}
 
-	for outputOrAlias, outputMeta := range filteredOutputs {
-		ct, err := ctyjson.UnmarshalType(outputMeta.Type)
-		if err != nil {
-			return terraform, err
-		}
-
-		if ct == cty.String {
-			cv, err := ctyjson.Unmarshal(outputMeta.Value, ct)
-			if err != nil {
-				return terraform, err
-			}
-			data[outputOrAlias] = []byte(cv.AsString())
-		} else {
-			data[outputOrAlias] = outputMeta.Value
-			data[outputOrAlias+typeinfo.Suffix] = outputMeta.Type
-		}
+	data, err := outputsData(filteredOutputs)
+	if err != nil {
+		return terraform, err
 	}
 
 	if len(data) == 0 || terraform.Spec.Destroy {
@@ -203,6 +241,228 @@ func (r *TerraformReconciler) writeOutput(ctx context.Context, terraform *infrav
 	return infrav1.TerraformOutputsWritten(terraform, revision, "Outputs written"), nil
 }
 
+// writeOutputsTo writes the outputs to the destinations of .spec.writeOutputsTo.
+func (r *TerraformReconciler) writeOutputsTo(ctx context.Context, terraform *infrav1.Terraform, runnerClient runner.RunnerClient, outputs map[string]tfexec.OutputMeta, revision string) (*infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if terraform.Spec.Destroy {
+		return infrav1.TerraformOutputsWritten(terraform, revision, "No Outputs written"), nil
+	}
+
+	for _, destination := range terraform.Spec.WriteOutputsTo {
+		data, err := destinationData(destination, outputs)
+		if err != nil {
+			err = fmt.Errorf("unable to write outputs to %s/%s: %w", destination.Kind, destination.Name, err)
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.OutputsWritingFailedReason,
+				err.Error(),
+			), err
+		}
+
+		if len(data) == 0 {
+			continue
+		}
+
+		req := &runner.WriteOutputsRequest{
+			Namespace:       terraform.Namespace,
+			Name:            terraform.Name,
+			SecretName:      destination.Name,
+			Uuid:            string(terraform.UID),
+			Data:            data,
+			Labels:          destination.Labels,
+			Annotations:     destination.Annotations,
+			Kind:            destination.Kind,
+			SecretType:      string(destination.Type),
+			TargetNamespace: destination.Namespace,
+		}
+		if destination.KubeConfig != nil {
+			req.KubeConfigSecretName = destination.KubeConfig.SecretRef.Name
+			req.KubeConfigSecretKey = destination.KubeConfig.SecretRef.Key
+		}
+
+		writeOutputsReply, err := runnerClient.WriteOutputs(ctx, req)
+		if err != nil {
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.OutputsWritingFailedReason,
+				err.Error(),
+			), err
+		}
+		log.Info(fmt.Sprintf("write outputs to %s/%s: %s, changed: %v", destination.Kind, destination.Name, writeOutputsReply.Message, writeOutputsReply.Changed))
+
+		if writeOutputsReply.Changed {
+			keysWritten := []string{}
+			for k := range data {
+				keysWritten = append(keysWritten, k)
+			}
+			sort.Strings(keysWritten)
+			msg := fmt.Sprintf("Outputs written to %s/%s.\n%d key(s): %s", destination.Kind, destination.Name, len(keysWritten), strings.Join(keysWritten, ", "))
+			r.Eventf(terraform, corev1.EventTypeNormal, infrav1.OutputsWrittenReason, "%s", msg)
+		}
+	}
+
+	// the remote destinations removed from the spec are not garbage collected
+	remoteOutputs := specRemoteOutputs(terraform)
+	for _, ref := range terraform.Status.RemoteOutputs {
+		if slices.Contains(remoteOutputs, ref) {
+			continue
+		}
+		if err := r.deleteRemoteOutputsAt(ctx, terraform, runnerClient, ref); err != nil {
+			// kept to be deleted the next time the outputs are written
+			remoteOutputs = append(remoteOutputs, ref)
+		}
+	}
+	terraform.Status.RemoteOutputs = remoteOutputs
+
+	return infrav1.TerraformOutputsWritten(terraform, revision, "Outputs written"), nil
+}
+
+// specRemoteOutputs returns the destinations of .spec.writeOutputsTo written
+// to remote clusters.
+func specRemoteOutputs(terraform *infrav1.Terraform) []infrav1.RemoteOutputsReference {
+	var refs []infrav1.RemoteOutputsReference
+	for _, destination := range terraform.Spec.WriteOutputsTo {
+		if ref := destination.RemoteOutputsReference(); ref != nil {
+			refs = append(refs, *ref)
+		}
+	}
+	return refs
+}
+
+// destinationData selects the outputs by sensitivity and name for the destination,
+// and returns its data, either with a key per output or with the templated keys.
+func destinationData(destination infrav1.OutputDestination, outputs map[string]tfexec.OutputMeta) (map[string][]byte, error) {
+	if err := destination.Validate(); err != nil {
+		return nil, err
+	}
+
+	selectedOutputs := map[string]tfexec.OutputMeta{}
+	sensitivity := destination.GetSensitivity()
+	for name, outputMeta := range outputs {
+		if sensitivity == infrav1.OutputSensitivitySensitive && !outputMeta.Sensitive ||
+			sensitivity == infrav1.OutputSensitivityNonSensitive && outputMeta.Sensitive {
+			continue
+		}
+		selectedOutputs[name] = outputMeta
+	}
+
+	if len(destination.Outputs) > 0 {
+		var err error
+		selectedOutputs, err = filterOutputs(selectedOutputs, destination.Outputs)
+		if err != nil {
+			return nil, fmt.Errorf("%w (with %s sensitivity)", err, sensitivity)
+		}
+	}
+
+	if len(destination.Keys) == 0 {
+		return outputsData(selectedOutputs)
+	}
+
+	return renderOutputKeys(destination.Keys, selectedOutputs)
+}
+
+// outputsData returns a key per output. String outputs are written as is, and
+// other types as JSON along with their type.
+func outputsData(outputs map[string]tfexec.OutputMeta) (map[string][]byte, error) {
+	data := map[string][]byte{}
+	for outputOrAlias, outputMeta := range outputs {
+		ct, err := ctyjson.UnmarshalType(outputMeta.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		if ct == cty.String {
+			cv, err := ctyjson.Unmarshal(outputMeta.Value, ct)
+			if err != nil {
+				return nil, err
+			}
+			data[outputOrAlias] = []byte(cv.AsString())
+		} else {
+			data[outputOrAlias] = outputMeta.Value
+			data[outputOrAlias+typeinfo.Suffix] = outputMeta.Type
+		}
+	}
+
+	return data, nil
+}
+
+// renderOutputKeys renders the template of every key with the values of the
+// outputs. Only the hermetic sprig functions are available, so that a template
+// cannot read the environment of the controller.
+func renderOutputKeys(keys map[string]string, outputs map[string]tfexec.OutputMeta) (map[string][]byte, error) {
+	values := map[string]any{}
+	for name, outputMeta := range outputs {
+		decoder := json.NewDecoder(bytes.NewReader(outputMeta.Value))
+		decoder.UseNumber()
+
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("unable to decode output %s: %w", name, err)
+		}
+		values[name] = value
+	}
+
+	data := map[string][]byte{}
+	for key, text := range keys {
+		tmpl, err := template.New(key).
+			Funcs(sprig.HermeticTxtFuncMap()).
+			Option("missingkey=error").
+			Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template for key %s: %w", key, err)
+		}
+
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, values); err != nil {
+			return nil, fmt.Errorf("unable to render key %s: %w", key, err)
+		}
+		data[key] = b.Bytes()
+	}
+
+	return data, nil
+}
+
+// deleteRemoteOutputs deletes the destinations of .spec.writeOutputsTo written to
+// remote clusters, as they are not garbage collected with the Terraform object,
+// along with the ones recorded in the status. Failures are reported but do not
+// block the deletion, as the remote cluster may be gone already.
+func (r *TerraformReconciler) deleteRemoteOutputs(ctx context.Context, terraform *infrav1.Terraform, runnerClient runner.RunnerClient) {
+	refs := specRemoteOutputs(terraform)
+	for _, ref := range terraform.Status.RemoteOutputs {
+		if !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+
+	for _, ref := range refs {
+		_ = r.deleteRemoteOutputsAt(ctx, terraform, runnerClient, ref)
+	}
+}
+
+// deleteRemoteOutputsAt deletes a destination written to a remote cluster. The
+// runner refuses to delete an object which is not labelled with the Terraform
+// object. A failure is reported with a warning event.
+func (r *TerraformReconciler) deleteRemoteOutputsAt(ctx context.Context, terraform *infrav1.Terraform, runnerClient runner.RunnerClient, ref infrav1.RemoteOutputsReference) error {
+	_, err := runnerClient.DeleteOutputs(ctx, &runner.DeleteOutputsRequest{
+		Namespace:            terraform.Namespace,
+		Name:                 terraform.Name,
+		SecretName:           ref.Name,
+		Kind:                 ref.Kind,
+		TargetNamespace:      ref.Namespace,
+		KubeConfigSecretName: ref.KubeConfig.SecretRef.Name,
+		KubeConfigSecretKey:  ref.KubeConfig.SecretRef.Key,
+	})
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "unable to delete outputs in the remote cluster", "kind", ref.Kind, "name", ref.Name)
+		msg := fmt.Sprintf("Unable to delete %s/%s in the remote cluster: %s", ref.Kind, ref.Name, err)
+		r.Eventf(terraform, corev1.EventTypeWarning, infrav1.OutputsWritingFailedReason, "%s", msg)
+	}
+	return err
+}
+
 func filterOutputs(outputs map[string]tfexec.OutputMeta, outputsToWrite []string) (map[string]tfexec.OutputMeta, error) {
 	if outputs == nil || outputsToWrite == nil {
 		return nil, fmt.Errorf("input maps or outputsToWrite slice cannot be nil")
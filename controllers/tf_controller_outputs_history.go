@@ -0,0 +1,242 @@
+package controllers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/hashicorp/terraform-exec/tfexec"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OutputsHashKeySecretName is the Secret, in the namespace of the controller,
+// holding the key the hashes of the outputs are computed with.
+const OutputsHashKeySecretName = "tf-controller-outputs-hash-key"
+
+// LoadOutputsHashKey reads the key the hashes of the outputs are computed with,
+// and generates it the first time.
+func LoadOutputsHashKey(ctx context.Context, c client.Client, namespace string) ([]byte, error) {
+	objectKey := types.NamespacedName{Namespace: namespace, Name: OutputsHashKeySecretName}
+
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, objectKey, secret)
+	if apierrors.IsNotFound(err) {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: OutputsHashKeySecretName},
+			Data:       map[string][]byte{"key": key},
+		}
+		err = c.Create(ctx, secret)
+		if apierrors.IsAlreadyExists(err) {
+			// another replica created it first
+			err = c.Get(ctx, objectKey, secret)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the outputs hash key from the Secret '%s': %w", objectKey, err)
+	}
+
+	if len(secret.Data["key"]) == 0 {
+		return nil, fmt.Errorf("the Secret '%s' has no outputs hash key", objectKey)
+	}
+	return secret.Data["key"], nil
+}
+
+// outputHash hashes the type and the value of an output with the key of the
+// controller, so the hashes of sensitive outputs, stored in the status, cannot
+// be checked against guessed values.
+func outputHash(key []byte, output tfexec.OutputMeta) string {
+	h := hmac.New(sha256.New, key)
+	h.Write(output.Type)
+	h.Write([]byte{0})
+	h.Write(output.Value)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordOutputs prepends the hashes of the outputs to the history when the
+// revision or the outputs changed, and returns the names of the outputs that
+// were changed, added or removed since the previous entry.
+func recordOutputs(history []infrav1.OutputsRevision, key []byte, outputs map[string]tfexec.OutputMeta, revision string, now metav1.Time) ([]infrav1.OutputsRevision, []string) {
+	hashes := make(map[string]string, len(outputs))
+	for name, output := range outputs {
+		hashes[name] = outputHash(key, output)
+	}
+
+	var changed []string
+	if len(history) > 0 {
+		previous := history[0].Hashes
+		for name, hash := range hashes {
+			if previous[name] != hash {
+				changed = append(changed, name)
+			}
+		}
+		for name := range previous {
+			if _, ok := hashes[name]; !ok {
+				changed = append(changed, name)
+			}
+		}
+		sort.Strings(changed)
+
+		if history[0].Revision == revision && len(changed) == 0 {
+			return history, nil
+		}
+	}
+
+	entry := infrav1.OutputsRevision{
+		Revision:   revision,
+		ObservedAt: now,
+		Hashes:     hashes,
+	}
+	history = append([]infrav1.OutputsRevision{entry}, history...)
+	if len(history) > infrav1.MaxOutputsHistory {
+		history = history[:infrav1.MaxOutputsHistory]
+	}
+
+	return history, changed
+}
+
+func (r *TerraformReconciler) recordOutputs(terraform *infrav1.Terraform, outputs map[string]tfexec.OutputMeta, revision string) *infrav1.Terraform {
+	var changed []string
+	terraform.Status.OutputsHistory, changed = recordOutputs(terraform.Status.OutputsHistory, r.OutputsHashKey, outputs, revision, metav1.Now())
+	if len(changed) > 0 {
+		r.Eventf(terraform, corev1.EventTypeNormal, infrav1.OutputsChangedReason,
+			"Outputs changed at revision %s: %s", revision, strings.Join(changed, ", "))
+	}
+
+	return terraform
+}
+
+// outputsHashAnnotation is the annotation of the consumers of the outputs of
+// the Terraform object. The name part of an annotation is limited to 63
+// characters, so longer names are truncated and suffixed with their hash.
+func outputsHashAnnotation(terraform *infrav1.Terraform) string {
+	const maxNameLength = 63
+
+	name := terraform.Name
+	if len(name) > maxNameLength {
+		sum := sha256.Sum256([]byte(name))
+		name = name[:maxNameLength-9] + "-" + hex.EncodeToString(sum[:4])
+	}
+	return infrav1.OutputsHashAnnotationPrefix + name
+}
+
+// consumedOutputsHash hashes the outputs a consumer consumes, as last recorded
+// in the history.
+func consumedOutputsHash(terraform *infrav1.Terraform, consumer infrav1.OutputsConsumer) string {
+	if len(terraform.Status.OutputsHistory) == 0 {
+		return ""
+	}
+	hashes := terraform.Status.OutputsHistory[0].Hashes
+
+	names := consumer.Outputs
+	if len(names) == 0 {
+		for name := range hashes {
+			names = append(names, name)
+		}
+	}
+	names = append([]string(nil), names...)
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s=%s\n", name, hashes[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// consumerDeployments lists the Deployments selected by the consumer with the
+// API reader, as the Deployments are not watched: listing them from the cache
+// would start an informer for all the Deployments of the cluster.
+func (r *TerraformReconciler) consumerDeployments(ctx context.Context, terraform *infrav1.Terraform, consumer infrav1.OutputsConsumer) ([]appsv1.Deployment, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&consumer.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of the outputs consumers: %w", err)
+	}
+
+	var deployments appsv1.DeploymentList
+	if err := r.APIReader.List(ctx, &deployments, client.InNamespace(terraform.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("unable to list the Deployments consuming the outputs: %w", err)
+	}
+
+	return deployments.Items, nil
+}
+
+// consumersOutOfDate checks whether a Deployment consuming the outputs is not
+// annotated with the hash of the current outputs.
+func (r *TerraformReconciler) consumersOutOfDate(ctx context.Context, terraform *infrav1.Terraform) (bool, error) {
+	annotation := outputsHashAnnotation(terraform)
+	for _, consumer := range terraform.Spec.RolloutOnOutputsChange {
+		hash := consumedOutputsHash(terraform, consumer)
+		deployments, err := r.consumerDeployments(ctx, terraform, consumer)
+		if err != nil {
+			return false, err
+		}
+		for _, deployment := range deployments {
+			if deployment.Spec.Template.Annotations[annotation] != hash {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// rolloutConsumers annotates the pod template of the Deployments consuming the
+// outputs with the hash of the outputs they consume, so they are rolled out
+// when one of these outputs changes.
+func (r *TerraformReconciler) rolloutConsumers(ctx context.Context, terraform *infrav1.Terraform, revision string) (*infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	annotation := outputsHashAnnotation(terraform)
+	for _, consumer := range terraform.Spec.RolloutOnOutputsChange {
+		hash := consumedOutputsHash(terraform, consumer)
+
+		deployments, err := r.consumerDeployments(ctx, terraform, consumer)
+		if err == nil {
+			for i := range deployments {
+				deployment := &deployments[i]
+				if deployment.Spec.Template.Annotations[annotation] == hash {
+					continue
+				}
+
+				original := deployment.DeepCopy()
+				if deployment.Spec.Template.Annotations == nil {
+					deployment.Spec.Template.Annotations = map[string]string{}
+				}
+				deployment.Spec.Template.Annotations[annotation] = hash
+				if err = r.Patch(ctx, deployment, client.MergeFrom(original)); err != nil {
+					err = fmt.Errorf("unable to annotate the Deployment '%s': %w", deployment.Name, err)
+					break
+				}
+				log.Info("rolling out the Deployment consuming the outputs", "deployment", deployment.Name)
+			}
+		}
+
+		if err != nil {
+			r.Eventf(terraform, corev1.EventTypeWarning, infrav1.OutputsRolloutFailedReason, "%s", err.Error())
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.OutputsRolloutFailedReason,
+				err.Error(),
+			), err
+		}
+	}
+
+	return terraform, nil
+}
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/hashicorp/terraform-exec/tfexec"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestRecordOutputs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := metav1.Now()
+	key := []byte("key")
+	outputs := map[string]tfexec.OutputMeta{
+		"endpoint": {Type: []byte(`"string"`), Value: []byte(`"db.example.com"`)},
+		"port":     {Type: []byte(`"number"`), Value: []byte(`5432`)},
+	}
+
+	// the first apply has nothing to compare to
+	history, changed := recordOutputs(nil, key, outputs, "main@sha1:a", now)
+	g.Expect(history).To(HaveLen(1))
+	g.Expect(history[0].Hashes).To(HaveKey("endpoint"))
+	g.Expect(changed).To(BeEmpty())
+
+	// the same outputs at the same revision are not recorded again
+	history, changed = recordOutputs(history, key, outputs, "main@sha1:a", now)
+	g.Expect(history).To(HaveLen(1))
+	g.Expect(changed).To(BeEmpty())
+
+	// a new revision is recorded even when the outputs did not change
+	history, changed = recordOutputs(history, key, outputs, "main@sha1:b", now)
+	g.Expect(history).To(HaveLen(2))
+	g.Expect(changed).To(BeEmpty())
+
+	outputs = map[string]tfexec.OutputMeta{
+		"endpoint": {Type: []byte(`"string"`), Value: []byte(`"db2.example.com"`)},
+		"username": {Type: []byte(`"string"`), Value: []byte(`"admin"`)},
+	}
+	history, changed = recordOutputs(history, key, outputs, "main@sha1:c", now)
+	g.Expect(history).To(HaveLen(3))
+	g.Expect(history[0].Revision).To(Equal("main@sha1:c"))
+	g.Expect(changed).To(Equal([]string{"endpoint", "port", "username"}))
+
+	for i := 0; i < infrav1.MaxOutputsHistory; i++ {
+		history, _ = recordOutputs(history, key, outputs, "main@sha1:"+string(rune('d'+i)), now)
+	}
+	g.Expect(history).To(HaveLen(infrav1.MaxOutputsHistory))
+}
+
+func TestOutputHashIsKeyed(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	output := tfexec.OutputMeta{Sensitive: true, Type: []byte(`"string"`), Value: []byte(`"p4ssw0rd"`)}
+	g.Expect(outputHash([]byte("key"), output)).To(Equal(outputHash([]byte("key"), output)))
+	// the hash of a guessed value cannot be computed without the key
+	g.Expect(outputHash([]byte("key"), output)).ToNot(Equal(outputHash([]byte("other"), output)))
+}
+
+func TestLoadOutputsHashKey(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	c := fake.NewClientBuilder().Build()
+
+	key, err := LoadOutputsHashKey(t.Context(), c, "flux-system")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(key).To(HaveLen(32))
+
+	// the key is kept across restarts
+	again, err := LoadOutputsHashKey(t.Context(), c, "flux-system")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(again).To(Equal(key))
+
+	secret := &corev1.Secret{}
+	g.Expect(c.Get(t.Context(), client.ObjectKey{Namespace: "flux-system", Name: OutputsHashKeySecretName}, secret)).To(Succeed())
+	g.Expect(secret.Data["key"]).To(Equal(key))
+}
+
+func TestOutputsHashAnnotation(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	terraform := &infrav1.Terraform{}
+	terraform.Name = "database"
+	g.Expect(outputsHashAnnotation(terraform)).To(Equal("outputs.infra.contrib.fluxcd.io/database"))
+
+	terraform.Name = strings.Repeat("a", 100)
+	annotation := outputsHashAnnotation(terraform)
+	g.Expect(validation.IsQualifiedName(annotation)).To(BeEmpty())
+
+	// names sharing the same prefix get distinct annotations
+	terraform.Name = strings.Repeat("a", 99) + "b"
+	g.Expect(outputsHashAnnotation(terraform)).ToNot(Equal(annotation))
+}
+
+func TestConsumedOutputsHash(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	terraform := &infrav1.Terraform{}
+	terraform.Status.OutputsHistory = []infrav1.OutputsRevision{{
+		Revision: "main@sha1:a",
+		Hashes:   map[string]string{"endpoint": "1", "port": "2"},
+	}}
+
+	all := consumedOutputsHash(terraform, infrav1.OutputsConsumer{})
+	endpoint := consumedOutputsHash(terraform, infrav1.OutputsConsumer{Outputs: []string{"endpoint"}})
+	g.Expect(all).ToNot(Equal(endpoint))
+
+	// consumers are not rolled out when the outputs they do not consume change
+	terraform.Status.OutputsHistory[0].Hashes["port"] = "3"
+	g.Expect(consumedOutputsHash(terraform, infrav1.OutputsConsumer{})).ToNot(Equal(all))
+	g.Expect(consumedOutputsHash(terraform, infrav1.OutputsConsumer{Outputs: []string{"endpoint"}})).To(Equal(endpoint))
+}
+
+func TestRolloutConsumersListsWithTheAPIReader(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "flux-system", Labels: map[string]string{"app": "web"}},
+	}
+	apiReader := fake.NewClientBuilder().WithObjects(deployment).Build()
+
+	// the cached client must not list the Deployments
+	c := fake.NewClientBuilder().WithObjects(deployment).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*appsv1.DeploymentList); ok {
+				return fmt.Errorf("listed the Deployments from the cache")
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}).Build()
+
+	r := &TerraformReconciler{Client: c, APIReader: apiReader, EventRecorder: record.NewFakeRecorder(10)}
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "database", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			RolloutOnOutputsChange: []infrav1.OutputsConsumer{{
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			}},
+		},
+	}
+	terraform.Status.OutputsHistory = []infrav1.OutputsRevision{{
+		Revision: "main@sha1:a",
+		Hashes:   map[string]string{"endpoint": "1"},
+	}}
+
+	_, err := r.rolloutConsumers(t.Context(), terraform, "main@sha1:a")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	patched := &appsv1.Deployment{}
+	g.Expect(c.Get(t.Context(), client.ObjectKeyFromObject(deployment), patched)).To(Succeed())
+	g.Expect(patched.Spec.Template.Annotations).To(HaveKeyWithValue(outputsHashAnnotation(terraform), consumedOutputsHash(terraform, terraform.Spec.RolloutOnOutputsChange[0])))
+}
@@ -62,6 +62,19 @@ func (r *TerraformReconciler) plan(ctx context.Context, patchHelper *patch.Seria
 		planRequest.Destroy = true
 	}
 
+	terraform, err := r.checkVariables(ctx, terraform, tfInstance, runnerClient, revision, sourceRefRootDir)
+	if err != nil {
+		return terraform, err
+	}
+
+	// validation and tests do not apply to destroy plans
+	if !planRequest.Destroy {
+		terraform, err = r.validateAndTest(ctx, terraform, tfInstance, runnerClient, revision)
+		if err != nil {
+			return terraform, err
+		}
+	}
+
 	if terraform.Spec.TFState != nil {
 		if terraform.Spec.TFState.LockTimeout.Duration.String() != "" {
 			log.Info(fmt.Sprintf("LockTimeout is set: %s", terraform.Spec.TFState.LockTimeout))
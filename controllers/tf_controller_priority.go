@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// terraformPriorityQueue is the priority queue of the controller. The
+// requests added without priority, e.g. those of the objects using a source
+// whose revision changed, get the .spec.priority of their object. A priority
+// set by controller-runtime, like the low priority of the resyncs of
+// unchanged objects or the priority kept on a requeue, is left untouched.
+type terraformPriorityQueue struct {
+	priorityqueue.PriorityQueue[reconcile.Request]
+	priorityOf func(reconcile.Request) int
+}
+
+func (q *terraformPriorityQueue) Add(item reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{}, item)
+}
+
+func (q *terraformPriorityQueue) AddAfter(item reconcile.Request, duration time.Duration) {
+	q.AddWithOpts(priorityqueue.AddOpts{After: duration}, item)
+}
+
+func (q *terraformPriorityQueue) AddRateLimited(item reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{RateLimited: true}, item)
+}
+
+func (q *terraformPriorityQueue) AddWithOpts(o priorityqueue.AddOpts, items ...reconcile.Request) {
+	if o.Priority != nil {
+		q.PriorityQueue.AddWithOpts(o, items...)
+		return
+	}
+
+	for _, item := range items {
+		opts := o
+		priority := q.priorityOf(item)
+		opts.Priority = &priority
+		q.PriorityQueue.AddWithOpts(opts, item)
+	}
+}
+
+// newPriorityQueue returns the constructor of the queue of the controller. It
+// builds the queue like controller-runtime does when the priority queue is
+// enabled, with the priorities of the objects.
+func (r *TerraformReconciler) newPriorityQueue(log logr.Logger) func(string, workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	return func(controllerName string, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+		return &terraformPriorityQueue{
+			PriorityQueue: priorityqueue.New(controllerName, func(o *priorityqueue.Opts[reconcile.Request]) {
+				o.Log = log.WithValues("controller", controllerName)
+				o.RateLimiter = rateLimiter
+			}),
+			priorityOf: r.priorityOf,
+		}
+	}
+}
+
+// priorityOf returns the .spec.priority of the object of a request, read from
+// the cache, or 0 if the object is not found.
+func (r *TerraformReconciler) priorityOf(req reconcile.Request) int {
+	terraform := &infrav1.Terraform{}
+	if err := r.Get(context.Background(), req.NamespacedName, terraform); err != nil {
+		return 0
+	}
+	return int(terraform.Spec.Priority)
+}
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestTerraformPriorityQueue(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	request := func(name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "infra", Name: name}}
+	}
+	priorities := map[string]int{"prod": 50, "sandbox": -50}
+
+	r := &TerraformReconciler{}
+	q := r.newPriorityQueue(logr.Discard())("terraform", workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]()).(*terraformPriorityQueue)
+	q.priorityOf = func(req reconcile.Request) int { return priorities[req.Name] }
+	defer q.ShutDown()
+
+	q.Add(request("sandbox"))
+	q.Add(request("staging"))
+	q.AddWithOpts(priorityqueue.AddOpts{}, request("prod"))
+	// a priority set by controller-runtime is kept
+	q.AddWithOpts(priorityqueue.AddOpts{Priority: ptr.To(handler.LowPriority)}, request("resync"))
+
+	var order []string
+	var got []int
+	for range 4 {
+		item, priority, _ := q.GetWithPriority()
+		order = append(order, item.Name)
+		got = append(got, priority)
+		q.Done(item)
+	}
+	g.Expect(order).To(Equal([]string{"prod", "staging", "sandbox", "resync"}))
+	g.Expect(got).To(Equal([]int{50, 0, -50, handler.LowPriority}))
+}
@@ -64,6 +64,33 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, patchHelper *patch.
 		return terraform, err
 	}
 
+	if _, ok := stateRestoreRequested(terraform); ok {
+		terraform, err = r.restoreState(ctx, runnerClient, terraform, tfInstance, revision)
+		if err != nil {
+			log.Error(err, "error restoring state")
+			return terraform, err
+		}
+
+		if err := patchHelper.Patch(ctx, terraform, r.patchOptions...); err != nil {
+			log.Error(err, "unable to update status after restoring state")
+			return terraform, err
+		}
+	}
+
+	if _, ok, _ := terraform.GetStateOperation(); ok {
+		terraform = r.runStateOperation(ctx, runnerClient, terraform, tfInstance)
+		if err := patchHelper.Patch(ctx, terraform, r.patchOptions...); err != nil {
+			log.Error(err, "unable to update status after the state operation")
+			return terraform, err
+		}
+
+		// Planning, let alone auto-applying, right after the state surgery
+		// would undo it, like re-creating a resource just removed from the
+		// state. The next reconciliation plans against the modified state.
+		log.Info("state operation done, skipping plan and apply")
+		return terraform, nil
+	}
+
 	if r.AllowBreakTheGlass {
 		// spec.breakTheGlass || annotation
 		breakTheGlass := terraform.Spec.BreakTheGlass
@@ -134,9 +161,10 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, patchHelper *patch.
 		}
 	}
 
+	var driftAction infrav1.DriftAction
 	if r.shouldDetectDrift(terraform, revision) {
 		var driftDetectionErr error // declared here to avoid shadowing on terraform variable
-		terraform, driftDetectionErr = r.detectDrift(ctx, terraform, tfInstance, runnerClient, revision, tmpDir)
+		terraform, driftAction, driftDetectionErr = r.detectDrift(ctx, terraform, tfInstance, runnerClient, revision, tmpDir)
 
 		// immediately return if no drift - reconciliation will retry normally
 		if driftDetectionErr == nil {
@@ -161,8 +189,15 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, patchHelper *patch.
 			return terraform, driftDetectionErr
 		}
 
-		// immediately return if drift is detected, but it's not "force" or "auto"
-		if driftDetectionErr.Error() == infrav1.DriftDetectedReason && !r.forceOrAutoApply(terraform) {
+		// with a drift policy, its action decides whether the drift is planned,
+		// to be applied or approved
+		if terraform.Spec.DriftPolicy != nil {
+			if driftAction == infrav1.DriftActionNotify {
+				log.Error(driftDetectionErr, "drift policy only notifies of detected drift")
+				return terraform, driftDetectionErr
+			}
+		} else if driftDetectionErr.Error() == infrav1.DriftDetectedReason && !r.forceOrAutoApply(terraform) {
+			// immediately return if drift is detected, but it's not "force" or "auto"
 			log.Error(driftDetectionErr, "will not force / auto apply detected drift")
 			return terraform, driftDetectionErr
 		}
@@ -184,6 +219,8 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, patchHelper *patch.
 
 	// if we should plan this Terraform CR, do so
 	if r.shouldPlan(terraform) {
+		// the plan remediates the drift, if any, as per the drift policy
+		terraform.Status.Plan.DriftAction = driftAction
 		terraform, err = r.plan(ctx, patchHelper, terraform, tfInstance, runnerClient, revision, tmpDir)
 		if err != nil {
 			log.Error(err, "error planning")
@@ -0,0 +1,290 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/runtime/conditions"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// sourceIndexKeys are the index keys of the Terraform objects by source kind.
+var sourceIndexKeys = map[string]string{
+	sourcev1.GitRepositoryKind: infrav1.GitRepositoryIndexKey,
+	sourcev1.BucketKind:        infrav1.BucketIndexKey,
+	sourcev1.OCIRepositoryKind: infrav1.OCIRepositoryIndexKey,
+}
+
+// rolloutState is the progress of a Terraform object in the rollout of a
+// source revision.
+type rolloutState int
+
+const (
+	rolloutNotStarted rolloutState = iota
+	rolloutInFlight
+	rolloutDone
+	rolloutFailed
+)
+
+// rolloutTracker remembers the Terraform objects let through by a rollout.
+// The status of an object shows the revision only once it is planned, so
+// the objects still planning are only known from here.
+type rolloutTracker struct {
+	mu       sync.Mutex
+	rollouts map[string]*sourceRollout
+}
+
+// sourceRollout is the rollout of a revision of a source.
+type sourceRollout struct {
+	revision string
+	started  map[types.NamespacedName]bool
+}
+
+// get returns the rollout of the revision of the source, forgetting the
+// rollout of the previous revision. It must be called with the lock held.
+func (t *rolloutTracker) get(source, revision string) *sourceRollout {
+	if t.rollouts == nil {
+		t.rollouts = map[string]*sourceRollout{}
+	}
+	rollout, ok := t.rollouts[source]
+	if !ok || rollout.revision != revision {
+		rollout = &sourceRollout{revision: revision, started: map[types.NamespacedName]bool{}}
+		t.rollouts[source] = rollout
+	}
+	return rollout
+}
+
+// rolloutPolicyFor returns the RolloutPolicy of the source of the object, if
+// any. The policy is looked up in the namespace of the source, so that only
+// the owners of the source decide of its rollout.
+func (r *TerraformReconciler) rolloutPolicyFor(ctx context.Context, terraform *infrav1.Terraform) (*infrav1.RolloutPolicy, error) {
+	if terraform.Spec.Inline != nil || terraform.Spec.SourceRef.Name == "" {
+		return nil, nil
+	}
+
+	ref := terraform.Spec.SourceRef
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = terraform.GetNamespace()
+	}
+
+	var list infrav1.RolloutPolicyList
+	if err := r.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("unable to list the rollout policies: %w", err)
+	}
+
+	// when several policies target the source, the first by name wins
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+	for i, policy := range list.Items {
+		if policy.Spec.SourceRef.Kind == ref.Kind && policy.Spec.SourceRef.Name == ref.Name && !policy.Spec.Suspend {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// checkRollout decides whether the object may start reconciling the revision
+// of its source, according to the RolloutPolicy of the source. It returns an
+// empty reason when the object may proceed, or the reason and the message of
+// the wait otherwise.
+func (r *TerraformReconciler) checkRollout(ctx context.Context, terraform *infrav1.Terraform, sourceObj sourcev1.Source) (string, string, error) {
+	policy, err := r.rolloutPolicyFor(ctx, terraform)
+	if err != nil || policy == nil {
+		return "", "", err
+	}
+
+	source, sourceKey, revision, ok := rolloutSourceOf(terraform, sourceObj)
+	if !ok {
+		return "", "", nil
+	}
+
+	r.rollouts.mu.Lock()
+	defer r.rollouts.mu.Unlock()
+	rollout := r.rollouts.get(sourceKey, revision)
+
+	key := client.ObjectKeyFromObject(terraform)
+	if rollout.started[key] || r.rolloutStateOf(terraform, source, revision, rollout) != rolloutNotStarted {
+		return "", "", nil
+	}
+
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list, client.MatchingFields{
+		sourceIndexKeys[terraform.Spec.SourceRef.Kind]: client.ObjectKeyFromObject(source).String(),
+	}); err != nil {
+		return "", "", fmt.Errorf("unable to list the objects of the rollout: %w", err)
+	}
+
+	wave, err := policy.WaveOf(terraform.GetLabels())
+	if err != nil {
+		return "", "", err
+	}
+
+	var failed []string
+	var inFlight int
+	pendingWave, pending := len(policy.Spec.Waves)+1, 0
+	for i := range list.Items {
+		member := &list.Items[i]
+		// suspended members are left out, they would block the rollout forever
+		if client.ObjectKeyFromObject(member) == key || !member.DeletionTimestamp.IsZero() || member.Spec.Suspend ||
+			!usesMainSource(member, terraform.Spec.SourceRef.Kind, source) {
+			continue
+		}
+
+		state := r.rolloutStateOf(member, source, revision, rollout)
+		switch state {
+		case rolloutFailed:
+			failed = append(failed, client.ObjectKeyFromObject(member).String())
+		case rolloutInFlight:
+			inFlight++
+		}
+
+		if state == rolloutDone {
+			continue
+		}
+		memberWave, err := policy.WaveOf(member.GetLabels())
+		if err != nil {
+			return "", "", err
+		}
+		if memberWave < wave {
+			pending++
+			pendingWave = min(pendingWave, memberWave)
+		}
+	}
+
+	if policy.Spec.StopOnFailure && len(failed) > 0 {
+		sort.Strings(failed)
+		return infrav1.RolloutHaltedReason, fmt.Sprintf("Rollout of revision %s halted by %s: %s failed",
+			revision, policy.Name, strings.Join(failed, ", ")), nil
+	}
+
+	if pending > 0 {
+		return infrav1.RolloutPendingReason, fmt.Sprintf("Rollout of revision %s waits for wave %s of %s, %d objects of the previous waves are not ready yet",
+			revision, policy.WaveName(pendingWave), policy.Name, pending), nil
+	}
+
+	if policy.Spec.MaxInFlight > 0 && inFlight >= int(policy.Spec.MaxInFlight) {
+		return infrav1.RolloutPendingReason, fmt.Sprintf("Rollout of revision %s waits for a slot of %s, %d objects in flight",
+			revision, policy.Name, inFlight), nil
+	}
+
+	rollout.started[key] = true
+	return "", "", nil
+}
+
+// releaseRollout frees the slot taken by the object in the rollout of the
+// revision of its source if the reconciliation ended before the status of the
+// object shows the revision, e.g. on an error. The object is in flight only
+// from its status afterwards, and would otherwise hold the slot until the
+// next revision.
+func (r *TerraformReconciler) releaseRollout(terraform *infrav1.Terraform, sourceObj sourcev1.Source) {
+	source, sourceKey, revision, ok := rolloutSourceOf(terraform, sourceObj)
+	if !ok || terraform.Status.LastAttemptedRevision == revision || sourceRevisionAttempted(terraform, source, revision) {
+		return
+	}
+
+	r.rollouts.mu.Lock()
+	defer r.rollouts.mu.Unlock()
+	if rollout, ok := r.rollouts.rollouts[sourceKey]; ok && rollout.revision == revision {
+		delete(rollout.started, client.ObjectKeyFromObject(terraform))
+	}
+}
+
+// rolloutSourceOf returns the main source of the object, which the rollout
+// follows, along with the key of its rollout and its revision.
+func rolloutSourceOf(terraform *infrav1.Terraform, sourceObj sourcev1.Source) (client.Object, string, string, bool) {
+	if composed, ok := sourceObj.(*composedSource); ok {
+		sourceObj = composed.main
+	}
+	source, ok := sourceObj.(client.Object)
+	if !ok {
+		return nil, "", "", false
+	}
+	sourceKey := fmt.Sprintf("%s/%s/%s", terraform.Spec.SourceRef.Kind, source.GetNamespace(), source.GetName())
+	return source, sourceKey, sourceObj.GetArtifact().Revision, true
+}
+
+// rolloutStateOf returns the progress of an object in the rollout of a
+// revision. An object waiting for its dependencies is not in flight, as its
+// dependencies may still wait for a slot.
+func (r *TerraformReconciler) rolloutStateOf(terraform *infrav1.Terraform, source client.Object, revision string, rollout *sourceRollout) rolloutState {
+	attempted := terraform.Status.LastAttemptedRevision == revision || sourceRevisionAttempted(terraform, source, revision)
+	if !attempted {
+		if rollout.started[client.ObjectKeyFromObject(terraform)] {
+			return rolloutInFlight
+		}
+		return rolloutNotStarted
+	}
+
+	ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
+	switch {
+	case ready != nil && ready.Reason == infrav1.DependencyNotReadyReason:
+		return rolloutNotStarted
+	case ready != nil && ready.Status == metav1.ConditionFalse:
+		return rolloutFailed
+	case conditions.IsTrue(terraform, meta.ReadyCondition):
+		return rolloutDone
+	case terraform.Status.Plan.Pending != "" && (terraform.Spec.PlanOnly || !r.forceOrAutoApply(terraform)):
+		// the plan waits for an approval, which is not part of the rollout
+		return rolloutDone
+	}
+	return rolloutInFlight
+}
+
+// usesMainSource reports whether the source is the .spec.sourceRef of the
+// object, and not one of its additional sources.
+func usesMainSource(terraform *infrav1.Terraform, kind string, source client.Object) bool {
+	ref := terraform.Spec.SourceRef
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = terraform.GetNamespace()
+	}
+	return terraform.Spec.Inline == nil && ref.Kind == kind && ref.Name == source.GetName() && namespace == source.GetNamespace()
+}
+
+// requestsForRolloutProgressOf maps a Terraform object to the objects of the
+// same source waiting for their turn in a rollout, so they proceed as soon as
+// it finishes, instead of at their retry interval.
+func (r *TerraformReconciler) requestsForRolloutProgressOf(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := ctrl.LoggerFrom(ctx)
+
+	terraform, ok := obj.(*infrav1.Terraform)
+	if !ok || terraform.Spec.Inline != nil {
+		return nil
+	}
+	indexKey, ok := sourceIndexKeys[terraform.Spec.SourceRef.Kind]
+	if !ok {
+		return nil
+	}
+
+	namespace := terraform.Spec.SourceRef.Namespace
+	if namespace == "" {
+		namespace = terraform.GetNamespace()
+	}
+
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list, client.MatchingFields{
+		indexKey: types.NamespacedName{Namespace: namespace, Name: terraform.Spec.SourceRef.Name}.String(),
+	}); err != nil {
+		log.Error(err, "failed to list objects for rollout progress")
+		return nil
+	}
+
+	var reqs []reconcile.Request
+	for _, t := range list.Items {
+		if conditions.HasAnyReason(&t, meta.ReadyCondition, infrav1.RolloutPendingReason, infrav1.RolloutHaltedReason) {
+			reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&t)})
+		}
+	}
+	return reqs
+}
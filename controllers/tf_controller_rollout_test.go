@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckRollout(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(sourcev1.AddToScheme(scheme)).To(Succeed())
+
+	source := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "modules", Namespace: "flux-system"},
+		Status:     sourcev1.GitRepositoryStatus{Artifact: &meta.Artifact{Revision: "main@sha1:2"}},
+	}
+
+	terraform := func(name, ring string) *infrav1.Terraform {
+		obj := &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team"},
+			Spec: infrav1.TerraformSpec{SourceRef: infrav1.CrossNamespaceSourceReference{
+				Kind: sourcev1.GitRepositoryKind, Name: "modules", Namespace: "flux-system",
+			}},
+			Status: infrav1.TerraformStatus{
+				LastAttemptedRevision: "main@sha1:1",
+				Conditions:            []metav1.Condition{{Type: meta.ReadyCondition, Status: metav1.ConditionTrue, Reason: "TerraformOutputsWritten"}},
+			},
+		}
+		if ring != "" {
+			obj.Labels = map[string]string{"ring": ring}
+		}
+		return obj
+	}
+
+	policy := &infrav1.RolloutPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "modules", Namespace: "flux-system"},
+		Spec: infrav1.RolloutPolicySpec{
+			SourceRef: infrav1.RolloutSourceReference{Kind: sourcev1.GitRepositoryKind, Name: "modules"},
+			Waves: []infrav1.RolloutWave{
+				{Name: "canary", Selector: metav1.LabelSelector{MatchLabels: map[string]string{"ring": "canary"}}},
+			},
+			MaxInFlight:   1,
+			StopOnFailure: true,
+		},
+	}
+
+	r := &TerraformReconciler{}
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(policy, terraform("canary", "canary"), terraform("fleet-a", ""), terraform("fleet-b", "")).
+		WithStatusSubresource(&infrav1.Terraform{}).
+		WithIndex(&infrav1.Terraform{}, infrav1.GitRepositoryIndexKey, r.IndexBy(sourcev1.GitRepositoryKind)).
+		Build()
+
+	get := func(name string) *infrav1.Terraform {
+		obj := &infrav1.Terraform{}
+		g.Expect(r.Get(t.Context(), client.ObjectKey{Namespace: "team", Name: name}, obj)).To(Succeed())
+		return obj
+	}
+	check := func(name string) (string, string) {
+		reason, msg, err := r.checkRollout(t.Context(), get(name), source)
+		g.Expect(err).ToNot(HaveOccurred())
+		return reason, msg
+	}
+	setStatus := func(name string, status metav1.ConditionStatus, reason string) {
+		obj := get(name)
+		obj.Status.LastAttemptedRevision = "main@sha1:2"
+		obj.Status.Conditions = []metav1.Condition{{Type: meta.ReadyCondition, Status: status, Reason: reason}}
+		g.Expect(r.Status().Update(t.Context(), obj)).To(Succeed())
+	}
+
+	// the fleet waits for the canary wave
+	reason, msg := check("fleet-a")
+	g.Expect(reason).To(Equal(infrav1.RolloutPendingReason))
+	g.Expect(msg).To(Equal("Rollout of revision main@sha1:2 waits for wave canary of modules, 1 objects of the previous waves are not ready yet"))
+
+	reason, _ = check("canary")
+	g.Expect(reason).To(BeEmpty())
+
+	// the canary started, it is let through again
+	reason, _ = check("canary")
+	g.Expect(reason).To(BeEmpty())
+
+	reason, _ = check("fleet-a")
+	g.Expect(reason).To(Equal(infrav1.RolloutPendingReason))
+
+	// the fleet starts once the canary is ready, one object at a time
+	setStatus("canary", metav1.ConditionTrue, "TerraformOutputsWritten")
+	reason, _ = check("fleet-a")
+	g.Expect(reason).To(BeEmpty())
+
+	reason, msg = check("fleet-b")
+	g.Expect(reason).To(Equal(infrav1.RolloutPendingReason))
+	g.Expect(msg).To(Equal("Rollout of revision main@sha1:2 waits for a slot of modules, 1 objects in flight"))
+
+	// a failure halts the rollout
+	setStatus("fleet-a", metav1.ConditionFalse, infrav1.TFExecApplyFailedReason)
+	reason, msg = check("fleet-b")
+	g.Expect(reason).To(Equal(infrav1.RolloutHaltedReason))
+	g.Expect(msg).To(Equal("Rollout of revision main@sha1:2 halted by modules: team/fleet-a failed"))
+
+	// the failed object may retry
+	reason, _ = check("fleet-a")
+	g.Expect(reason).To(BeEmpty())
+
+	// without stopOnFailure, the failed object does not hold a slot
+	policy.Spec.StopOnFailure = false
+	g.Expect(r.Update(t.Context(), policy)).To(Succeed())
+	reason, _ = check("fleet-b")
+	g.Expect(reason).To(BeEmpty())
+
+	// a new revision starts a new rollout
+	source.Status.Artifact.Revision = "main@sha1:3"
+	reason, msg = check("fleet-b")
+	g.Expect(reason).To(Equal(infrav1.RolloutPendingReason))
+	g.Expect(msg).To(HavePrefix("Rollout of revision main@sha1:3 waits for wave canary"))
+
+	// a suspended policy lets everything through
+	policy.Spec.Suspend = true
+	g.Expect(r.Update(t.Context(), policy)).To(Succeed())
+	reason, _ = check("fleet-b")
+	g.Expect(reason).To(BeEmpty())
+}
+
+func TestCheckRolloutSkipsSuspendedAndReleasesSlots(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(sourcev1.AddToScheme(scheme)).To(Succeed())
+
+	source := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "modules", Namespace: "flux-system"},
+		Status:     sourcev1.GitRepositoryStatus{Artifact: &meta.Artifact{Revision: "main@sha1:2"}},
+	}
+
+	terraform := func(name, ring string, suspend bool) *infrav1.Terraform {
+		return &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team", Labels: map[string]string{"ring": ring}},
+			Spec: infrav1.TerraformSpec{
+				Suspend: suspend,
+				SourceRef: infrav1.CrossNamespaceSourceReference{
+					Kind: sourcev1.GitRepositoryKind, Name: "modules", Namespace: "flux-system",
+				},
+			},
+			Status: infrav1.TerraformStatus{LastAttemptedRevision: "main@sha1:1"},
+		}
+	}
+
+	policy := &infrav1.RolloutPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "modules", Namespace: "flux-system"},
+		Spec: infrav1.RolloutPolicySpec{
+			SourceRef: infrav1.RolloutSourceReference{Kind: sourcev1.GitRepositoryKind, Name: "modules"},
+			Waves: []infrav1.RolloutWave{
+				{Name: "canary", Selector: metav1.LabelSelector{MatchLabels: map[string]string{"ring": "canary"}}},
+			},
+			MaxInFlight: 1,
+		},
+	}
+
+	r := &TerraformReconciler{}
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(policy, terraform("canary", "canary", true), terraform("fleet-a", "fleet", false), terraform("fleet-b", "fleet", false)).
+		WithIndex(&infrav1.Terraform{}, infrav1.GitRepositoryIndexKey, r.IndexBy(sourcev1.GitRepositoryKind)).
+		Build()
+
+	get := func(name string) *infrav1.Terraform {
+		obj := &infrav1.Terraform{}
+		g.Expect(r.Get(t.Context(), client.ObjectKey{Namespace: "team", Name: name}, obj)).To(Succeed())
+		return obj
+	}
+	check := func(name string) string {
+		reason, _, err := r.checkRollout(t.Context(), get(name), source)
+		g.Expect(err).ToNot(HaveOccurred())
+		return reason
+	}
+
+	// the suspended canary does not hold back the fleet
+	g.Expect(check("fleet-a")).To(BeEmpty())
+	g.Expect(check("fleet-b")).To(Equal(infrav1.RolloutPendingReason))
+
+	// a reconciliation that ends before attempting the revision frees the slot
+	r.releaseRollout(get("fleet-a"), source)
+	g.Expect(check("fleet-b")).To(BeEmpty())
+
+	// once the revision is attempted, the slot is kept
+	fleetB := get("fleet-b")
+	fleetB.Status.LastAttemptedRevision = "main@sha1:2"
+	r.releaseRollout(fleetB, source)
+	g.Expect(check("fleet-a")).To(Equal(infrav1.RolloutPendingReason))
+}
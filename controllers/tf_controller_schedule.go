@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/robfig/cron/v3"
+)
+
+// parseSchedule parses .spec.schedule in .spec.timeZone. It returns nil if
+// the object has no schedule.
+func parseSchedule(terraform *infrav1.Terraform) (cron.Schedule, error) {
+	schedule := strings.TrimSpace(terraform.Spec.Schedule)
+	if schedule == "" {
+		return nil, nil
+	}
+
+	// the time zone is set with .spec.timeZone only, as for CronJobs
+	if strings.HasPrefix(schedule, "TZ=") || strings.HasPrefix(schedule, "CRON_TZ=") {
+		return nil, fmt.Errorf("invalid schedule %q: set the time zone with .spec.timeZone", terraform.Spec.Schedule)
+	}
+
+	timeZone := terraform.Spec.TimeZone
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", terraform.Spec.TimeZone, err)
+	}
+
+	parsed, err := cron.ParseStandard("CRON_TZ=" + timeZone + " " + schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", terraform.Spec.Schedule, err)
+	}
+	return parsed, nil
+}
+
+// nextReconcileAt returns the time of the periodic reconciliation following
+// the given one: the next time of the schedule if any, or the time after the
+// interval otherwise.
+func nextReconcileAt(terraform *infrav1.Terraform, last time.Time) time.Time {
+	schedule, err := parseSchedule(terraform)
+	if err != nil || schedule == nil {
+		return last.Add(terraform.Spec.Interval.Duration)
+	}
+	return schedule.Next(last)
+}
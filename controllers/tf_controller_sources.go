@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/flux-iac/tofu-controller/runner"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// additionalSource is an additional source, with the path its artifact is
+// extracted to.
+type additionalSource struct {
+	ref    infrav1.CrossNamespaceSourceReference
+	path   string
+	source sourcev1.Source
+}
+
+// composedSource is the source of a Terraform object with additional sources.
+// Its artifact is the artifact of the main source, with a revision derived
+// from the revisions of all the sources, so a change to any of them is
+// handled as a change of the source.
+type composedSource struct {
+	metav1.TypeMeta
+
+	main       sourcev1.Source
+	additional []additionalSource
+}
+
+var _ sourcev1.Source = &composedSource{}
+
+func (s *composedSource) GetRequeueAfter() time.Duration {
+	return s.main.GetRequeueAfter()
+}
+
+// GetArtifact returns nil until the artifacts of all the sources are available.
+func (s *composedSource) GetArtifact() *meta.Artifact {
+	main := s.main.GetArtifact()
+	if main == nil {
+		return nil
+	}
+
+	revisions := make([]string, 0, len(s.additional))
+	for _, a := range s.additional {
+		artifact := a.source.GetArtifact()
+		if artifact == nil {
+			return nil
+		}
+		revisions = append(revisions, a.path+"="+artifact.Revision)
+	}
+
+	artifact := main.DeepCopy()
+	artifact.Revision = composeRevision(main.Revision, revisions)
+	return artifact
+}
+
+func (s *composedSource) DeepCopyObject() runtime.Object {
+	additional := make([]additionalSource, len(s.additional))
+	for i, a := range s.additional {
+		additional[i] = additionalSource{ref: a.ref, path: a.path, source: a.source.DeepCopyObject().(sourcev1.Source)}
+	}
+	return &composedSource{
+		TypeMeta:   s.TypeMeta,
+		main:       s.main.DeepCopyObject().(sourcev1.Source),
+		additional: additional,
+	}
+}
+
+// composeRevision keeps the branch or tag of the main revision, if any, with
+// a digest of the revisions of all the sources, like main@sha256:<digest>.
+func composeRevision(main string, additional []string) string {
+	h := sha256.New()
+	fmt.Fprintln(h, main)
+	for _, revision := range additional {
+		fmt.Fprintln(h, revision)
+	}
+	digest := fmt.Sprintf("sha256:%x", h.Sum(nil))
+
+	if name, _, ok := strings.Cut(main, "@"); ok {
+		return name + "@" + digest
+	}
+	return digest
+}
+
+func (r *TerraformReconciler) composeSources(ctx context.Context, terraform *infrav1.Terraform, main sourcev1.Source) (sourcev1.Source, error) {
+	composed := &composedSource{main: main}
+	for _, additional := range terraform.Spec.AdditionalSources {
+		source, err := r.getSourceByRef(ctx, terraform, additional.SourceRef)
+		if err != nil {
+			return nil, err
+		}
+		composed.additional = append(composed.additional, additionalSource{
+			ref:    additional.SourceRef,
+			path:   additional.Path,
+			source: source,
+		})
+	}
+
+	return composed, nil
+}
+
+// additionalArtifacts downloads the artifacts of the additional sources, and
+// returns the revisions of all the sources.
+func (r *TerraformReconciler) additionalArtifacts(sourceObj sourcev1.Source, terraform *infrav1.Terraform) ([]*runner.AdditionalArtifact, []infrav1.SourceRevision, error) {
+	composed, ok := sourceObj.(*composedSource)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	revisions := []infrav1.SourceRevision{{
+		Revision: composed.main.GetArtifact().Revision,
+	}}
+	if terraform.Spec.Inline == nil {
+		revisions[0].SourceRef = terraform.Spec.SourceRef
+	}
+
+	var artifacts []*runner.AdditionalArtifact
+	for _, a := range composed.additional {
+		buf, err := r.downloadAsBytes(a.source.GetArtifact())
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to download the artifact of %s: %w", a.ref.String(), err)
+		}
+		artifacts = append(artifacts, &runner.AdditionalArtifact{TarGz: buf.Bytes(), Path: a.path})
+		revisions = append(revisions, infrav1.SourceRevision{
+			SourceRef: a.ref,
+			Path:      a.path,
+			Revision:  a.source.GetArtifact().Revision,
+		})
+	}
+
+	return artifacts, revisions, nil
+}
+
+// sourceRevisionAttempted reports whether the revision of a source has already
+// been attempted by a Terraform object with additional sources.
+func sourceRevisionAttempted(terraform *infrav1.Terraform, source client.Object, revision string) bool {
+	kind := source.GetObjectKind().GroupVersionKind().Kind
+	for _, recorded := range terraform.Status.SourceRevisions {
+		namespace := recorded.SourceRef.Namespace
+		if namespace == "" {
+			namespace = terraform.Namespace
+		}
+		if recorded.SourceRef.Name == source.GetName() && namespace == source.GetNamespace() &&
+			(kind == "" || recorded.SourceRef.Kind == kind) &&
+			recorded.Revision == revision {
+			return true
+		}
+	}
+	return false
+}
+
+// artifactBytes returns the artifact of the source, packaged for inline
+// sources, and downloaded from the source-controller otherwise.
+func (r *TerraformReconciler) artifactBytes(sourceObj sourcev1.Source) (*bytes.Buffer, error) {
+	switch source := sourceObj.(type) {
+	case *composedSource:
+		return r.artifactBytes(source.main)
+	case *inlineSource:
+		return bytes.NewBuffer(source.tarGz), nil
+	}
+	return r.downloadAsBytes(sourceObj.GetArtifact())
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestComposedSource(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	main := &sourcev1.GitRepository{}
+	main.Status.Artifact = &meta.Artifact{Revision: "main@sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb", URL: "http://source/main.tar.gz"}
+	modules := &sourcev1.GitRepository{}
+	env := &sourcev1.OCIRepository{}
+
+	composed := &composedSource{main: main, additional: []additionalSource{
+		{path: "modules", source: modules},
+		{path: "env", source: env},
+	}}
+
+	// not ready until all the artifacts are available
+	g.Expect(composed.GetArtifact()).To(BeNil())
+
+	modules.Status.Artifact = &meta.Artifact{Revision: "v1.2.0@sha1:0f8dd3a4d56e3f5b7c1a05d3c5e2b9f64b1c2d3e"}
+	env.Status.Artifact = &meta.Artifact{Revision: "latest@sha256:3f1a2b"}
+
+	artifact := composed.GetArtifact()
+	g.Expect(artifact).ToNot(BeNil())
+	g.Expect(artifact.URL).To(Equal(main.Status.Artifact.URL))
+	g.Expect(artifact.Revision).To(HavePrefix("main@sha256:"))
+	g.Expect(main.Status.Artifact.Revision).To(Equal("main@sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb"))
+
+	// a change of any of the sources changes the revision
+	revision := artifact.Revision
+	env.Status.Artifact.Revision = "latest@sha256:9c8d7e"
+	g.Expect(composed.GetArtifact().Revision).ToNot(Equal(revision))
+
+	g.Expect(composeRevision("sha256:3f1a2b", nil)).To(HavePrefix("sha256:"))
+}
+
+func TestSourceRevisionAttempted(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	terraform := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "flux-system"}}
+	terraform.Status.SourceRevisions = []infrav1.SourceRevision{
+		{SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "app"}, Revision: "main@sha1:a"},
+		{SourceRef: infrav1.CrossNamespaceSourceReference{Kind: "GitRepository", Name: "modules", Namespace: "shared"}, Path: "modules", Revision: "v1@sha1:b"},
+	}
+
+	app := &sourcev1.GitRepository{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "flux-system"}}
+	modules := &sourcev1.GitRepository{ObjectMeta: metav1.ObjectMeta{Name: "modules", Namespace: "shared"}}
+
+	g.Expect(sourceRevisionAttempted(terraform, app, "main@sha1:a")).To(BeTrue())
+	g.Expect(sourceRevisionAttempted(terraform, app, "main@sha1:c")).To(BeFalse())
+	g.Expect(sourceRevisionAttempted(terraform, modules, "v1@sha1:b")).To(BeTrue())
+	g.Expect(sourceRevisionAttempted(terraform, modules, "v2@sha1:d")).To(BeFalse())
+}
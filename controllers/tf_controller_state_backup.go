@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flux-iac/tofu-controller/api/statebackup"
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/flux-iac/tofu-controller/runner"
+	"github.com/fluxcd/pkg/runtime/acl"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (r *TerraformReconciler) shouldBackupState(terraform *infrav1.Terraform) bool {
+	return terraform.Spec.StateBackup != nil && r.stateBackendConfig(terraform) != ""
+}
+
+// backupState stores the current Terraform state in a Secret, and prunes the
+// backups exceeding .spec.stateBackup.retain. Backing up the same state twice
+// is a no-op, as backups taken before apply are identified by the state serial.
+func (r *TerraformReconciler) backupState(ctx context.Context, runnerClient runner.RunnerClient, terraform *infrav1.Terraform, tfInstance, revision string) (*infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	namespace := terraform.GetStateBackupNamespace()
+	if r.NoCrossNamespaceRefs && namespace != terraform.GetNamespace() {
+		msg := fmt.Sprintf("cannot store state backups in namespace %s, cross-namespace references have been disabled", namespace)
+		return infrav1.TerraformNotReady(terraform, revision, infrav1.AccessDeniedReason, msg), acl.AccessDeniedError(msg)
+	}
+
+	statePullReply, err := runnerClient.StatePull(ctx, &runner.StatePullRequest{
+		TfInstance: tfInstance,
+	})
+	if err != nil {
+		err = fmt.Errorf("error pulling state for backup: %s", err)
+		return infrav1.TerraformNotReady(terraform, revision, infrav1.StateBackupFailedReason, err.Error()), err
+	}
+
+	if len(statePullReply.State) == 0 {
+		log.Info("no state to back up")
+		return terraform, nil
+	}
+
+	serial, err := statebackup.Serial(statePullReply.State)
+	if err != nil {
+		return infrav1.TerraformNotReady(terraform, revision, infrav1.StateBackupFailedReason, err.Error()), err
+	}
+
+	secret, err := statebackup.ToSecret(terraform, namespace, statebackup.ReasonApply, strconv.FormatInt(serial, 10), revision, statePullReply.State)
+	if err != nil {
+		err = fmt.Errorf("error backing up state: %s", err)
+		return infrav1.TerraformNotReady(terraform, revision, infrav1.StateBackupFailedReason, err.Error()), err
+	}
+
+	if err := r.Create(ctx, secret); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			err = fmt.Errorf("error backing up state: %s", err)
+			return infrav1.TerraformNotReady(terraform, revision, infrav1.StateBackupFailedReason, err.Error()), err
+		}
+
+		log.Info("state already backed up", "backup", secret.Name)
+	} else {
+		log.Info("state backed up", "backup", secret.Name)
+	}
+
+	terraform.Status.StateBackup.LastBackup = secret.Name
+	terraform.Status.StateBackup.LastBackupAt = &metav1.Time{Time: time.Now()}
+
+	// pruning is best effort, the next backup prunes again
+	if err := r.pruneStateBackups(ctx, terraform); err != nil {
+		log.Error(err, "unable to prune state backups")
+	}
+
+	return terraform, nil
+}
+
+func (r *TerraformReconciler) pruneStateBackups(ctx context.Context, terraform *infrav1.Terraform) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	var secrets corev1.SecretList
+	if err := r.List(ctx, &secrets,
+		client.InNamespace(terraform.GetStateBackupNamespace()),
+		client.MatchingLabels(statebackup.MatchingLabels(terraform.Name, terraform.WorkspaceName())),
+	); err != nil {
+		return err
+	}
+
+	var backups []statebackup.Backup
+	for _, secret := range secrets.Items {
+		backup, err := statebackup.FromSecret(secret)
+		if err != nil {
+			log.Info("skipping state backup", "secret", secret.Name, "reason", err.Error())
+			continue
+		}
+		backups = append(backups, *backup)
+	}
+
+	for _, backup := range statebackup.Expired(backups, terraform.GetStateBackupRetain()) {
+		secret := &corev1.Secret{}
+		secret.Name = backup.Name
+		secret.Namespace = backup.Namespace
+		if err := r.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.Info("pruned state backup", "backup", backup.Name)
+	}
+
+	return nil
+}
+
+// stateRestoreRequested returns the backup requested to restore the Terraform state from.
+func stateRestoreRequested(terraform *infrav1.Terraform) (types.NamespacedName, bool) {
+	value, ok := terraform.GetAnnotations()[infrav1.RestoreStateAnnotation]
+	if !ok || value == "" {
+		return types.NamespacedName{}, false
+	}
+
+	key := types.NamespacedName{Namespace: terraform.GetStateBackupNamespace(), Name: value}
+	if namespace, name, found := strings.Cut(value, "/"); found {
+		key = types.NamespacedName{Namespace: namespace, Name: name}
+	}
+
+	return key, true
+}
+
+// restoreState pushes the state held by the requested backup, and removes the
+// request annotation once the state has been restored. The pending plan is
+// dropped, as it has been made against the replaced state.
+func (r *TerraformReconciler) restoreState(ctx context.Context, runnerClient runner.RunnerClient, terraform *infrav1.Terraform, tfInstance, revision string) (*infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	key, ok := stateRestoreRequested(terraform)
+	if !ok {
+		return terraform, nil
+	}
+
+	if r.NoCrossNamespaceRefs && key.Namespace != terraform.GetNamespace() {
+		msg := fmt.Sprintf("cannot access state backup %s, cross-namespace references have been disabled", key)
+		return infrav1.TerraformNotReady(terraform, revision, infrav1.AccessDeniedReason, msg), acl.AccessDeniedError(msg)
+	}
+
+	var secret corev1.Secret
+	if err := r.Get(ctx, key, &secret); err != nil {
+		err = fmt.Errorf("error getting state backup %s: %s", key, err)
+		return infrav1.TerraformNotReady(terraform, revision, infrav1.StateRestoreFailedReason, err.Error()), err
+	}
+
+	state, err := statebackup.State(secret)
+	if err != nil {
+		return infrav1.TerraformNotReady(terraform, revision, infrav1.StateRestoreFailedReason, err.Error()), err
+	}
+
+	log.Info("restoring state", "backup", key.String())
+	if _, err := runnerClient.StatePush(ctx, &runner.StatePushRequest{
+		TfInstance: tfInstance,
+		State:      state,
+		Force:      true,
+	}); err != nil {
+		if st, ok := status.FromError(err); ok {
+			for _, detail := range st.Details() {
+				if reply, ok := detail.(*runner.StatePushReply); ok && reply.StateLockIdentifier != "" {
+					terraform = infrav1.TerraformStateLocked(terraform, reply.StateLockIdentifier, fmt.Sprintf("Terraform Locked with Lock Identifier: %s", reply.StateLockIdentifier))
+				}
+			}
+		}
+
+		err = fmt.Errorf("error restoring state from %s: %s", key, err)
+		r.Eventf(terraform, corev1.EventTypeWarning, infrav1.StateRestoreFailedReason, "%s", err.Error())
+		return infrav1.TerraformNotReady(terraform, revision, infrav1.StateRestoreFailedReason, err.Error()), err
+	}
+
+	delete(terraform.Annotations, infrav1.RestoreStateAnnotation)
+	terraform.Status.Plan.Pending = ""
+	terraform.Status.StateBackup.LastRestored = key.String()
+	terraform.Status.StateBackup.LastRestoredAt = &metav1.Time{Time: time.Now()}
+
+	msg := fmt.Sprintf("State restored from backup %s", key)
+	r.Eventf(terraform, corev1.EventTypeNormal, infrav1.StateRestoredReason, "%s", msg)
+
+	return terraform, nil
+}
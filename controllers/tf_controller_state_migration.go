@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/flux-iac/tofu-controller/runner"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// stateBackendConfig returns the backend configuration the Terraform state is
+// initialized with, or an empty string if the backend is disabled.
+func (r *TerraformReconciler) stateBackendConfig(terraform *infrav1.Terraform) string {
+	if terraform.Spec.Cloud != nil {
+		return terraform.Spec.Cloud.ToHCL()
+	}
+
+	if r.backendCompletelyDisable(terraform) {
+		return ""
+	}
+
+	return r.backendConfig(terraform)
+}
+
+// stateBackendConfigHash hashes the backend configuration together with the
+// references of its -backend-config values. The referenced values themselves
+// are not hashed, so rotating credentials does not trigger a migration.
+func stateBackendConfigHash(backendConfig string, backendConfigsFrom []infrav1.BackendConfigsReference) string {
+	h := sha256.New()
+	h.Write([]byte(backendConfig))
+	for _, ref := range backendConfigsFrom {
+		fmt.Fprintf(h, "\n%s/%s:%v:%v", ref.Kind, ref.Name, ref.Keys, ref.Optional)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// pendingStateMigration returns the identifier of the state migration required
+// to move the Terraform state to the current backend configuration, and whether
+// a migration is required at all.
+//
+// Nothing has to be migrated when the state has never been initialized by
+// this controller, or when either the previous or the current backend is disabled.
+func (r *TerraformReconciler) pendingStateMigration(terraform *infrav1.Terraform) (string, bool) {
+	previous := terraform.Status.StateMigration
+	if previous.BackendConfigHash == "" || previous.BackendConfig == "" {
+		return "", false
+	}
+
+	backendConfig := r.stateBackendConfig(terraform)
+	if backendConfig == "" {
+		return "", false
+	}
+
+	hash := stateBackendConfigHash(backendConfig, terraform.Spec.BackendConfigsFrom)
+	if hash == previous.BackendConfigHash {
+		return "", false
+	}
+
+	return infrav1.StateMigrationID(hash), true
+}
+
+// shouldMigrateState returns true if the state migration has been approved.
+func (r *TerraformReconciler) shouldMigrateState(terraform *infrav1.Terraform, migrationID string) bool {
+	if terraform.Spec.StateMigration == nil {
+		return false
+	}
+
+	approve := terraform.Spec.StateMigration.Approve
+	return approve == infrav1.ApproveStateMigrationAutoValue || approve == migrationID
+}
+
+func (r *TerraformReconciler) migrateState(ctx context.Context, runnerClient runner.RunnerClient, terraform *infrav1.Terraform, tfInstance, workingDir, revision, migrationID string) (*infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	backendConfig := r.stateBackendConfig(terraform)
+
+	log.Info("migrating state", "migration", migrationID)
+	migrateStateReply, err := runnerClient.MigrateState(ctx, &runner.MigrateStateRequest{
+		TfInstance:            tfInstance,
+		WorkingDir:            workingDir,
+		PreviousBackendConfig: []byte(terraform.Status.StateMigration.BackendConfig),
+		BackendConfig:         []byte(backendConfig),
+		MigrationID:           migrationID,
+		Revision:              revision,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			for _, detail := range st.Details() {
+				if reply, ok := detail.(*runner.MigrateStateReply); ok && reply.StateLockIdentifier != "" {
+					terraform = infrav1.TerraformStateLocked(terraform, reply.StateLockIdentifier, fmt.Sprintf("Terraform Locked with Lock Identifier: %s", reply.StateLockIdentifier))
+				}
+			}
+		}
+
+		err = fmt.Errorf("error migrating state: %s", err)
+		r.Eventf(terraform, corev1.EventTypeWarning, infrav1.StateMigrationFailedReason, "%s", err.Error())
+
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.StateMigrationFailedReason,
+			err.Error(),
+		), err
+	}
+
+	hash := stateBackendConfigHash(backendConfig, terraform.Spec.BackendConfigsFrom)
+	terraform = infrav1.TerraformStateMigrated(terraform, migrationID, hash, backendConfig, migrateStateReply.BackupName)
+
+	msg := fmt.Sprintf("State migrated to the new backend (%s), previous state backed up to Secret %s", migrationID, migrateStateReply.BackupName)
+	r.Eventf(terraform, corev1.EventTypeNormal, infrav1.StateMigratedReason, "%s", msg)
+
+	return terraform, nil
+}
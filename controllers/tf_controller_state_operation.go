@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/flux-iac/tofu-controller/runner"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// runStateOperation executes the state operation requested with the
+// infrav1.StateOperationAnnotation, records its result in the status, and emits
+// an event on the Terraform object to keep an audit trail of the state changes.
+// The annotation is removed whether the operation succeeds or not, as
+// state surgery must not be retried blindly.
+func (r *TerraformReconciler) runStateOperation(ctx context.Context, runnerClient runner.RunnerClient, terraform *infrav1.Terraform, tfInstance string) *infrav1.Terraform {
+	log := ctrl.LoggerFrom(ctx)
+
+	op, ok, err := terraform.GetStateOperation()
+	if !ok {
+		return terraform
+	}
+	delete(terraform.Annotations, infrav1.StateOperationAnnotation)
+
+	if err != nil {
+		log.Error(err, "invalid state operation")
+		r.Eventf(terraform, corev1.EventTypeWarning, infrav1.StateOperationFailedReason, "%s", err.Error())
+		return infrav1.TerraformStateOperationFailed(terraform, op, err.Error(), nil)
+	}
+
+	log.Info("running state operation", "id", op.ID, "operation", op.String())
+
+	var (
+		message   string
+		addresses []string
+		lockID    string
+	)
+	switch op.Type {
+	case infrav1.StateOperationList:
+		var reply *runner.StateListReply
+		if reply, err = runnerClient.StateList(ctx, &runner.StateListRequest{
+			TfInstance: tfInstance,
+			Addresses:  op.Addresses,
+		}); err == nil {
+			addresses = reply.Addresses
+			message = fmt.Sprintf("Listed %d resources", len(addresses))
+		}
+	case infrav1.StateOperationMove:
+		var reply *runner.StateMoveReply
+		if reply, err = runnerClient.StateMove(ctx, &runner.StateMoveRequest{
+			TfInstance:  tfInstance,
+			Source:      op.Source,
+			Destination: op.Destination,
+		}); err == nil {
+			message = reply.Message
+		}
+	case infrav1.StateOperationRemove:
+		var reply *runner.StateRemoveReply
+		if reply, err = runnerClient.StateRemove(ctx, &runner.StateRemoveRequest{
+			TfInstance: tfInstance,
+			Addresses:  op.Addresses,
+		}); err == nil {
+			message = reply.Message
+		}
+	case infrav1.StateOperationImport:
+		var reply *runner.ImportReply
+		if reply, err = runnerClient.Import(ctx, &runner.ImportRequest{
+			TfInstance: tfInstance,
+			Address:    op.Address,
+			Id:         op.ResourceID,
+		}); err == nil {
+			message = reply.Message
+		}
+	}
+
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			for _, detail := range st.Details() {
+				switch reply := detail.(type) {
+				case *runner.StateMoveReply:
+					lockID = reply.StateLockIdentifier
+				case *runner.StateRemoveReply:
+					lockID = reply.StateLockIdentifier
+					// the addresses removed before the failure are gone from the state
+					addresses = reply.RemovedAddresses
+				case *runner.ImportReply:
+					lockID = reply.StateLockIdentifier
+				}
+			}
+		}
+		if lockID != "" {
+			terraform = infrav1.TerraformStateLocked(terraform, lockID, fmt.Sprintf("Terraform Locked with Lock Identifier: %s", lockID))
+		}
+
+		log.Error(err, "state operation failed", "id", op.ID)
+		msg := fmt.Sprintf("%s (%s) failed: %s", op, op.ID, err)
+		r.Eventf(terraform, corev1.EventTypeWarning, infrav1.StateOperationFailedReason, "%s", msg)
+		return infrav1.TerraformStateOperationFailed(terraform, op, err.Error(), addresses)
+	}
+
+	msg := fmt.Sprintf("%s (%s): %s", op, op.ID, strings.TrimSpace(message))
+	r.Eventf(terraform, corev1.EventTypeNormal, infrav1.StateOperationSucceededReason, "%s", msg)
+
+	return infrav1.TerraformStateOperationCompleted(terraform, op, message, addresses)
+}
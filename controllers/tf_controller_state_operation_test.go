@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/flux-iac/tofu-controller/runner"
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/runtime/patch"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// mockRunnerClientForStateOperation answers the RPCs setting up Terraform,
+// and records the state operations and the plans. Any other RPC panics.
+type mockRunnerClientForStateOperation struct {
+	runner.RunnerClient
+
+	removed []string
+	planned bool
+}
+
+func (m *mockRunnerClientForStateOperation) UploadAndExtract(context.Context, *runner.UploadAndExtractRequest, ...grpc.CallOption) (*runner.UploadAndExtractReply, error) {
+	return &runner.UploadAndExtractReply{WorkingDir: "/tmp/work", TmpDir: "/tmp"}, nil
+}
+
+func (m *mockRunnerClientForStateOperation) WriteBackendConfig(context.Context, *runner.WriteBackendConfigRequest, ...grpc.CallOption) (*runner.WriteBackendConfigReply, error) {
+	return &runner.WriteBackendConfigReply{}, nil
+}
+
+func (m *mockRunnerClientForStateOperation) LookPath(context.Context, *runner.LookPathRequest, ...grpc.CallOption) (*runner.LookPathReply, error) {
+	return &runner.LookPathReply{ExecPath: "/usr/bin/tofu"}, nil
+}
+
+func (m *mockRunnerClientForStateOperation) NewTerraform(context.Context, *runner.NewTerraformRequest, ...grpc.CallOption) (*runner.NewTerraformReply, error) {
+	return &runner.NewTerraformReply{Id: "1"}, nil
+}
+
+func (m *mockRunnerClientForStateOperation) SetEnv(context.Context, *runner.SetEnvRequest, ...grpc.CallOption) (*runner.SetEnvReply, error) {
+	return &runner.SetEnvReply{}, nil
+}
+
+func (m *mockRunnerClientForStateOperation) GenerateVarsForTF(context.Context, *runner.GenerateVarsForTFRequest, ...grpc.CallOption) (*runner.GenerateVarsForTFReply, error) {
+	return &runner.GenerateVarsForTFReply{}, nil
+}
+
+func (m *mockRunnerClientForStateOperation) GenerateTemplate(context.Context, *runner.GenerateTemplateRequest, ...grpc.CallOption) (*runner.GenerateTemplateReply, error) {
+	return &runner.GenerateTemplateReply{}, nil
+}
+
+func (m *mockRunnerClientForStateOperation) Init(context.Context, *runner.InitRequest, ...grpc.CallOption) (*runner.InitReply, error) {
+	return &runner.InitReply{}, nil
+}
+
+func (m *mockRunnerClientForStateOperation) SelectWorkspace(context.Context, *runner.WorkspaceRequest, ...grpc.CallOption) (*runner.WorkspaceReply, error) {
+	return &runner.WorkspaceReply{}, nil
+}
+
+func (m *mockRunnerClientForStateOperation) CleanupDir(context.Context, *runner.CleanupDirRequest, ...grpc.CallOption) (*runner.CleanupDirReply, error) {
+	return &runner.CleanupDirReply{}, nil
+}
+
+func (m *mockRunnerClientForStateOperation) StateRemove(_ context.Context, req *runner.StateRemoveRequest, _ ...grpc.CallOption) (*runner.StateRemoveReply, error) {
+	m.removed = append(m.removed, req.Addresses...)
+	return &runner.StateRemoveReply{Message: "removed"}, nil
+}
+
+func (m *mockRunnerClientForStateOperation) Plan(context.Context, *runner.PlanRequest, ...grpc.CallOption) (*runner.PlanReply, error) {
+	m.planned = true
+	return &runner.PlanReply{}, nil
+}
+
+// mockRunnerClientForFailedStateRemove removes the first address and fails on
+// the next one, like the runner.
+type mockRunnerClientForFailedStateRemove struct {
+	runner.RunnerClient
+}
+
+func (m *mockRunnerClientForFailedStateRemove) StateRemove(_ context.Context, req *runner.StateRemoveRequest, _ ...grpc.CallOption) (*runner.StateRemoveReply, error) {
+	st, err := status.New(codes.Internal, "Invalid target address (already removed: "+req.Addresses[0]+")").WithDetails(&runner.StateRemoveReply{
+		Message:          "not ok",
+		RemovedAddresses: req.Addresses[:1],
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nil, st.Err()
+}
+
+func TestReconcileStopsAfterStateOperation(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "helloworld",
+			Namespace: "flux-system",
+			Annotations: map[string]string{
+				infrav1.StateOperationAnnotation: `{"id":"1","type":"rm","addresses":["aws_instance.web"]}`,
+			},
+		},
+		Spec: infrav1.TerraformSpec{
+			ApprovePlan: infrav1.ApprovePlanAutoValue,
+			Interval:    metav1.Duration{Duration: time.Minute},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(terraform).WithStatusSubresource(terraform).Build()
+	r := &TerraformReconciler{
+		Client:           c,
+		EventRecorder:    record.NewFakeRecorder(10),
+		Scheme:           scheme,
+		RunnerRPCTimeout: time.Minute,
+	}
+	g.Expect(c.Get(t.Context(), client.ObjectKeyFromObject(terraform), terraform)).To(Succeed())
+
+	sourceObj := &inlineSource{artifact: &meta.Artifact{Revision: "inline@sha256:1234"}}
+	runnerClient := &mockRunnerClientForStateOperation{}
+
+	reconciled, err := r.reconcile(t.Context(), patch.NewSerialPatcher(terraform, c), runnerClient, terraform, sourceObj, "loop")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(runnerClient.removed).To(Equal([]string{"aws_instance.web"}))
+	g.Expect(runnerClient.planned).To(BeFalse())
+	g.Expect(reconciled.Status.StateOperation.Succeeded).To(BeTrue())
+
+	// the request is consumed, the next reconciliation plans as usual
+	stored := &infrav1.Terraform{}
+	g.Expect(c.Get(t.Context(), client.ObjectKeyFromObject(terraform), stored)).To(Succeed())
+	g.Expect(stored.Annotations).ToNot(HaveKey(infrav1.StateOperationAnnotation))
+}
+
+func TestRunStateOperationRecordsPartialRemoval(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "helloworld",
+			Namespace: "flux-system",
+			Annotations: map[string]string{
+				infrav1.StateOperationAnnotation: `{"id":"1","type":"rm","addresses":["random_pet.name","aws_instance.web"]}`,
+			},
+		},
+	}
+	terraform.Status.Plan.Pending = "plan-main-1234"
+
+	r := &TerraformReconciler{EventRecorder: record.NewFakeRecorder(10)}
+
+	terraform = r.runStateOperation(t.Context(), &mockRunnerClientForFailedStateRemove{}, terraform, "1")
+	g.Expect(terraform.Status.StateOperation.Succeeded).To(BeFalse())
+	g.Expect(terraform.Status.StateOperation.Addresses).To(Equal([]string{"random_pet.name"}))
+	g.Expect(terraform.Status.StateOperation.Message).To(ContainSubstring("already removed: random_pet.name"))
+	g.Expect(terraform.Status.Plan.Pending).To(BeEmpty())
+	g.Expect(r.EventRecorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("already removed: random_pet.name")))
+}
@@ -0,0 +1,231 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/flux-iac/tofu-controller/api/typeinfo"
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/flux-iac/tofu-controller/runner"
+	"github.com/fluxcd/pkg/runtime/acl"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// upstreamOutputs reads the outputs published by the Terraform objects
+// referenced by .spec.varsFrom, keyed by namespace/name, together with a hash
+// of them. The values are JSON encoded, ready to be used as variables.
+func (r *TerraformReconciler) upstreamOutputs(ctx context.Context, terraform *infrav1.Terraform) (map[string]*runner.TerraformOutputs, string, error) {
+	result := map[string]*runner.TerraformOutputs{}
+
+	for _, vf := range terraform.Spec.VarsFrom {
+		if vf.Kind != infrav1.TerraformKind {
+			if vf.Namespace != "" {
+				return nil, "", fmt.Errorf("varsFrom %s/%s cannot set a namespace, only Terraform references can", vf.Kind, vf.Name)
+			}
+			continue
+		}
+
+		upstreamName := types.NamespacedName{Namespace: vf.Namespace, Name: vf.Name}
+		if upstreamName.Namespace == "" {
+			upstreamName.Namespace = terraform.GetNamespace()
+		}
+
+		if r.NoCrossNamespaceRefs && upstreamName.Namespace != terraform.GetNamespace() {
+			return nil, "", acl.AccessDeniedError(
+				fmt.Sprintf("cannot access %s, cross-namespace references have been disabled", upstreamName),
+			)
+		}
+
+		var upstream infrav1.Terraform
+		if err := r.Get(ctx, upstreamName, &upstream); err != nil {
+			if apierrors.IsNotFound(err) && vf.Optional {
+				continue
+			}
+			return nil, "", fmt.Errorf("unable to get Terraform '%s' referenced by varsFrom: %w", upstreamName, err)
+		}
+
+		source, ok := outputsSourceOf(&upstream)
+		if !ok {
+			return nil, "", fmt.Errorf("the Terraform '%s' referenced by varsFrom does not write its outputs, set .spec.writeOutputsToSecret or a .spec.writeOutputsTo destination in its cluster", upstreamName)
+		}
+
+		if err := r.Get(ctx, client.ObjectKeyFromObject(source), source); err != nil {
+			if apierrors.IsNotFound(err) && vf.Optional {
+				continue
+			}
+			return nil, "", fmt.Errorf("unable to get the outputs of Terraform '%s': %w", upstreamName, err)
+		}
+
+		var data map[string][]byte
+		switch source := source.(type) {
+		case *corev1.Secret:
+			data = source.Data
+		case *corev1.ConfigMap:
+			data = map[string][]byte{}
+			for key, value := range source.Data {
+				data[key] = []byte(value)
+			}
+		}
+
+		outputs, err := outputVars(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to read the outputs of Terraform '%s': %w", upstreamName, err)
+		}
+		result[upstreamName.String()] = &runner.TerraformOutputs{Outputs: outputs}
+	}
+
+	if len(result) == 0 {
+		return result, "", nil
+	}
+
+	return result, upstreamOutputsHash(result), nil
+}
+
+// outputsSourceOf returns the object the outputs of the upstream Terraform
+// object are read from: its .spec.writeOutputsToSecret, or else its first
+// .spec.writeOutputsTo destination in the local cluster. A ConfigMap only holds
+// the outputs which are not sensitive.
+func outputsSourceOf(upstream *infrav1.Terraform) (client.Object, bool) {
+	if upstream.Spec.WriteOutputsToSecret != nil {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Namespace: upstream.GetNamespace(),
+			Name:      upstream.Spec.WriteOutputsToSecret.Name,
+		}}, true
+	}
+
+	for _, destination := range upstream.Spec.WriteOutputsTo {
+		if destination.KubeConfig != nil {
+			continue
+		}
+
+		objectMeta := metav1.ObjectMeta{Namespace: upstream.GetNamespace(), Name: destination.Name}
+		if destination.Kind == "ConfigMap" {
+			return &corev1.ConfigMap{ObjectMeta: objectMeta}, true
+		}
+		return &corev1.Secret{ObjectMeta: objectMeta}, true
+	}
+
+	return nil, false
+}
+
+// outputVars converts the data of an output secret to JSON encoded variables.
+// Values with a type companion key are already JSON, the others are strings.
+func outputVars(data map[string][]byte) (map[string][]byte, error) {
+	vars := map[string][]byte{}
+	for key, value := range data {
+		if strings.HasSuffix(key, typeinfo.Suffix) {
+			continue
+		}
+
+		if _, typed := data[key+typeinfo.Suffix]; typed {
+			if !json.Valid(value) {
+				return nil, fmt.Errorf("output %q is not valid JSON", key)
+			}
+			vars[key] = value
+			continue
+		}
+
+		encoded, err := json.Marshal(string(value))
+		if err != nil {
+			return nil, fmt.Errorf("could not encode output %q: %w", key, err)
+		}
+		vars[key] = encoded
+	}
+	return vars, nil
+}
+
+func upstreamOutputsHash(upstreams map[string]*runner.TerraformOutputs) string {
+	names := make([]string, 0, len(upstreams))
+	for name := range upstreams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		outputs := upstreams[name].Outputs
+		keys := make([]string, 0, len(outputs))
+		for key := range outputs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		fmt.Fprintf(h, "%s\n", name)
+		for _, key := range keys {
+			fmt.Fprintf(h, "%s=%s\n", key, outputs[key])
+		}
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil))
+}
+
+// upstreamOutputsChanged reports whether the outputs of the Terraform objects
+// referenced by .spec.varsFrom differ from the ones the variables were last
+// generated with. Errors are left to the dependency check to report.
+func (r *TerraformReconciler) upstreamOutputsChanged(ctx context.Context, terraform *infrav1.Terraform) bool {
+	if len(terraform.GetVarsFromTerraform()) == 0 {
+		return false
+	}
+
+	_, hash, err := r.upstreamOutputs(ctx, terraform)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).V(1).Info("unable to read the upstream outputs", "error", err.Error())
+		return false
+	}
+
+	return hash != terraform.Status.UpstreamOutputsHash
+}
+
+// IndexVarsFromTerraform indexes a Terraform object by the Terraform objects
+// it reads variables from.
+func (r *TerraformReconciler) IndexVarsFromTerraform(o client.Object) []string {
+	terraform, ok := o.(*infrav1.Terraform)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Terraform, got %T", o))
+	}
+
+	var keys []string
+	for _, ref := range terraform.GetVarsFromTerraform() {
+		keys = append(keys, ref.String())
+	}
+	return keys
+}
+
+// requestsForOutputsChangeOf maps an output Secret or ConfigMap to the Terraform
+// objects reading variables from the Terraform object which owns it.
+func (r *TerraformReconciler) requestsForOutputsChangeOf(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := ctrl.LoggerFrom(ctx)
+
+	var owner string
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == infrav1.TerraformKind && ref.Controller != nil && *ref.Controller {
+			owner = types.NamespacedName{Namespace: obj.GetNamespace(), Name: ref.Name}.String()
+		}
+	}
+	if owner == "" {
+		return nil
+	}
+
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list, client.MatchingFields{
+		infrav1.VarsFromTerraformIndexKey: owner,
+	}); err != nil {
+		log.Error(err, "failed to list objects for outputs change")
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, 0, len(list.Items))
+	for _, t := range list.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&t)})
+	}
+	return reqs
+}
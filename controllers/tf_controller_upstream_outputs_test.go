@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/flux-iac/tofu-controller/runner"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestOutputVars(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	vars, err := outputVars(map[string][]byte{
+		"endpoint":      []byte("db.example.com"),
+		"port":          []byte("5432"),
+		"port__type":    []byte(`"number"`),
+		"subnets":       []byte(`["a","b"]`),
+		"subnets__type": []byte(`["list","string"]`),
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(vars).To(Equal(map[string][]byte{
+		"endpoint": []byte(`"db.example.com"`),
+		"port":     []byte(`5432`),
+		"subnets":  []byte(`["a","b"]`),
+	}))
+
+	_, err = outputVars(map[string][]byte{
+		"port":       []byte("not json"),
+		"port__type": []byte(`"number"`),
+	})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestUpstreamOutputsHash(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	outputs := map[string]*runner.TerraformOutputs{
+		"apps/database": {Outputs: map[string][]byte{"endpoint": []byte(`"db.example.com"`)}},
+	}
+	hash := upstreamOutputsHash(outputs)
+	g.Expect(upstreamOutputsHash(outputs)).To(Equal(hash))
+
+	outputs["apps/database"].Outputs["endpoint"] = []byte(`"db2.example.com"`)
+	g.Expect(upstreamOutputsHash(outputs)).ToNot(Equal(hash))
+}
+
+func TestUpstreamOutputsFromWriteOutputsTo(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	// the outputs are read from the first destination in the local cluster
+	upstream := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "database", Namespace: "apps"},
+		Spec: infrav1.TerraformSpec{
+			WriteOutputsTo: []infrav1.OutputDestination{
+				{Kind: "Secret", Name: "database-outputs", KubeConfig: &infrav1.KubeConfigReference{}},
+				{Kind: "ConfigMap", Name: "database-outputs"},
+			},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "database-outputs", Namespace: "apps"},
+		Data: map[string]string{
+			"endpoint":   "db.example.com",
+			"port":       "5432",
+			"port__type": `"number"`,
+		},
+	}
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "apps"},
+		Spec: infrav1.TerraformSpec{
+			VarsFrom: []infrav1.VarsReference{{Kind: infrav1.TerraformKind, Name: "database"}},
+		},
+	}
+
+	r := &TerraformReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(upstream, configMap).Build(),
+	}
+
+	outputs, hash, err := r.upstreamOutputs(t.Context(), terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(hash).ToNot(BeEmpty())
+	g.Expect(outputs).To(HaveKey("apps/database"))
+	g.Expect(outputs["apps/database"].Outputs).To(Equal(map[string][]byte{
+		"endpoint": []byte(`"db.example.com"`),
+		"port":     []byte(`5432`),
+	}))
+
+	// without any local destination, the outputs cannot be read
+	upstream.Spec.WriteOutputsTo = upstream.Spec.WriteOutputsTo[:1]
+	r.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(upstream, configMap).Build()
+	_, _, err = r.upstreamOutputs(t.Context(), terraform)
+	g.Expect(err).To(MatchError(ContainSubstring("does not write its outputs")))
+}
+
+func TestOutputsChangePredicateUpdate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	p := OutputsChangePredicate{}
+	oldConfigMap := &corev1.ConfigMap{Data: map[string]string{"endpoint": "db.example.com"}}
+
+	g.Expect(p.Update(event.UpdateEvent{
+		ObjectOld: oldConfigMap,
+		ObjectNew: &corev1.ConfigMap{Data: map[string]string{"endpoint": "db.example.org"}},
+	})).To(BeTrue())
+	g.Expect(p.Update(event.UpdateEvent{
+		ObjectOld: oldConfigMap,
+		ObjectNew: oldConfigMap.DeepCopy(),
+	})).To(BeFalse())
+	g.Expect(p.Update(event.UpdateEvent{
+		ObjectOld: &corev1.Secret{Data: map[string][]byte{"endpoint": []byte("db.example.com")}},
+		ObjectNew: &corev1.Secret{Data: map[string][]byte{"endpoint": []byte("db.example.org")}},
+	})).To(BeTrue())
+}
@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/flux-iac/tofu-controller/runner"
+	"github.com/fluxcd/pkg/runtime/conditions"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// validateAndTest runs the validation and the tests of the module requested with
+// .spec.validate and .spec.test, before planning. Any failure blocks the plan,
+// and the results are recorded in the Validated and Tested conditions.
+func (r *TerraformReconciler) validateAndTest(ctx context.Context, terraform *infrav1.Terraform, tfInstance string, runnerClient runner.RunnerClient, revision string) (*infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if terraform.Spec.Validate == nil {
+		conditions.Delete(terraform, infrav1.ConditionTypeValidated)
+	} else {
+		log.Info("calling validate ...")
+
+		reply, err := runnerClient.Validate(ctx, &runner.ValidateRequest{
+			TfInstance:  tfInstance,
+			CheckFormat: !terraform.Spec.Validate.SkipFormatCheck,
+		})
+		if err != nil {
+			err = fmt.Errorf("error running validate: %s", err)
+			r.Eventf(terraform, corev1.EventTypeWarning, infrav1.ValidationFailedReason, "%s", err.Error())
+			return infrav1.TerraformValidationFailed(terraform, revision, err.Error()), err
+		}
+
+		if !reply.Valid {
+			msg := validationFailureMessage(reply)
+			r.Eventf(terraform, corev1.EventTypeWarning, infrav1.ValidationFailedReason, "%s", msg)
+			return infrav1.TerraformValidationFailed(terraform, revision, msg), fmt.Errorf("%s", reply.Message)
+		}
+
+		terraform = infrav1.TerraformValidated(terraform, reply.Message)
+	}
+
+	if terraform.Spec.Test == nil {
+		conditions.Delete(terraform, infrav1.ConditionTypeTested)
+	} else {
+		log.Info("calling test ...")
+
+		testCtx := ctx
+		if timeout := terraform.Spec.Test.Timeout; timeout != nil {
+			var cancel context.CancelFunc
+			testCtx, cancel = context.WithTimeout(ctx, timeout.Duration)
+			defer cancel()
+		}
+
+		reply, err := runnerClient.Test(testCtx, &runner.TestRequest{
+			TfInstance: tfInstance,
+		})
+		if err != nil {
+			err = fmt.Errorf("error running test: %s", err)
+			r.Eventf(terraform, corev1.EventTypeWarning, infrav1.TestsFailedReason, "%s", err.Error())
+			return infrav1.TerraformTestsFailed(terraform, revision, err.Error()), err
+		}
+
+		if !reply.Passed {
+			msg := strings.Join(append([]string{"Tests failed: " + reply.Message}, reply.Failures...), "\n")
+			r.Eventf(terraform, corev1.EventTypeWarning, infrav1.TestsFailedReason, "%s", msg)
+			return infrav1.TerraformTestsFailed(terraform, revision, msg), fmt.Errorf("tests failed: %s", reply.Message)
+		}
+
+		terraform = infrav1.TerraformTested(terraform, "Tests passed: "+reply.Message)
+	}
+
+	return terraform, nil
+}
+
+func validationFailureMessage(reply *runner.ValidateReply) string {
+	lines := append([]string{reply.Message}, reply.Diagnostics...)
+	if len(reply.UnformattedFiles) > 0 {
+		lines = append(lines, "Not formatted: "+strings.Join(reply.UnformattedFiles, ", "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// checkVariables compares the variables with the variable blocks of the module.
+// Type mismatches block the plan. Missing required variables are only reported,
+// as Terraform may get them from a source the check does not read, like a
+// variable declared in a tfvars file of a parent directory, and unknown
+// variables are ignored by Terraform. The variables of the module are recorded
+// in the status.
+func (r *TerraformReconciler) checkVariables(ctx context.Context, terraform *infrav1.Terraform, tfInstance string, runnerClient runner.RunnerClient, revision string, sourceRefRootDir string) (*infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.Info("calling check variables ...")
+
+	reply, err := runnerClient.CheckVariables(ctx, &runner.CheckVariablesRequest{
+		TfInstance:       tfInstance,
+		SourceRefRootDir: sourceRefRootDir,
+	})
+	if err != nil {
+		// the module may use syntax the checker does not know, leave it to Terraform
+		log.Info("unable to check the variables, skipping", "error", err.Error())
+		conditions.Delete(terraform, infrav1.ConditionTypeVariablesInvalid)
+		return terraform, nil
+	}
+
+	terraform.Status.Variables = variableSchemas(reply.Variables)
+
+	if len(reply.Invalid) > 0 {
+		msg := variablesProblemsMessage(reply)
+		r.Eventf(terraform, corev1.EventTypeWarning, infrav1.VariablesInvalidReason, "%s", msg)
+		return infrav1.TerraformVariablesInvalid(terraform, revision, msg), fmt.Errorf("invalid variables: %s", reply.Message)
+	}
+
+	if len(reply.Missing) > 0 {
+		msg := variablesProblemsMessage(reply)
+		r.Eventf(terraform, corev1.EventTypeWarning, infrav1.MissingVariablesReason, "%s", msg)
+		conditions.MarkTrue(terraform, infrav1.ConditionTypeVariablesInvalid, infrav1.MissingVariablesReason, "%s", msg)
+	} else if len(reply.Unknown) > 0 {
+		conditions.MarkTrue(terraform, infrav1.ConditionTypeVariablesInvalid, infrav1.UnknownVariablesReason,
+			"Values for undeclared variables are ignored: %s", strings.Join(reply.Unknown, ", "))
+	} else {
+		conditions.Delete(terraform, infrav1.ConditionTypeVariablesInvalid)
+	}
+
+	return terraform, nil
+}
+
+func variablesProblemsMessage(reply *runner.CheckVariablesReply) string {
+	var lines []string
+	if len(reply.Missing) > 0 {
+		lines = append(lines, "Missing required variables: "+strings.Join(reply.Missing, ", "))
+	}
+	if len(reply.Invalid) > 0 {
+		lines = append(lines, "Invalid variables:")
+		lines = append(lines, reply.Invalid...)
+	}
+	if len(reply.Unknown) > 0 {
+		lines = append(lines, "Undeclared variables: "+strings.Join(reply.Unknown, ", "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func variableSchemas(variables []*runner.VariableSchema) []infrav1.VariableSchema {
+	if len(variables) == 0 {
+		return nil
+	}
+
+	schemas := make([]infrav1.VariableSchema, 0, len(variables))
+	for _, v := range variables {
+		schema := infrav1.VariableSchema{
+			Name:        v.Name,
+			Type:        v.Type,
+			Description: v.Description,
+			Required:    v.Required,
+			Sensitive:   v.Sensitive,
+			Validations: v.Validations,
+		}
+		if len(v.Default) > 0 {
+			schema.Default = &apiextensionsv1.JSON{Raw: v.Default}
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas
+}
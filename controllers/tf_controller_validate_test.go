@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/flux-iac/tofu-controller/runner"
+	"github.com/fluxcd/pkg/runtime/conditions"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+type mockRunnerClientForCheckVariables struct {
+	runner.RunnerClient
+
+	reply *runner.CheckVariablesReply
+}
+
+func (m *mockRunnerClientForCheckVariables) CheckVariables(context.Context, *runner.CheckVariablesRequest, ...grpc.CallOption) (*runner.CheckVariablesReply, error) {
+	return m.reply, nil
+}
+
+func TestCheckVariablesOnlyBlocksInvalidVariables(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	r := &TerraformReconciler{EventRecorder: record.NewFakeRecorder(10)}
+
+	// a missing variable may be found by Terraform, the plan goes on
+	terraform, err := r.checkVariables(t.Context(), &infrav1.Terraform{}, "1", &mockRunnerClientForCheckVariables{
+		reply: &runner.CheckVariablesReply{Missing: []string{"region"}},
+	}, "main@sha1:1234", "/tmp/source")
+	g.Expect(err).ToNot(HaveOccurred())
+	condition := conditions.Get(terraform, infrav1.ConditionTypeVariablesInvalid)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(condition.Reason).To(Equal(infrav1.MissingVariablesReason))
+	g.Expect(condition.Message).To(Equal("Missing required variables: region"))
+
+	// unknown variables are ignored by Terraform, the plan goes on
+	terraform, err = r.checkVariables(t.Context(), &infrav1.Terraform{}, "1", &mockRunnerClientForCheckVariables{
+		reply: &runner.CheckVariablesReply{Unknown: []string{"zone"}},
+	}, "main@sha1:1234", "/tmp/source")
+	g.Expect(err).ToNot(HaveOccurred())
+	condition = conditions.Get(terraform, infrav1.ConditionTypeVariablesInvalid)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(condition.Reason).To(Equal(infrav1.UnknownVariablesReason))
+
+	terraform, err = r.checkVariables(t.Context(), &infrav1.Terraform{}, "1", &mockRunnerClientForCheckVariables{
+		reply: &runner.CheckVariablesReply{Invalid: []string{"replicas: a number is required"}},
+	}, "main@sha1:1234", "/tmp/source")
+	g.Expect(err).To(HaveOccurred())
+	condition = conditions.Get(terraform, infrav1.ConditionTypeVariablesInvalid)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(condition.Reason).To(Equal(infrav1.VariablesInvalidReason))
+
+	// nothing to report removes the condition
+	terraform, err = r.checkVariables(t.Context(), terraform, "1", &mockRunnerClientForCheckVariables{
+		reply: &runner.CheckVariablesReply{},
+	}, "main@sha1:1234", "/tmp/source")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(conditions.Has(terraform, infrav1.ConditionTypeVariablesInvalid)).To(BeFalse())
+}
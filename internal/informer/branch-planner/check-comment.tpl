@@ -0,0 +1,5 @@
+tf-controller blocked the plan, the {{ .Check }} failed:
+
+```
+{{ .Message }}
+```
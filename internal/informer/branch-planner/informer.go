@@ -16,6 +16,7 @@ import (
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -29,8 +30,12 @@ var (
 	//go:embed error-comment.tpl
 	errorCommentTemplate string
 
+	//go:embed check-comment.tpl
+	checkCommentTemplate string
+
 	parsedPlanTemplate  = template.Must(template.New("plan-comment").Parse(planCommentTemplate))
 	parsedErrorTemplate = template.Must(template.New("error-comment").Parse(errorCommentTemplate))
+	parsedCheckTemplate = template.Must(template.New("check-comment").Parse(checkCommentTemplate))
 )
 
 type Informer struct {
@@ -158,7 +163,11 @@ func (i *Informer) updateHandler(oldObj, newObj any) {
 	}
 
 	for _, condition := range new.Status.Conditions {
-		if condition.Reason == infrav1.TFExecInitFailedReason || condition.Reason == infrav1.PostPlanningWebhookFailedReason {
+		if condition.Reason == infrav1.TFExecInitFailedReason ||
+			condition.Reason == infrav1.PostPlanningWebhookFailedReason ||
+			condition.Reason == infrav1.ValidationFailedReason ||
+			condition.Reason == infrav1.TestsFailedReason ||
+			condition.Reason == infrav1.VariablesInvalidReason {
 			if ann := new.GetAnnotations(); ann != nil && ann[config.AnnotationErrorRevision] == new.Status.LastAttemptedRevision {
 				break
 			}
@@ -175,7 +184,17 @@ func (i *Informer) updateHandler(oldObj, newObj any) {
 				return
 			}
 
-			content, err := formatErrorOutput(condition.Message)
+			var content []byte
+			switch condition.Reason {
+			case infrav1.ValidationFailedReason:
+				content, err = formatCheckOutput("validation", condition.Message)
+			case infrav1.TestsFailedReason:
+				content, err = formatCheckOutput("tests", condition.Message)
+			case infrav1.VariablesInvalidReason:
+				content, err = formatCheckOutput("variables check", condition.Message)
+			default:
+				content, err = formatErrorOutput(condition.Message)
+			}
 			if err != nil {
 				i.log.Error(err, "failed to format error output")
 				return
@@ -214,7 +233,7 @@ func (i *Informer) updateHandler(oldObj, newObj any) {
 
 	i.log.Info("Updated plan", "pr-id", new.Labels[config.LabelPRIDKey])
 
-	content, err := formatPlanOutput(plan)
+	content, err := formatPlanOutput(plan, checkResults(new))
 	if err != nil {
 		i.log.Error(err, "failed to format plan output")
 		return
@@ -365,8 +384,31 @@ func (i *Informer) getRepo(ctx context.Context, tf *infrav1.Terraform) (provider
 	return provider.RepoFromURL(obj.Spec.URL)
 }
 
-func formatPlanOutput(planOutput string) ([]byte, error) {
-	data := struct{ PlanOutput string }{PlanOutput: planOutput}
+// checkResults returns the results of the validation and the tests run before
+// the plan, to be shown along with it.
+func checkResults(tf *infrav1.Terraform) []string {
+	var results []string
+	for _, condition := range tf.Status.Conditions {
+		if condition.Status != metav1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case infrav1.ConditionTypeValidated:
+			results = append(results, "Validation: "+condition.Message)
+		case infrav1.ConditionTypeTested:
+			results = append(results, "Tests: "+condition.Message)
+		}
+	}
+
+	return results
+}
+
+func formatPlanOutput(planOutput string, checks []string) ([]byte, error) {
+	data := struct {
+		PlanOutput string
+		Checks     []string
+	}{PlanOutput: planOutput, Checks: checks}
 
 	var buf bytes.Buffer
 	if err := parsedPlanTemplate.Execute(&buf, data); err != nil {
@@ -386,3 +428,14 @@ func formatErrorOutput(message string) ([]byte, error) {
 
 	return buf.Bytes(), nil
 }
+
+func formatCheckOutput(check, message string) ([]byte, error) {
+	data := struct{ Check, Message string }{Check: check, Message: message}
+
+	var buf bytes.Buffer
+	if err := parsedCheckTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to format %s output: %w", check, err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -1,3 +1,9 @@
+{{- if .Checks -}}
+tf-controller checks:
+
+{{ range .Checks }}- {{ . }}
+{{ end }}
+{{ end -}}
 tf-controller plan output:
 
 ```hcl
@@ -66,7 +66,12 @@ func RunnerServe(namespace, addr string, tlsSecretName string, sigterm chan os.S
 
 	// 30 MB is the maximum allowed payload size for gRPC.
 	maxMsgSize := maxMessageSizeInMiB * 1024 * 1024
-	grpcServer := grpc.NewServer(grpc.Creds(credentials), grpc.MaxRecvMsgSize(maxMsgSize), grpc.MaxSendMsgSize(maxMsgSize))
+	grpcServer := grpc.NewServer(
+		grpc.Creds(credentials),
+		grpc.MaxRecvMsgSize(maxMsgSize),
+		grpc.MaxSendMsgSize(maxMsgSize),
+		grpc.UnaryInterceptor(runnerServer.RedactInterceptor),
+	)
 	runner.RegisterRunnerServer(grpcServer, runnerServer)
 
 	if err := grpcServer.Serve(listener); err != nil {
@@ -0,0 +1,285 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// RedactedValue replaces the sensitive values in the output of the runner.
+	RedactedValue = "***"
+
+	// minRedactedLength avoids masking short values, like true or 1, which
+	// would make every message unreadable.
+	minRedactedLength = 4
+)
+
+// redactor holds the sensitive values of the current Terraform session: the
+// values sourced from Secrets and the values of variables declared sensitive.
+type redactor struct {
+	mu       sync.RWMutex
+	values   map[string]struct{}
+	replacer *strings.Replacer
+}
+
+func (s *redactor) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values = nil
+	s.replacer = nil
+}
+
+// add registers sensitive values.
+func (s *redactor) add(values ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := false
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if len(value) < minRedactedLength {
+			continue
+		}
+		if s.values == nil {
+			s.values = map[string]struct{}{}
+		}
+		if _, ok := s.values[value]; !ok {
+			s.values[value] = struct{}{}
+			changed = true
+		}
+	}
+
+	if changed {
+		s.replacer = newRedactReplacer(s.values)
+	}
+}
+
+// addJSON registers the strings of a JSON value.
+func (s *redactor) addJSON(value *apiextensionsv1.JSON) {
+	if value == nil {
+		return
+	}
+
+	var v any
+	if err := json.Unmarshal(value.Raw, &v); err != nil {
+		return
+	}
+	s.add(jsonStrings(v)...)
+}
+
+// redact masks the sensitive values of a string.
+func (s *redactor) redact(text string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.replacer == nil {
+		return text
+	}
+	return s.replacer.Replace(text)
+}
+
+// redactJSON masks the string values of a JSON document which are equal to a
+// sensitive value. Unlike redact, it leaves the keys and the other strings
+// alone, so that the document keeps its structure and can still be parsed.
+func (s *redactor) redactJSON(data []byte) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.values) == 0 {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(s.redactStrings(v))
+}
+
+func (s *redactor) redactStrings(v any) any {
+	switch v := v.(type) {
+	case string:
+		if _, ok := s.values[strings.TrimSpace(v)]; ok {
+			return RedactedValue
+		}
+	case []any:
+		for i := range v {
+			v[i] = s.redactStrings(v[i])
+		}
+	case map[string]any:
+		for k := range v {
+			v[k] = s.redactStrings(v[k])
+		}
+	}
+	return v
+}
+
+func (s *redactor) redactAll(texts []string) []string {
+	for i := range texts {
+		texts[i] = s.redact(texts[i])
+	}
+	return texts
+}
+
+func (s *redactor) redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := s.redact(err.Error())
+	if msg == err.Error() {
+		return err
+	}
+	return errors.New(msg)
+}
+
+// writer masks the sensitive values of everything written to w.
+func (s *redactor) writer(w io.Writer) io.Writer {
+	return &redactWriter{redactor: s, w: w}
+}
+
+// newRedactReplacer replaces the values as they are, and as they appear in
+// JSON documents, longest first so a value containing another one is fully masked.
+func newRedactReplacer(values map[string]struct{}) *strings.Replacer {
+	forms := map[string]struct{}{}
+	for value := range values {
+		forms[value] = struct{}{}
+		if encoded, err := json.Marshal(value); err == nil {
+			forms[strings.Trim(string(encoded), `"`)] = struct{}{}
+		}
+	}
+
+	sorted := make([]string, 0, len(forms))
+	for form := range forms {
+		sorted = append(sorted, form)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) > len(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+
+	pairs := make([]string, 0, 2*len(sorted))
+	for _, form := range sorted {
+		pairs = append(pairs, form, RedactedValue)
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+func jsonStrings(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var values []string
+		for _, item := range v {
+			values = append(values, jsonStrings(item)...)
+		}
+		return values
+	case map[string]any:
+		var values []string
+		for _, item := range v {
+			values = append(values, jsonStrings(item)...)
+		}
+		return values
+	}
+	return nil
+}
+
+type redactWriter struct {
+	redactor *redactor
+	w        io.Writer
+}
+
+func (w *redactWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.w, w.redactor.redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// redactSink masks the sensitive values of the messages, values and errors
+// of log lines.
+type redactSink struct {
+	logr.LogSink
+	redactor *redactor
+}
+
+func (s redactSink) Info(level int, msg string, keysAndValues ...any) {
+	s.LogSink.Info(level, s.redactor.redact(msg), s.redactValues(keysAndValues)...)
+}
+
+func (s redactSink) Error(err error, msg string, keysAndValues ...any) {
+	s.LogSink.Error(s.redactor.redactError(err), s.redactor.redact(msg), s.redactValues(keysAndValues)...)
+}
+
+func (s redactSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return redactSink{LogSink: s.LogSink.WithValues(s.redactValues(keysAndValues)...), redactor: s.redactor}
+}
+
+func (s redactSink) WithName(name string) logr.LogSink {
+	return redactSink{LogSink: s.LogSink.WithName(name), redactor: s.redactor}
+}
+
+func (s redactSink) redactValues(keysAndValues []any) []any {
+	redacted := make([]any, len(keysAndValues))
+	for i, v := range keysAndValues {
+		switch v := v.(type) {
+		case string:
+			redacted[i] = s.redactor.redact(v)
+		case error:
+			redacted[i] = s.redactor.redactError(v)
+		case fmt.Stringer:
+			redacted[i] = s.redactor.redact(v.String())
+		default:
+			redacted[i] = v
+		}
+	}
+	return redacted
+}
+
+// RedactInterceptor masks the sensitive values of the session in the log lines
+// of the requests, and in the errors returned to the controller, which end up
+// in events and conditions.
+func (r *TerraformRunnerServer) RedactInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	log := ctrl.LoggerFrom(ctx)
+	if log.GetSink() != nil {
+		ctx = logr.NewContext(ctx, logr.New(redactSink{LogSink: log.GetSink(), redactor: &r.sensitive}))
+	}
+
+	reply, err := handler(ctx, req)
+	if err == nil {
+		return reply, nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return reply, r.sensitive.redactError(err)
+	}
+
+	msg := r.sensitive.redact(st.Message())
+	if msg == st.Message() {
+		return reply, err
+	}
+
+	redacted := st.Proto()
+	redacted.Message = msg
+	return reply, status.ErrorProto(redacted)
+}
@@ -0,0 +1,87 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestRedactor(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var r redactor
+	g.Expect(r.redact("nothing to hide")).To(Equal("nothing to hide"))
+
+	r.add("s3cr3t", "pass\"word", "abc")
+	r.addJSON(&apiextensionsv1.JSON{Raw: []byte(`{"users":["admin-token"],"port":5432}`)})
+
+	g.Expect(r.redact(`Error: invalid value "s3cr3t" for password`)).To(Equal(`Error: invalid value "***" for password`))
+	// values are also masked as they appear in JSON documents
+	g.Expect(r.redact(`{"password":"pass\"word"}`)).To(Equal(`{"password":"***"}`))
+	g.Expect(r.redact("token admin-token")).To(Equal("token ***"))
+	// short values are not masked
+	g.Expect(r.redact("abc 5432")).To(Equal("abc 5432"))
+
+	var buf bytes.Buffer
+	_, err := r.writer(&buf).Write([]byte("using s3cr3t\n"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(buf.String()).To(Equal("using ***\n"))
+
+	r.reset()
+	g.Expect(r.redact("s3cr3t")).To(Equal("s3cr3t"))
+}
+
+func TestRedactJSON(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var r redactor
+	plan := []byte(`{"format_version":"1.2","resource_changes":[{"address":"aws_db_instance.s3cr3t","change":{"after":{"password":"s3cr3t","description":"not s3cr3t at all","port":5432,"s3cr3t":"s3cr3t"}}}]}`)
+
+	redacted, err := r.redactJSON(plan)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(redacted).To(Equal(plan))
+
+	r.add("s3cr3t")
+	redacted, err = r.redactJSON(plan)
+	g.Expect(err).ToNot(HaveOccurred())
+	// only whole values are masked, the keys and the other strings are kept
+	g.Expect(redacted).To(MatchJSON(`{"format_version":"1.2","resource_changes":[{"address":"aws_db_instance.s3cr3t","change":{"after":{"password":"***","description":"not s3cr3t at all","port":5432,"s3cr3t":"***"}}}]}`))
+
+	_, err = r.redactJSON([]byte("not json"))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestRedactInterceptor(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := &TerraformRunnerServer{}
+	server.sensitive.add("s3cr3t")
+
+	var logged []string
+	ctx := logr.NewContext(context.Background(), funcr.New(func(prefix, args string) {
+		logged = append(logged, args)
+	}, funcr.Options{}))
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		logr.FromContextOrDiscard(ctx).Error(errors.New("bad s3cr3t"), "failed with s3cr3t", "value", "s3cr3t")
+		return &PlanReply{Message: "s3cr3t"}, status.Error(codes.Internal, "error running Plan: s3cr3t is invalid")
+	}
+
+	reply, err := server.RedactInterceptor(ctx, &PlanRequest{}, &grpc.UnaryServerInfo{}, handler)
+	g.Expect(status.Code(err)).To(Equal(codes.Internal))
+	g.Expect(status.Convert(err).Message()).To(Equal("error running Plan: *** is invalid"))
+	// replies are left untouched, they may carry values like outputs
+	g.Expect(reply.(*PlanReply).Message).To(Equal("s3cr3t"))
+
+	g.Expect(logged).To(HaveLen(1))
+	g.Expect(logged[0]).ToNot(ContainSubstring("s3cr3t"))
+}
@@ -474,13 +474,14 @@ func (x *CreateFileMappingsReply) GetMessage() string {
 }
 
 type UploadAndExtractRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	TarGz         []byte                 `protobuf:"bytes,3,opt,name=tarGz,proto3" json:"tarGz,omitempty"`
-	Path          string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Namespace           string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TarGz               []byte                 `protobuf:"bytes,3,opt,name=tarGz,proto3" json:"tarGz,omitempty"`
+	Path                string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	AdditionalArtifacts []*AdditionalArtifact  `protobuf:"bytes,5,rep,name=additionalArtifacts,proto3" json:"additionalArtifacts,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *UploadAndExtractRequest) Reset() {
@@ -541,6 +542,65 @@ func (x *UploadAndExtractRequest) GetPath() string {
 	return ""
 }
 
+func (x *UploadAndExtractRequest) GetAdditionalArtifacts() []*AdditionalArtifact {
+	if x != nil {
+		return x.AdditionalArtifacts
+	}
+	return nil
+}
+
+type AdditionalArtifact struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TarGz         []byte                 `protobuf:"bytes,1,opt,name=tarGz,proto3" json:"tarGz,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdditionalArtifact) Reset() {
+	*x = AdditionalArtifact{}
+	mi := &file_runner_runner_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdditionalArtifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdditionalArtifact) ProtoMessage() {}
+
+func (x *AdditionalArtifact) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdditionalArtifact.ProtoReflect.Descriptor instead.
+func (*AdditionalArtifact) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AdditionalArtifact) GetTarGz() []byte {
+	if x != nil {
+		return x.TarGz
+	}
+	return nil
+}
+
+func (x *AdditionalArtifact) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
 type UploadAndExtractReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WorkingDir    string                 `protobuf:"bytes,1,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
@@ -551,7 +611,7 @@ type UploadAndExtractReply struct {
 
 func (x *UploadAndExtractReply) Reset() {
 	*x = UploadAndExtractReply{}
-	mi := &file_runner_runner_proto_msgTypes[10]
+	mi := &file_runner_runner_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -563,7 +623,7 @@ func (x *UploadAndExtractReply) String() string {
 func (*UploadAndExtractReply) ProtoMessage() {}
 
 func (x *UploadAndExtractReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[10]
+	mi := &file_runner_runner_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -576,7 +636,7 @@ func (x *UploadAndExtractReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadAndExtractReply.ProtoReflect.Descriptor instead.
 func (*UploadAndExtractReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{10}
+	return file_runner_runner_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UploadAndExtractReply) GetWorkingDir() string {
@@ -602,7 +662,7 @@ type CleanupDirRequest struct {
 
 func (x *CleanupDirRequest) Reset() {
 	*x = CleanupDirRequest{}
-	mi := &file_runner_runner_proto_msgTypes[11]
+	mi := &file_runner_runner_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -614,7 +674,7 @@ func (x *CleanupDirRequest) String() string {
 func (*CleanupDirRequest) ProtoMessage() {}
 
 func (x *CleanupDirRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[11]
+	mi := &file_runner_runner_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -627,7 +687,7 @@ func (x *CleanupDirRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CleanupDirRequest.ProtoReflect.Descriptor instead.
 func (*CleanupDirRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{11}
+	return file_runner_runner_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CleanupDirRequest) GetTmpDir() string {
@@ -646,7 +706,7 @@ type CleanupDirReply struct {
 
 func (x *CleanupDirReply) Reset() {
 	*x = CleanupDirReply{}
-	mi := &file_runner_runner_proto_msgTypes[12]
+	mi := &file_runner_runner_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -658,7 +718,7 @@ func (x *CleanupDirReply) String() string {
 func (*CleanupDirReply) ProtoMessage() {}
 
 func (x *CleanupDirReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[12]
+	mi := &file_runner_runner_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -671,7 +731,7 @@ func (x *CleanupDirReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CleanupDirReply.ProtoReflect.Descriptor instead.
 func (*CleanupDirReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{12}
+	return file_runner_runner_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CleanupDirReply) GetMessage() string {
@@ -691,7 +751,7 @@ type WriteBackendConfigRequest struct {
 
 func (x *WriteBackendConfigRequest) Reset() {
 	*x = WriteBackendConfigRequest{}
-	mi := &file_runner_runner_proto_msgTypes[13]
+	mi := &file_runner_runner_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -703,7 +763,7 @@ func (x *WriteBackendConfigRequest) String() string {
 func (*WriteBackendConfigRequest) ProtoMessage() {}
 
 func (x *WriteBackendConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[13]
+	mi := &file_runner_runner_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -716,7 +776,7 @@ func (x *WriteBackendConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteBackendConfigRequest.ProtoReflect.Descriptor instead.
 func (*WriteBackendConfigRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{13}
+	return file_runner_runner_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *WriteBackendConfigRequest) GetDirPath() string {
@@ -742,7 +802,7 @@ type WriteBackendConfigReply struct {
 
 func (x *WriteBackendConfigReply) Reset() {
 	*x = WriteBackendConfigReply{}
-	mi := &file_runner_runner_proto_msgTypes[14]
+	mi := &file_runner_runner_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -754,7 +814,7 @@ func (x *WriteBackendConfigReply) String() string {
 func (*WriteBackendConfigReply) ProtoMessage() {}
 
 func (x *WriteBackendConfigReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[14]
+	mi := &file_runner_runner_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -767,7 +827,7 @@ func (x *WriteBackendConfigReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteBackendConfigReply.ProtoReflect.Descriptor instead.
 func (*WriteBackendConfigReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{14}
+	return file_runner_runner_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *WriteBackendConfigReply) GetMessage() string {
@@ -788,7 +848,7 @@ type ProcessCliConfigRequest struct {
 
 func (x *ProcessCliConfigRequest) Reset() {
 	*x = ProcessCliConfigRequest{}
-	mi := &file_runner_runner_proto_msgTypes[15]
+	mi := &file_runner_runner_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -800,7 +860,7 @@ func (x *ProcessCliConfigRequest) String() string {
 func (*ProcessCliConfigRequest) ProtoMessage() {}
 
 func (x *ProcessCliConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[15]
+	mi := &file_runner_runner_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -813,7 +873,7 @@ func (x *ProcessCliConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessCliConfigRequest.ProtoReflect.Descriptor instead.
 func (*ProcessCliConfigRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{15}
+	return file_runner_runner_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ProcessCliConfigRequest) GetDirPath() string {
@@ -846,7 +906,7 @@ type ProcessCliConfigReply struct {
 
 func (x *ProcessCliConfigReply) Reset() {
 	*x = ProcessCliConfigReply{}
-	mi := &file_runner_runner_proto_msgTypes[16]
+	mi := &file_runner_runner_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -858,7 +918,7 @@ func (x *ProcessCliConfigReply) String() string {
 func (*ProcessCliConfigReply) ProtoMessage() {}
 
 func (x *ProcessCliConfigReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[16]
+	mi := &file_runner_runner_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -871,7 +931,7 @@ func (x *ProcessCliConfigReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessCliConfigReply.ProtoReflect.Descriptor instead.
 func (*ProcessCliConfigReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{16}
+	return file_runner_runner_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ProcessCliConfigReply) GetFilePath() string {
@@ -882,15 +942,16 @@ func (x *ProcessCliConfigReply) GetFilePath() string {
 }
 
 type GenerateVarsForTFRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	WorkingDir    string                 `protobuf:"bytes,1,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState       `protogen:"open.v1"`
+	WorkingDir       string                       `protobuf:"bytes,1,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
+	TerraformOutputs map[string]*TerraformOutputs `protobuf:"bytes,2,rep,name=terraformOutputs,proto3" json:"terraformOutputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *GenerateVarsForTFRequest) Reset() {
 	*x = GenerateVarsForTFRequest{}
-	mi := &file_runner_runner_proto_msgTypes[17]
+	mi := &file_runner_runner_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -902,7 +963,7 @@ func (x *GenerateVarsForTFRequest) String() string {
 func (*GenerateVarsForTFRequest) ProtoMessage() {}
 
 func (x *GenerateVarsForTFRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[17]
+	mi := &file_runner_runner_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -915,7 +976,7 @@ func (x *GenerateVarsForTFRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateVarsForTFRequest.ProtoReflect.Descriptor instead.
 func (*GenerateVarsForTFRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{17}
+	return file_runner_runner_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GenerateVarsForTFRequest) GetWorkingDir() string {
@@ -925,6 +986,57 @@ func (x *GenerateVarsForTFRequest) GetWorkingDir() string {
 	return ""
 }
 
+func (x *GenerateVarsForTFRequest) GetTerraformOutputs() map[string]*TerraformOutputs {
+	if x != nil {
+		return x.TerraformOutputs
+	}
+	return nil
+}
+
+type TerraformOutputs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Outputs       map[string][]byte      `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TerraformOutputs) Reset() {
+	*x = TerraformOutputs{}
+	mi := &file_runner_runner_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerraformOutputs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerraformOutputs) ProtoMessage() {}
+
+func (x *TerraformOutputs) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerraformOutputs.ProtoReflect.Descriptor instead.
+func (*TerraformOutputs) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TerraformOutputs) GetOutputs() map[string][]byte {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
 type GenerateVarsForTFReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -934,7 +1046,7 @@ type GenerateVarsForTFReply struct {
 
 func (x *GenerateVarsForTFReply) Reset() {
 	*x = GenerateVarsForTFReply{}
-	mi := &file_runner_runner_proto_msgTypes[18]
+	mi := &file_runner_runner_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -946,7 +1058,7 @@ func (x *GenerateVarsForTFReply) String() string {
 func (*GenerateVarsForTFReply) ProtoMessage() {}
 
 func (x *GenerateVarsForTFReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[18]
+	mi := &file_runner_runner_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -959,7 +1071,7 @@ func (x *GenerateVarsForTFReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateVarsForTFReply.ProtoReflect.Descriptor instead.
 func (*GenerateVarsForTFReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{18}
+	return file_runner_runner_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *GenerateVarsForTFReply) GetMessage() string {
@@ -978,7 +1090,7 @@ type GenerateTemplateRequest struct {
 
 func (x *GenerateTemplateRequest) Reset() {
 	*x = GenerateTemplateRequest{}
-	mi := &file_runner_runner_proto_msgTypes[19]
+	mi := &file_runner_runner_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -990,7 +1102,7 @@ func (x *GenerateTemplateRequest) String() string {
 func (*GenerateTemplateRequest) ProtoMessage() {}
 
 func (x *GenerateTemplateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[19]
+	mi := &file_runner_runner_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1003,7 +1115,7 @@ func (x *GenerateTemplateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateTemplateRequest.ProtoReflect.Descriptor instead.
 func (*GenerateTemplateRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{19}
+	return file_runner_runner_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GenerateTemplateRequest) GetWorkingDir() string {
@@ -1022,7 +1134,7 @@ type GenerateTemplateReply struct {
 
 func (x *GenerateTemplateReply) Reset() {
 	*x = GenerateTemplateReply{}
-	mi := &file_runner_runner_proto_msgTypes[20]
+	mi := &file_runner_runner_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1034,7 +1146,7 @@ func (x *GenerateTemplateReply) String() string {
 func (*GenerateTemplateReply) ProtoMessage() {}
 
 func (x *GenerateTemplateReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[20]
+	mi := &file_runner_runner_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1047,7 +1159,7 @@ func (x *GenerateTemplateReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateTemplateReply.ProtoReflect.Descriptor instead.
 func (*GenerateTemplateReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{20}
+	return file_runner_runner_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GenerateTemplateReply) GetMessage() string {
@@ -1057,34 +1169,28 @@ func (x *GenerateTemplateReply) GetMessage() string {
 	return ""
 }
 
-type PlanRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	TfInstance       string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	Out              string                 `protobuf:"bytes,2,opt,name=out,proto3" json:"out,omitempty"`
-	Refresh          bool                   `protobuf:"varint,3,opt,name=refresh,proto3" json:"refresh,omitempty"`
-	Destroy          bool                   `protobuf:"varint,4,opt,name=destroy,proto3" json:"destroy,omitempty"`
-	Targets          []string               `protobuf:"bytes,5,rep,name=targets,proto3" json:"targets,omitempty"`
-	LockTimeout      string                 `protobuf:"bytes,6,opt,name=lockTimeout,proto3" json:"lockTimeout,omitempty"`
-	SourceRefRootDir string                 `protobuf:"bytes,7,opt,name=sourceRefRootDir,proto3" json:"sourceRefRootDir,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type GenerateImportsAndMovesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkingDir    string                 `protobuf:"bytes,1,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlanRequest) Reset() {
-	*x = PlanRequest{}
-	mi := &file_runner_runner_proto_msgTypes[21]
+func (x *GenerateImportsAndMovesRequest) Reset() {
+	*x = GenerateImportsAndMovesRequest{}
+	mi := &file_runner_runner_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlanRequest) String() string {
+func (x *GenerateImportsAndMovesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlanRequest) ProtoMessage() {}
+func (*GenerateImportsAndMovesRequest) ProtoMessage() {}
 
-func (x *PlanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[21]
+func (x *GenerateImportsAndMovesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1095,85 +1201,93 @@ func (x *PlanRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlanRequest.ProtoReflect.Descriptor instead.
-func (*PlanRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use GenerateImportsAndMovesRequest.ProtoReflect.Descriptor instead.
+func (*GenerateImportsAndMovesRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *PlanRequest) GetTfInstance() string {
+func (x *GenerateImportsAndMovesRequest) GetWorkingDir() string {
 	if x != nil {
-		return x.TfInstance
+		return x.WorkingDir
 	}
 	return ""
 }
 
-func (x *PlanRequest) GetOut() string {
-	if x != nil {
-		return x.Out
-	}
-	return ""
+type GenerateImportsAndMovesReply struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Message         string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ImportAddresses []string               `protobuf:"bytes,2,rep,name=importAddresses,proto3" json:"importAddresses,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *PlanRequest) GetRefresh() bool {
-	if x != nil {
-		return x.Refresh
-	}
-	return false
+func (x *GenerateImportsAndMovesReply) Reset() {
+	*x = GenerateImportsAndMovesReply{}
+	mi := &file_runner_runner_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlanRequest) GetDestroy() bool {
-	if x != nil {
-		return x.Destroy
-	}
-	return false
+func (x *GenerateImportsAndMovesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *PlanRequest) GetTargets() []string {
+func (*GenerateImportsAndMovesReply) ProtoMessage() {}
+
+func (x *GenerateImportsAndMovesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[24]
 	if x != nil {
-		return x.Targets
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *PlanRequest) GetLockTimeout() string {
+// Deprecated: Use GenerateImportsAndMovesReply.ProtoReflect.Descriptor instead.
+func (*GenerateImportsAndMovesReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GenerateImportsAndMovesReply) GetMessage() string {
 	if x != nil {
-		return x.LockTimeout
+		return x.Message
 	}
 	return ""
 }
 
-func (x *PlanRequest) GetSourceRefRootDir() string {
+func (x *GenerateImportsAndMovesReply) GetImportAddresses() []string {
 	if x != nil {
-		return x.SourceRefRootDir
+		return x.ImportAddresses
 	}
-	return ""
+	return nil
 }
 
-type PlanReply struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	Drifted             bool                   `protobuf:"varint,1,opt,name=drifted,proto3" json:"drifted,omitempty"`
-	Message             string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	StateLockIdentifier string                 `protobuf:"bytes,3,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
-	PlanCreated         bool                   `protobuf:"varint,4,opt,name=planCreated,proto3" json:"planCreated,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+type ValidateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	CheckFormat   bool                   `protobuf:"varint,2,opt,name=checkFormat,proto3" json:"checkFormat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlanReply) Reset() {
-	*x = PlanReply{}
-	mi := &file_runner_runner_proto_msgTypes[22]
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	mi := &file_runner_runner_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlanReply) String() string {
+func (x *ValidateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlanReply) ProtoMessage() {}
+func (*ValidateRequest) ProtoMessage() {}
 
-func (x *PlanReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[22]
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1184,62 +1298,50 @@ func (x *PlanReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlanReply.ProtoReflect.Descriptor instead.
-func (*PlanReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *PlanReply) GetDrifted() bool {
-	if x != nil {
-		return x.Drifted
-	}
-	return false
-}
-
-func (x *PlanReply) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *PlanReply) GetStateLockIdentifier() string {
+func (x *ValidateRequest) GetTfInstance() string {
 	if x != nil {
-		return x.StateLockIdentifier
+		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *PlanReply) GetPlanCreated() bool {
+func (x *ValidateRequest) GetCheckFormat() bool {
 	if x != nil {
-		return x.PlanCreated
+		return x.CheckFormat
 	}
 	return false
 }
 
-type ShowPlanFileRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ValidateReply struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Message          string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Valid            bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	Diagnostics      []string               `protobuf:"bytes,3,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	UnformattedFiles []string               `protobuf:"bytes,4,rep,name=unformattedFiles,proto3" json:"unformattedFiles,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *ShowPlanFileRequest) Reset() {
-	*x = ShowPlanFileRequest{}
-	mi := &file_runner_runner_proto_msgTypes[23]
+func (x *ValidateReply) Reset() {
+	*x = ValidateReply{}
+	mi := &file_runner_runner_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShowPlanFileRequest) String() string {
+func (x *ValidateReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowPlanFileRequest) ProtoMessage() {}
+func (*ValidateReply) ProtoMessage() {}
 
-func (x *ShowPlanFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[23]
+func (x *ValidateReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1250,47 +1352,61 @@ func (x *ShowPlanFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowPlanFileRequest.ProtoReflect.Descriptor instead.
-func (*ShowPlanFileRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use ValidateReply.ProtoReflect.Descriptor instead.
+func (*ValidateReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *ShowPlanFileRequest) GetTfInstance() string {
+func (x *ValidateReply) GetMessage() string {
 	if x != nil {
-		return x.TfInstance
+		return x.Message
 	}
 	return ""
 }
 
-func (x *ShowPlanFileRequest) GetFilename() string {
+func (x *ValidateReply) GetValid() bool {
 	if x != nil {
-		return x.Filename
+		return x.Valid
 	}
-	return ""
+	return false
 }
 
-type ShowPlanFileReply struct {
+func (x *ValidateReply) GetDiagnostics() []string {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
+
+func (x *ValidateReply) GetUnformattedFiles() []string {
+	if x != nil {
+		return x.UnformattedFiles
+	}
+	return nil
+}
+
+type TestRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	JsonOutput    []byte                 `protobuf:"bytes,1,opt,name=jsonOutput,proto3" json:"jsonOutput,omitempty"`
+	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShowPlanFileReply) Reset() {
-	*x = ShowPlanFileReply{}
-	mi := &file_runner_runner_proto_msgTypes[24]
+func (x *TestRequest) Reset() {
+	*x = TestRequest{}
+	mi := &file_runner_runner_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShowPlanFileReply) String() string {
+func (x *TestRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowPlanFileReply) ProtoMessage() {}
+func (*TestRequest) ProtoMessage() {}
 
-func (x *ShowPlanFileReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[24]
+func (x *TestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1301,41 +1417,46 @@ func (x *ShowPlanFileReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowPlanFileReply.ProtoReflect.Descriptor instead.
-func (*ShowPlanFileReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use TestRequest.ProtoReflect.Descriptor instead.
+func (*TestRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *ShowPlanFileReply) GetJsonOutput() []byte {
+func (x *TestRequest) GetTfInstance() string {
 	if x != nil {
-		return x.JsonOutput
+		return x.TfInstance
 	}
-	return nil
+	return ""
 }
 
-type ShowPlanFileRawRequest struct {
+type TestReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Passed        bool                   `protobuf:"varint,2,opt,name=passed,proto3" json:"passed,omitempty"`
+	PassedCount   int32                  `protobuf:"varint,3,opt,name=passedCount,proto3" json:"passedCount,omitempty"`
+	FailedCount   int32                  `protobuf:"varint,4,opt,name=failedCount,proto3" json:"failedCount,omitempty"`
+	ErroredCount  int32                  `protobuf:"varint,5,opt,name=erroredCount,proto3" json:"erroredCount,omitempty"`
+	SkippedCount  int32                  `protobuf:"varint,6,opt,name=skippedCount,proto3" json:"skippedCount,omitempty"`
+	Failures      []string               `protobuf:"bytes,7,rep,name=failures,proto3" json:"failures,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShowPlanFileRawRequest) Reset() {
-	*x = ShowPlanFileRawRequest{}
-	mi := &file_runner_runner_proto_msgTypes[25]
+func (x *TestReply) Reset() {
+	*x = TestReply{}
+	mi := &file_runner_runner_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShowPlanFileRawRequest) String() string {
+func (x *TestReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowPlanFileRawRequest) ProtoMessage() {}
+func (*TestReply) ProtoMessage() {}
 
-func (x *ShowPlanFileRawRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[25]
+func (x *TestReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1346,47 +1467,83 @@ func (x *ShowPlanFileRawRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowPlanFileRawRequest.ProtoReflect.Descriptor instead.
-func (*ShowPlanFileRawRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use TestReply.ProtoReflect.Descriptor instead.
+func (*TestReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *ShowPlanFileRawRequest) GetTfInstance() string {
+func (x *TestReply) GetMessage() string {
 	if x != nil {
-		return x.TfInstance
+		return x.Message
 	}
 	return ""
 }
 
-func (x *ShowPlanFileRawRequest) GetFilename() string {
+func (x *TestReply) GetPassed() bool {
 	if x != nil {
-		return x.Filename
+		return x.Passed
 	}
-	return ""
+	return false
 }
 
-type ShowPlanFileRawReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RawOutput     string                 `protobuf:"bytes,1,opt,name=rawOutput,proto3" json:"rawOutput,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *TestReply) GetPassedCount() int32 {
+	if x != nil {
+		return x.PassedCount
+	}
+	return 0
 }
 
-func (x *ShowPlanFileRawReply) Reset() {
-	*x = ShowPlanFileRawReply{}
-	mi := &file_runner_runner_proto_msgTypes[26]
+func (x *TestReply) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *TestReply) GetErroredCount() int32 {
+	if x != nil {
+		return x.ErroredCount
+	}
+	return 0
+}
+
+func (x *TestReply) GetSkippedCount() int32 {
+	if x != nil {
+		return x.SkippedCount
+	}
+	return 0
+}
+
+func (x *TestReply) GetFailures() []string {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type CheckVariablesRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TfInstance       string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	SourceRefRootDir string                 `protobuf:"bytes,2,opt,name=sourceRefRootDir,proto3" json:"sourceRefRootDir,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CheckVariablesRequest) Reset() {
+	*x = CheckVariablesRequest{}
+	mi := &file_runner_runner_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShowPlanFileRawReply) String() string {
+func (x *CheckVariablesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowPlanFileRawReply) ProtoMessage() {}
+func (*CheckVariablesRequest) ProtoMessage() {}
 
-func (x *ShowPlanFileRawReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[26]
+func (x *CheckVariablesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1397,45 +1554,51 @@ func (x *ShowPlanFileRawReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowPlanFileRawReply.ProtoReflect.Descriptor instead.
-func (*ShowPlanFileRawReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use CheckVariablesRequest.ProtoReflect.Descriptor instead.
+func (*CheckVariablesRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *ShowPlanFileRawReply) GetRawOutput() string {
+func (x *CheckVariablesRequest) GetTfInstance() string {
 	if x != nil {
-		return x.RawOutput
+		return x.TfInstance
 	}
 	return ""
 }
 
-type SaveTFPlanRequest struct {
-	state                    protoimpl.MessageState `protogen:"open.v1"`
-	TfInstance               string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	BackendCompletelyDisable bool                   `protobuf:"varint,2,opt,name=backendCompletelyDisable,proto3" json:"backendCompletelyDisable,omitempty"`
-	Name                     string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace                string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Uuid                     string                 `protobuf:"bytes,5,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Revision                 string                 `protobuf:"bytes,6,opt,name=revision,proto3" json:"revision,omitempty"`
-	unknownFields            protoimpl.UnknownFields
-	sizeCache                protoimpl.SizeCache
+func (x *CheckVariablesRequest) GetSourceRefRootDir() string {
+	if x != nil {
+		return x.SourceRefRootDir
+	}
+	return ""
 }
 
-func (x *SaveTFPlanRequest) Reset() {
-	*x = SaveTFPlanRequest{}
-	mi := &file_runner_runner_proto_msgTypes[27]
+type CheckVariablesReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Variables     []*VariableSchema      `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty"`
+	Missing       []string               `protobuf:"bytes,3,rep,name=missing,proto3" json:"missing,omitempty"`
+	Invalid       []string               `protobuf:"bytes,4,rep,name=invalid,proto3" json:"invalid,omitempty"`
+	Unknown       []string               `protobuf:"bytes,5,rep,name=unknown,proto3" json:"unknown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckVariablesReply) Reset() {
+	*x = CheckVariablesReply{}
+	mi := &file_runner_runner_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SaveTFPlanRequest) String() string {
+func (x *CheckVariablesReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveTFPlanRequest) ProtoMessage() {}
+func (*CheckVariablesReply) ProtoMessage() {}
 
-func (x *SaveTFPlanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[27]
+func (x *CheckVariablesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1446,75 +1609,74 @@ func (x *SaveTFPlanRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveTFPlanRequest.ProtoReflect.Descriptor instead.
-func (*SaveTFPlanRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use CheckVariablesReply.ProtoReflect.Descriptor instead.
+func (*CheckVariablesReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *SaveTFPlanRequest) GetTfInstance() string {
+func (x *CheckVariablesReply) GetMessage() string {
 	if x != nil {
-		return x.TfInstance
+		return x.Message
 	}
 	return ""
 }
 
-func (x *SaveTFPlanRequest) GetBackendCompletelyDisable() bool {
-	if x != nil {
-		return x.BackendCompletelyDisable
-	}
-	return false
-}
-
-func (x *SaveTFPlanRequest) GetName() string {
+func (x *CheckVariablesReply) GetVariables() []*VariableSchema {
 	if x != nil {
-		return x.Name
+		return x.Variables
 	}
-	return ""
+	return nil
 }
 
-func (x *SaveTFPlanRequest) GetNamespace() string {
+func (x *CheckVariablesReply) GetMissing() []string {
 	if x != nil {
-		return x.Namespace
+		return x.Missing
 	}
-	return ""
+	return nil
 }
 
-func (x *SaveTFPlanRequest) GetUuid() string {
+func (x *CheckVariablesReply) GetInvalid() []string {
 	if x != nil {
-		return x.Uuid
+		return x.Invalid
 	}
-	return ""
+	return nil
 }
 
-func (x *SaveTFPlanRequest) GetRevision() string {
+func (x *CheckVariablesReply) GetUnknown() []string {
 	if x != nil {
-		return x.Revision
+		return x.Unknown
 	}
-	return ""
+	return nil
 }
 
-type SaveTFPlanReply struct {
+type VariableSchema struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Default       []byte                 `protobuf:"bytes,4,opt,name=default,proto3" json:"default,omitempty"`
+	Required      bool                   `protobuf:"varint,5,opt,name=required,proto3" json:"required,omitempty"`
+	Sensitive     bool                   `protobuf:"varint,6,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+	Validations   int32                  `protobuf:"varint,7,opt,name=validations,proto3" json:"validations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SaveTFPlanReply) Reset() {
-	*x = SaveTFPlanReply{}
-	mi := &file_runner_runner_proto_msgTypes[28]
+func (x *VariableSchema) Reset() {
+	*x = VariableSchema{}
+	mi := &file_runner_runner_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SaveTFPlanReply) String() string {
+func (x *VariableSchema) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveTFPlanReply) ProtoMessage() {}
+func (*VariableSchema) ProtoMessage() {}
 
-func (x *SaveTFPlanReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[28]
+func (x *VariableSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1525,44 +1687,88 @@ func (x *SaveTFPlanReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveTFPlanReply.ProtoReflect.Descriptor instead.
-func (*SaveTFPlanReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use VariableSchema.ProtoReflect.Descriptor instead.
+func (*VariableSchema) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *SaveTFPlanReply) GetMessage() string {
+func (x *VariableSchema) GetName() string {
 	if x != nil {
-		return x.Message
+		return x.Name
 	}
 	return ""
 }
 
-type LoadTFPlanRequest struct {
-	state                    protoimpl.MessageState `protogen:"open.v1"`
-	TfInstance               string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	BackendCompletelyDisable bool                   `protobuf:"varint,2,opt,name=backendCompletelyDisable,proto3" json:"backendCompletelyDisable,omitempty"`
-	Name                     string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace                string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	PendingPlan              string                 `protobuf:"bytes,5,opt,name=pendingPlan,proto3" json:"pendingPlan,omitempty"`
-	unknownFields            protoimpl.UnknownFields
-	sizeCache                protoimpl.SizeCache
+func (x *VariableSchema) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
 }
 
-func (x *LoadTFPlanRequest) Reset() {
-	*x = LoadTFPlanRequest{}
-	mi := &file_runner_runner_proto_msgTypes[29]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *VariableSchema) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
 }
 
-func (x *LoadTFPlanRequest) String() string {
+func (x *VariableSchema) GetDefault() []byte {
+	if x != nil {
+		return x.Default
+	}
+	return nil
+}
+
+func (x *VariableSchema) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *VariableSchema) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
+}
+
+func (x *VariableSchema) GetValidations() int32 {
+	if x != nil {
+		return x.Validations
+	}
+	return 0
+}
+
+type PlanRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TfInstance       string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Out              string                 `protobuf:"bytes,2,opt,name=out,proto3" json:"out,omitempty"`
+	Refresh          bool                   `protobuf:"varint,3,opt,name=refresh,proto3" json:"refresh,omitempty"`
+	Destroy          bool                   `protobuf:"varint,4,opt,name=destroy,proto3" json:"destroy,omitempty"`
+	Targets          []string               `protobuf:"bytes,5,rep,name=targets,proto3" json:"targets,omitempty"`
+	LockTimeout      string                 `protobuf:"bytes,6,opt,name=lockTimeout,proto3" json:"lockTimeout,omitempty"`
+	SourceRefRootDir string                 `protobuf:"bytes,7,opt,name=sourceRefRootDir,proto3" json:"sourceRefRootDir,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PlanRequest) Reset() {
+	*x = PlanRequest{}
+	mi := &file_runner_runner_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoadTFPlanRequest) ProtoMessage() {}
+func (*PlanRequest) ProtoMessage() {}
 
-func (x *LoadTFPlanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[29]
+func (x *PlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1573,68 +1779,151 @@ func (x *LoadTFPlanRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoadTFPlanRequest.ProtoReflect.Descriptor instead.
-func (*LoadTFPlanRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use PlanRequest.ProtoReflect.Descriptor instead.
+func (*PlanRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *LoadTFPlanRequest) GetTfInstance() string {
+func (x *PlanRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *LoadTFPlanRequest) GetBackendCompletelyDisable() bool {
+func (x *PlanRequest) GetOut() string {
 	if x != nil {
-		return x.BackendCompletelyDisable
+		return x.Out
+	}
+	return ""
+}
+
+func (x *PlanRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
 	}
 	return false
 }
 
-func (x *LoadTFPlanRequest) GetName() string {
+func (x *PlanRequest) GetDestroy() bool {
 	if x != nil {
-		return x.Name
+		return x.Destroy
+	}
+	return false
+}
+
+func (x *PlanRequest) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *PlanRequest) GetLockTimeout() string {
+	if x != nil {
+		return x.LockTimeout
 	}
 	return ""
 }
 
-func (x *LoadTFPlanRequest) GetNamespace() string {
+func (x *PlanRequest) GetSourceRefRootDir() string {
 	if x != nil {
-		return x.Namespace
+		return x.SourceRefRootDir
 	}
 	return ""
 }
 
-func (x *LoadTFPlanRequest) GetPendingPlan() string {
+type PlanReply struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Drifted             bool                   `protobuf:"varint,1,opt,name=drifted,proto3" json:"drifted,omitempty"`
+	Message             string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier string                 `protobuf:"bytes,3,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	PlanCreated         bool                   `protobuf:"varint,4,opt,name=planCreated,proto3" json:"planCreated,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PlanReply) Reset() {
+	*x = PlanReply{}
+	mi := &file_runner_runner_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanReply) ProtoMessage() {}
+
+func (x *PlanReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[33]
 	if x != nil {
-		return x.PendingPlan
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanReply.ProtoReflect.Descriptor instead.
+func (*PlanReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PlanReply) GetDrifted() bool {
+	if x != nil {
+		return x.Drifted
+	}
+	return false
+}
+
+func (x *PlanReply) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-type LoadTFPlanReply struct {
+func (x *PlanReply) GetStateLockIdentifier() string {
+	if x != nil {
+		return x.StateLockIdentifier
+	}
+	return ""
+}
+
+func (x *PlanReply) GetPlanCreated() bool {
+	if x != nil {
+		return x.PlanCreated
+	}
+	return false
+}
+
+type ShowPlanFileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoadTFPlanReply) Reset() {
-	*x = LoadTFPlanReply{}
-	mi := &file_runner_runner_proto_msgTypes[30]
+func (x *ShowPlanFileRequest) Reset() {
+	*x = ShowPlanFileRequest{}
+	mi := &file_runner_runner_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoadTFPlanReply) String() string {
+func (x *ShowPlanFileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoadTFPlanReply) ProtoMessage() {}
+func (*ShowPlanFileRequest) ProtoMessage() {}
 
-func (x *LoadTFPlanReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[30]
+func (x *ShowPlanFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1645,44 +1934,1496 @@ func (x *LoadTFPlanReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoadTFPlanReply.ProtoReflect.Descriptor instead.
-func (*LoadTFPlanReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use ShowPlanFileRequest.ProtoReflect.Descriptor instead.
+func (*ShowPlanFileRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ShowPlanFileRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+func (x *ShowPlanFileRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+type ShowPlanFileReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JsonOutput    []byte                 `protobuf:"bytes,1,opt,name=jsonOutput,proto3" json:"jsonOutput,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShowPlanFileReply) Reset() {
+	*x = ShowPlanFileReply{}
+	mi := &file_runner_runner_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShowPlanFileReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShowPlanFileReply) ProtoMessage() {}
+
+func (x *ShowPlanFileReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShowPlanFileReply.ProtoReflect.Descriptor instead.
+func (*ShowPlanFileReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ShowPlanFileReply) GetJsonOutput() []byte {
+	if x != nil {
+		return x.JsonOutput
+	}
+	return nil
+}
+
+type ShowPlanFileRawRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShowPlanFileRawRequest) Reset() {
+	*x = ShowPlanFileRawRequest{}
+	mi := &file_runner_runner_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShowPlanFileRawRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShowPlanFileRawRequest) ProtoMessage() {}
+
+func (x *ShowPlanFileRawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShowPlanFileRawRequest.ProtoReflect.Descriptor instead.
+func (*ShowPlanFileRawRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ShowPlanFileRawRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+func (x *ShowPlanFileRawRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+type ShowPlanFileRawReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RawOutput     string                 `protobuf:"bytes,1,opt,name=rawOutput,proto3" json:"rawOutput,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShowPlanFileRawReply) Reset() {
+	*x = ShowPlanFileRawReply{}
+	mi := &file_runner_runner_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShowPlanFileRawReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShowPlanFileRawReply) ProtoMessage() {}
+
+func (x *ShowPlanFileRawReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShowPlanFileRawReply.ProtoReflect.Descriptor instead.
+func (*ShowPlanFileRawReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ShowPlanFileRawReply) GetRawOutput() string {
+	if x != nil {
+		return x.RawOutput
+	}
+	return ""
+}
+
+type SaveTFPlanRequest struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	TfInstance               string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	BackendCompletelyDisable bool                   `protobuf:"varint,2,opt,name=backendCompletelyDisable,proto3" json:"backendCompletelyDisable,omitempty"`
+	Name                     string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace                string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Uuid                     string                 `protobuf:"bytes,5,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Revision                 string                 `protobuf:"bytes,6,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *SaveTFPlanRequest) Reset() {
+	*x = SaveTFPlanRequest{}
+	mi := &file_runner_runner_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveTFPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveTFPlanRequest) ProtoMessage() {}
+
+func (x *SaveTFPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveTFPlanRequest.ProtoReflect.Descriptor instead.
+func (*SaveTFPlanRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SaveTFPlanRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+func (x *SaveTFPlanRequest) GetBackendCompletelyDisable() bool {
+	if x != nil {
+		return x.BackendCompletelyDisable
+	}
+	return false
+}
+
+func (x *SaveTFPlanRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SaveTFPlanRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SaveTFPlanRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *SaveTFPlanRequest) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+type SaveTFPlanReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveTFPlanReply) Reset() {
+	*x = SaveTFPlanReply{}
+	mi := &file_runner_runner_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveTFPlanReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveTFPlanReply) ProtoMessage() {}
+
+func (x *SaveTFPlanReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveTFPlanReply.ProtoReflect.Descriptor instead.
+func (*SaveTFPlanReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SaveTFPlanReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type LoadTFPlanRequest struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	TfInstance               string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	BackendCompletelyDisable bool                   `protobuf:"varint,2,opt,name=backendCompletelyDisable,proto3" json:"backendCompletelyDisable,omitempty"`
+	Name                     string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace                string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	PendingPlan              string                 `protobuf:"bytes,5,opt,name=pendingPlan,proto3" json:"pendingPlan,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *LoadTFPlanRequest) Reset() {
+	*x = LoadTFPlanRequest{}
+	mi := &file_runner_runner_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadTFPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadTFPlanRequest) ProtoMessage() {}
+
+func (x *LoadTFPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadTFPlanRequest.ProtoReflect.Descriptor instead.
+func (*LoadTFPlanRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *LoadTFPlanRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+func (x *LoadTFPlanRequest) GetBackendCompletelyDisable() bool {
+	if x != nil {
+		return x.BackendCompletelyDisable
+	}
+	return false
+}
+
+func (x *LoadTFPlanRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LoadTFPlanRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *LoadTFPlanRequest) GetPendingPlan() string {
+	if x != nil {
+		return x.PendingPlan
+	}
+	return ""
+}
+
+type LoadTFPlanReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadTFPlanReply) Reset() {
+	*x = LoadTFPlanReply{}
+	mi := &file_runner_runner_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadTFPlanReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadTFPlanReply) ProtoMessage() {}
+
+func (x *LoadTFPlanReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadTFPlanReply.ProtoReflect.Descriptor instead.
+func (*LoadTFPlanReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *LoadTFPlanReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ApplyRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TfInstance         string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	DirOrPlan          string                 `protobuf:"bytes,2,opt,name=dirOrPlan,proto3" json:"dirOrPlan,omitempty"`
+	RefreshBeforeApply bool                   `protobuf:"varint,3,opt,name=refreshBeforeApply,proto3" json:"refreshBeforeApply,omitempty"`
+	Targets            []string               `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
+	Parallelism        int32                  `protobuf:"varint,5,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ApplyRequest) Reset() {
+	*x = ApplyRequest{}
+	mi := &file_runner_runner_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyRequest) ProtoMessage() {}
+
+func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyRequest.ProtoReflect.Descriptor instead.
+func (*ApplyRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ApplyRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetDirOrPlan() string {
+	if x != nil {
+		return x.DirOrPlan
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetRefreshBeforeApply() bool {
+	if x != nil {
+		return x.RefreshBeforeApply
+	}
+	return false
+}
+
+func (x *ApplyRequest) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *ApplyRequest) GetParallelism() int32 {
+	if x != nil {
+		return x.Parallelism
+	}
+	return 0
+}
+
+type ApplyReply struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Message             string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier string                 `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ApplyReply) Reset() {
+	*x = ApplyReply{}
+	mi := &file_runner_runner_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyReply) ProtoMessage() {}
+
+func (x *ApplyReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyReply.ProtoReflect.Descriptor instead.
+func (*ApplyReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ApplyReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ApplyReply) GetStateLockIdentifier() string {
+	if x != nil {
+		return x.StateLockIdentifier
+	}
+	return ""
+}
+
+type GetInventoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInventoryRequest) Reset() {
+	*x = GetInventoryRequest{}
+	mi := &file_runner_runner_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInventoryRequest) ProtoMessage() {}
+
+func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInventoryRequest.ProtoReflect.Descriptor instead.
+func (*GetInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetInventoryRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+type GetInventoryReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inventories   []*Inventory           `protobuf:"bytes,1,rep,name=inventories,proto3" json:"inventories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInventoryReply) Reset() {
+	*x = GetInventoryReply{}
+	mi := &file_runner_runner_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInventoryReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInventoryReply) ProtoMessage() {}
+
+func (x *GetInventoryReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInventoryReply.ProtoReflect.Descriptor instead.
+func (*GetInventoryReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetInventoryReply) GetInventories() []*Inventory {
+	if x != nil {
+		return x.Inventories
+	}
+	return nil
+}
+
+type Inventory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Identifier    string                 `protobuf:"bytes,3,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Inventory) Reset() {
+	*x = Inventory{}
+	mi := &file_runner_runner_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Inventory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Inventory) ProtoMessage() {}
+
+func (x *Inventory) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Inventory.ProtoReflect.Descriptor instead.
+func (*Inventory) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Inventory) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Inventory) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Inventory) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+type DestroyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Targets       []string               `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DestroyRequest) Reset() {
+	*x = DestroyRequest{}
+	mi := &file_runner_runner_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DestroyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestroyRequest) ProtoMessage() {}
+
+func (x *DestroyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestroyRequest.ProtoReflect.Descriptor instead.
+func (*DestroyRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DestroyRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+func (x *DestroyRequest) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type DestroyReply struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Message             string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier string                 `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *DestroyReply) Reset() {
+	*x = DestroyReply{}
+	mi := &file_runner_runner_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DestroyReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestroyReply) ProtoMessage() {}
+
+func (x *DestroyReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestroyReply.ProtoReflect.Descriptor instead.
+func (*DestroyReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *DestroyReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DestroyReply) GetStateLockIdentifier() string {
+	if x != nil {
+		return x.StateLockIdentifier
+	}
+	return ""
+}
+
+type OutputRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutputRequest) Reset() {
+	*x = OutputRequest{}
+	mi := &file_runner_runner_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputRequest) ProtoMessage() {}
+
+func (x *OutputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputRequest.ProtoReflect.Descriptor instead.
+func (*OutputRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *OutputRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+type OutputReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Outputs       map[string]*OutputMeta `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutputReply) Reset() {
+	*x = OutputReply{}
+	mi := &file_runner_runner_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutputReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputReply) ProtoMessage() {}
+
+func (x *OutputReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputReply.ProtoReflect.Descriptor instead.
+func (*OutputReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *OutputReply) GetOutputs() map[string]*OutputMeta {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+type OutputMeta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sensitive     bool                   `protobuf:"varint,1,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+	Type          []byte                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Value         []byte                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutputMeta) Reset() {
+	*x = OutputMeta{}
+	mi := &file_runner_runner_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutputMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputMeta) ProtoMessage() {}
+
+func (x *OutputMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputMeta.ProtoReflect.Descriptor instead.
+func (*OutputMeta) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *OutputMeta) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
+}
+
+func (x *OutputMeta) GetType() []byte {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *OutputMeta) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type WriteOutputsRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Namespace            string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name                 string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	SecretName           string                 `protobuf:"bytes,3,opt,name=secretName,proto3" json:"secretName,omitempty"`
+	Uuid                 string                 `protobuf:"bytes,4,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Data                 map[string][]byte      `protobuf:"bytes,5,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Labels               map[string]string      `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Annotations          map[string]string      `protobuf:"bytes,7,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Kind                 string                 `protobuf:"bytes,8,opt,name=kind,proto3" json:"kind,omitempty"`
+	SecretType           string                 `protobuf:"bytes,9,opt,name=secretType,proto3" json:"secretType,omitempty"`
+	TargetNamespace      string                 `protobuf:"bytes,10,opt,name=targetNamespace,proto3" json:"targetNamespace,omitempty"`
+	KubeConfigSecretName string                 `protobuf:"bytes,11,opt,name=kubeConfigSecretName,proto3" json:"kubeConfigSecretName,omitempty"`
+	KubeConfigSecretKey  string                 `protobuf:"bytes,12,opt,name=kubeConfigSecretKey,proto3" json:"kubeConfigSecretKey,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *WriteOutputsRequest) Reset() {
+	*x = WriteOutputsRequest{}
+	mi := &file_runner_runner_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteOutputsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteOutputsRequest) ProtoMessage() {}
+
+func (x *WriteOutputsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteOutputsRequest.ProtoReflect.Descriptor instead.
+func (*WriteOutputsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *WriteOutputsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WriteOutputsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WriteOutputsRequest) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *WriteOutputsRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *WriteOutputsRequest) GetData() map[string][]byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *WriteOutputsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *WriteOutputsRequest) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *WriteOutputsRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *WriteOutputsRequest) GetSecretType() string {
+	if x != nil {
+		return x.SecretType
+	}
+	return ""
+}
+
+func (x *WriteOutputsRequest) GetTargetNamespace() string {
+	if x != nil {
+		return x.TargetNamespace
+	}
+	return ""
+}
+
+func (x *WriteOutputsRequest) GetKubeConfigSecretName() string {
+	if x != nil {
+		return x.KubeConfigSecretName
+	}
+	return ""
+}
+
+func (x *WriteOutputsRequest) GetKubeConfigSecretKey() string {
+	if x != nil {
+		return x.KubeConfigSecretKey
+	}
+	return ""
+}
+
+type DeleteOutputsRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Namespace            string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	SecretName           string                 `protobuf:"bytes,2,opt,name=secretName,proto3" json:"secretName,omitempty"`
+	Kind                 string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	TargetNamespace      string                 `protobuf:"bytes,4,opt,name=targetNamespace,proto3" json:"targetNamespace,omitempty"`
+	KubeConfigSecretName string                 `protobuf:"bytes,5,opt,name=kubeConfigSecretName,proto3" json:"kubeConfigSecretName,omitempty"`
+	KubeConfigSecretKey  string                 `protobuf:"bytes,6,opt,name=kubeConfigSecretKey,proto3" json:"kubeConfigSecretKey,omitempty"`
+	Name                 string                 `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *DeleteOutputsRequest) Reset() {
+	*x = DeleteOutputsRequest{}
+	mi := &file_runner_runner_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOutputsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOutputsRequest) ProtoMessage() {}
+
+func (x *DeleteOutputsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOutputsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOutputsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DeleteOutputsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DeleteOutputsRequest) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *DeleteOutputsRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DeleteOutputsRequest) GetTargetNamespace() string {
+	if x != nil {
+		return x.TargetNamespace
+	}
+	return ""
+}
+
+func (x *DeleteOutputsRequest) GetKubeConfigSecretName() string {
+	if x != nil {
+		return x.KubeConfigSecretName
+	}
+	return ""
+}
+
+func (x *DeleteOutputsRequest) GetKubeConfigSecretKey() string {
+	if x != nil {
+		return x.KubeConfigSecretKey
+	}
+	return ""
+}
+
+func (x *DeleteOutputsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteOutputsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOutputsReply) Reset() {
+	*x = DeleteOutputsReply{}
+	mi := &file_runner_runner_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOutputsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOutputsReply) ProtoMessage() {}
+
+func (x *DeleteOutputsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOutputsReply.ProtoReflect.Descriptor instead.
+func (*DeleteOutputsReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DeleteOutputsReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type WriteOutputsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Changed       bool                   `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteOutputsReply) Reset() {
+	*x = WriteOutputsReply{}
+	mi := &file_runner_runner_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteOutputsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteOutputsReply) ProtoMessage() {}
+
+func (x *WriteOutputsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteOutputsReply.ProtoReflect.Descriptor instead.
+func (*WriteOutputsReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *WriteOutputsReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WriteOutputsReply) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+type GetOutputsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	SecretName    string                 `protobuf:"bytes,2,opt,name=secretName,proto3" json:"secretName,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOutputsRequest) Reset() {
+	*x = GetOutputsRequest{}
+	mi := &file_runner_runner_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOutputsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOutputsRequest) ProtoMessage() {}
+
+func (x *GetOutputsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOutputsRequest.ProtoReflect.Descriptor instead.
+func (*GetOutputsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetOutputsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *GetOutputsRequest) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+type GetOutputsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Outputs       map[string]string      `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOutputsReply) Reset() {
+	*x = GetOutputsReply{}
+	mi := &file_runner_runner_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOutputsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOutputsReply) ProtoMessage() {}
+
+func (x *GetOutputsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOutputsReply.ProtoReflect.Descriptor instead.
+func (*GetOutputsReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetOutputsReply) GetOutputs() map[string]string {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+type InitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Upgrade       bool                   `protobuf:"varint,2,opt,name=upgrade,proto3" json:"upgrade,omitempty"`
+	ForceCopy     bool                   `protobuf:"varint,3,opt,name=forceCopy,proto3" json:"forceCopy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitRequest) Reset() {
+	*x = InitRequest{}
+	mi := &file_runner_runner_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitRequest) ProtoMessage() {}
+
+func (x *InitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitRequest.ProtoReflect.Descriptor instead.
+func (*InitRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *InitRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+func (x *InitRequest) GetUpgrade() bool {
+	if x != nil {
+		return x.Upgrade
+	}
+	return false
+}
+
+func (x *InitRequest) GetForceCopy() bool {
+	if x != nil {
+		return x.ForceCopy
+	}
+	return false
+}
+
+type InitReply struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Message             string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier string                 `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *InitReply) Reset() {
+	*x = InitReply{}
+	mi := &file_runner_runner_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitReply) ProtoMessage() {}
+
+func (x *InitReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitReply.ProtoReflect.Descriptor instead.
+func (*InitReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *InitReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
 }
 
-func (x *LoadTFPlanReply) GetMessage() string {
+func (x *InitReply) GetStateLockIdentifier() string {
 	if x != nil {
-		return x.Message
+		return x.StateLockIdentifier
 	}
 	return ""
 }
 
-type ApplyRequest struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	TfInstance         string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	DirOrPlan          string                 `protobuf:"bytes,2,opt,name=dirOrPlan,proto3" json:"dirOrPlan,omitempty"`
-	RefreshBeforeApply bool                   `protobuf:"varint,3,opt,name=refreshBeforeApply,proto3" json:"refreshBeforeApply,omitempty"`
-	Targets            []string               `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
-	Parallelism        int32                  `protobuf:"varint,5,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+type MigrateStateRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	TfInstance            string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	WorkingDir            string                 `protobuf:"bytes,2,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
+	PreviousBackendConfig []byte                 `protobuf:"bytes,3,opt,name=previousBackendConfig,proto3" json:"previousBackendConfig,omitempty"`
+	BackendConfig         []byte                 `protobuf:"bytes,4,opt,name=backendConfig,proto3" json:"backendConfig,omitempty"`
+	MigrationID           string                 `protobuf:"bytes,5,opt,name=migrationID,proto3" json:"migrationID,omitempty"`
+	Revision              string                 `protobuf:"bytes,6,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
-func (x *ApplyRequest) Reset() {
-	*x = ApplyRequest{}
-	mi := &file_runner_runner_proto_msgTypes[31]
+func (x *MigrateStateRequest) Reset() {
+	*x = MigrateStateRequest{}
+	mi := &file_runner_runner_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ApplyRequest) String() string {
+func (x *MigrateStateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApplyRequest) ProtoMessage() {}
+func (*MigrateStateRequest) ProtoMessage() {}
 
-func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[31]
+func (x *MigrateStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1693,69 +3434,77 @@ func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ApplyRequest.ProtoReflect.Descriptor instead.
-func (*ApplyRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use MigrateStateRequest.ProtoReflect.Descriptor instead.
+func (*MigrateStateRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *ApplyRequest) GetTfInstance() string {
+func (x *MigrateStateRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *ApplyRequest) GetDirOrPlan() string {
+func (x *MigrateStateRequest) GetWorkingDir() string {
 	if x != nil {
-		return x.DirOrPlan
+		return x.WorkingDir
 	}
 	return ""
 }
 
-func (x *ApplyRequest) GetRefreshBeforeApply() bool {
+func (x *MigrateStateRequest) GetPreviousBackendConfig() []byte {
 	if x != nil {
-		return x.RefreshBeforeApply
+		return x.PreviousBackendConfig
 	}
-	return false
+	return nil
 }
 
-func (x *ApplyRequest) GetTargets() []string {
+func (x *MigrateStateRequest) GetBackendConfig() []byte {
 	if x != nil {
-		return x.Targets
+		return x.BackendConfig
 	}
 	return nil
 }
 
-func (x *ApplyRequest) GetParallelism() int32 {
+func (x *MigrateStateRequest) GetMigrationID() string {
 	if x != nil {
-		return x.Parallelism
+		return x.MigrationID
 	}
-	return 0
+	return ""
 }
 
-type ApplyReply struct {
+func (x *MigrateStateRequest) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+type MigrateStateReply struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
 	Message             string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	StateLockIdentifier string                 `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	BackupName          string                 `protobuf:"bytes,3,opt,name=backupName,proto3" json:"backupName,omitempty"`
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
 
-func (x *ApplyReply) Reset() {
-	*x = ApplyReply{}
-	mi := &file_runner_runner_proto_msgTypes[32]
+func (x *MigrateStateReply) Reset() {
+	*x = MigrateStateReply{}
+	mi := &file_runner_runner_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ApplyReply) String() string {
+func (x *MigrateStateReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApplyReply) ProtoMessage() {}
+func (*MigrateStateReply) ProtoMessage() {}
 
-func (x *ApplyReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[32]
+func (x *MigrateStateReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1766,91 +3515,54 @@ func (x *ApplyReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ApplyReply.ProtoReflect.Descriptor instead.
-func (*ApplyReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use MigrateStateReply.ProtoReflect.Descriptor instead.
+func (*MigrateStateReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *ApplyReply) GetMessage() string {
+func (x *MigrateStateReply) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *ApplyReply) GetStateLockIdentifier() string {
+func (x *MigrateStateReply) GetStateLockIdentifier() string {
 	if x != nil {
 		return x.StateLockIdentifier
 	}
 	return ""
 }
 
-type GetInventoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetInventoryRequest) Reset() {
-	*x = GetInventoryRequest{}
-	mi := &file_runner_runner_proto_msgTypes[33]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetInventoryRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetInventoryRequest) ProtoMessage() {}
-
-func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[33]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetInventoryRequest.ProtoReflect.Descriptor instead.
-func (*GetInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{33}
-}
-
-func (x *GetInventoryRequest) GetTfInstance() string {
+func (x *MigrateStateReply) GetBackupName() string {
 	if x != nil {
-		return x.TfInstance
+		return x.BackupName
 	}
 	return ""
 }
 
-type GetInventoryReply struct {
+type StatePullRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Inventories   []*Inventory           `protobuf:"bytes,1,rep,name=inventories,proto3" json:"inventories,omitempty"`
+	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetInventoryReply) Reset() {
-	*x = GetInventoryReply{}
-	mi := &file_runner_runner_proto_msgTypes[34]
+func (x *StatePullRequest) Reset() {
+	*x = StatePullRequest{}
+	mi := &file_runner_runner_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetInventoryReply) String() string {
+func (x *StatePullRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetInventoryReply) ProtoMessage() {}
+func (*StatePullRequest) ProtoMessage() {}
 
-func (x *GetInventoryReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[34]
+func (x *StatePullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1861,42 +3573,40 @@ func (x *GetInventoryReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetInventoryReply.ProtoReflect.Descriptor instead.
-func (*GetInventoryReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use StatePullRequest.ProtoReflect.Descriptor instead.
+func (*StatePullRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *GetInventoryReply) GetInventories() []*Inventory {
+func (x *StatePullRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Inventories
+		return x.TfInstance
 	}
-	return nil
+	return ""
 }
 
-type Inventory struct {
+type StatePullReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Identifier    string                 `protobuf:"bytes,3,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	State         []byte                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Inventory) Reset() {
-	*x = Inventory{}
-	mi := &file_runner_runner_proto_msgTypes[35]
+func (x *StatePullReply) Reset() {
+	*x = StatePullReply{}
+	mi := &file_runner_runner_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Inventory) String() string {
+func (x *StatePullReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Inventory) ProtoMessage() {}
+func (*StatePullReply) ProtoMessage() {}
 
-func (x *Inventory) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[35]
+func (x *StatePullReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1907,55 +3617,42 @@ func (x *Inventory) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Inventory.ProtoReflect.Descriptor instead.
-func (*Inventory) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{35}
-}
-
-func (x *Inventory) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Inventory) GetType() string {
-	if x != nil {
-		return x.Type
-	}
-	return ""
+// Deprecated: Use StatePullReply.ProtoReflect.Descriptor instead.
+func (*StatePullReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *Inventory) GetIdentifier() string {
+func (x *StatePullReply) GetState() []byte {
 	if x != nil {
-		return x.Identifier
+		return x.State
 	}
-	return ""
+	return nil
 }
 
-type DestroyRequest struct {
+type StatePushRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	Targets       []string               `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	State         []byte                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Force         bool                   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DestroyRequest) Reset() {
-	*x = DestroyRequest{}
-	mi := &file_runner_runner_proto_msgTypes[36]
+func (x *StatePushRequest) Reset() {
+	*x = StatePushRequest{}
+	mi := &file_runner_runner_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DestroyRequest) String() string {
+func (x *StatePushRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DestroyRequest) ProtoMessage() {}
+func (*StatePushRequest) ProtoMessage() {}
 
-func (x *DestroyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[36]
+func (x *StatePushRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1966,26 +3663,33 @@ func (x *DestroyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DestroyRequest.ProtoReflect.Descriptor instead.
-func (*DestroyRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use StatePushRequest.ProtoReflect.Descriptor instead.
+func (*StatePushRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *DestroyRequest) GetTfInstance() string {
+func (x *StatePushRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *DestroyRequest) GetTargets() []string {
+func (x *StatePushRequest) GetState() []byte {
 	if x != nil {
-		return x.Targets
+		return x.State
 	}
 	return nil
 }
 
-type DestroyReply struct {
+func (x *StatePushRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type StatePushReply struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
 	Message             string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	StateLockIdentifier string                 `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
@@ -1993,21 +3697,21 @@ type DestroyReply struct {
 	sizeCache           protoimpl.SizeCache
 }
 
-func (x *DestroyReply) Reset() {
-	*x = DestroyReply{}
-	mi := &file_runner_runner_proto_msgTypes[37]
+func (x *StatePushReply) Reset() {
+	*x = StatePushReply{}
+	mi := &file_runner_runner_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DestroyReply) String() string {
+func (x *StatePushReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DestroyReply) ProtoMessage() {}
+func (*StatePushReply) ProtoMessage() {}
 
-func (x *DestroyReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[37]
+func (x *StatePushReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2018,47 +3722,48 @@ func (x *DestroyReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DestroyReply.ProtoReflect.Descriptor instead.
-func (*DestroyReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use StatePushReply.ProtoReflect.Descriptor instead.
+func (*StatePushReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *DestroyReply) GetMessage() string {
+func (x *StatePushReply) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *DestroyReply) GetStateLockIdentifier() string {
+func (x *StatePushReply) GetStateLockIdentifier() string {
 	if x != nil {
 		return x.StateLockIdentifier
 	}
 	return ""
 }
 
-type OutputRequest struct {
+type StateListRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Addresses     []string               `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OutputRequest) Reset() {
-	*x = OutputRequest{}
-	mi := &file_runner_runner_proto_msgTypes[38]
+func (x *StateListRequest) Reset() {
+	*x = StateListRequest{}
+	mi := &file_runner_runner_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OutputRequest) String() string {
+func (x *StateListRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OutputRequest) ProtoMessage() {}
+func (*StateListRequest) ProtoMessage() {}
 
-func (x *OutputRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[38]
+func (x *StateListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2069,150 +3774,93 @@ func (x *OutputRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OutputRequest.ProtoReflect.Descriptor instead.
-func (*OutputRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use StateListRequest.ProtoReflect.Descriptor instead.
+func (*StateListRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *OutputRequest) GetTfInstance() string {
+func (x *StateListRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-type OutputReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Outputs       map[string]*OutputMeta `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *OutputReply) Reset() {
-	*x = OutputReply{}
-	mi := &file_runner_runner_proto_msgTypes[39]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *OutputReply) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*OutputReply) ProtoMessage() {}
-
-func (x *OutputReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[39]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use OutputReply.ProtoReflect.Descriptor instead.
-func (*OutputReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{39}
-}
-
-func (x *OutputReply) GetOutputs() map[string]*OutputMeta {
+func (x *StateListRequest) GetAddresses() []string {
 	if x != nil {
-		return x.Outputs
+		return x.Addresses
 	}
 	return nil
 }
 
-type OutputMeta struct {
+type StateListReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sensitive     bool                   `protobuf:"varint,1,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
-	Type          []byte                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Value         []byte                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Addresses     []string               `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OutputMeta) Reset() {
-	*x = OutputMeta{}
-	mi := &file_runner_runner_proto_msgTypes[40]
+func (x *StateListReply) Reset() {
+	*x = StateListReply{}
+	mi := &file_runner_runner_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OutputMeta) String() string {
+func (x *StateListReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OutputMeta) ProtoMessage() {}
+func (*StateListReply) ProtoMessage() {}
 
-func (x *OutputMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[40]
+func (x *StateListReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use OutputMeta.ProtoReflect.Descriptor instead.
-func (*OutputMeta) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{40}
-}
-
-func (x *OutputMeta) GetSensitive() bool {
-	if x != nil {
-		return x.Sensitive
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *OutputMeta) GetType() []byte {
-	if x != nil {
-		return x.Type
-	}
-	return nil
+// Deprecated: Use StateListReply.ProtoReflect.Descriptor instead.
+func (*StateListReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *OutputMeta) GetValue() []byte {
+func (x *StateListReply) GetAddresses() []string {
 	if x != nil {
-		return x.Value
+		return x.Addresses
 	}
 	return nil
 }
 
-type WriteOutputsRequest struct {
+type StateMoveRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	SecretName    string                 `protobuf:"bytes,3,opt,name=secretName,proto3" json:"secretName,omitempty"`
-	Uuid          string                 `protobuf:"bytes,4,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Data          map[string][]byte      `protobuf:"bytes,5,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Labels        map[string]string      `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Annotations   map[string]string      `protobuf:"bytes,7,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Destination   string                 `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WriteOutputsRequest) Reset() {
-	*x = WriteOutputsRequest{}
-	mi := &file_runner_runner_proto_msgTypes[41]
+func (x *StateMoveRequest) Reset() {
+	*x = StateMoveRequest{}
+	mi := &file_runner_runner_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WriteOutputsRequest) String() string {
+func (x *StateMoveRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteOutputsRequest) ProtoMessage() {}
+func (*StateMoveRequest) ProtoMessage() {}
 
-func (x *WriteOutputsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[41]
+func (x *StateMoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2223,83 +3871,55 @@ func (x *WriteOutputsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteOutputsRequest.ProtoReflect.Descriptor instead.
-func (*WriteOutputsRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{41}
-}
-
-func (x *WriteOutputsRequest) GetNamespace() string {
-	if x != nil {
-		return x.Namespace
-	}
-	return ""
+// Deprecated: Use StateMoveRequest.ProtoReflect.Descriptor instead.
+func (*StateMoveRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *WriteOutputsRequest) GetName() string {
+func (x *StateMoveRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Name
+		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *WriteOutputsRequest) GetSecretName() string {
+func (x *StateMoveRequest) GetSource() string {
 	if x != nil {
-		return x.SecretName
+		return x.Source
 	}
 	return ""
 }
 
-func (x *WriteOutputsRequest) GetUuid() string {
+func (x *StateMoveRequest) GetDestination() string {
 	if x != nil {
-		return x.Uuid
+		return x.Destination
 	}
 	return ""
 }
 
-func (x *WriteOutputsRequest) GetData() map[string][]byte {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-func (x *WriteOutputsRequest) GetLabels() map[string]string {
-	if x != nil {
-		return x.Labels
-	}
-	return nil
-}
-
-func (x *WriteOutputsRequest) GetAnnotations() map[string]string {
-	if x != nil {
-		return x.Annotations
-	}
-	return nil
-}
-
-type WriteOutputsReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Changed       bool                   `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type StateMoveReply struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Message             string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier string                 `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *WriteOutputsReply) Reset() {
-	*x = WriteOutputsReply{}
-	mi := &file_runner_runner_proto_msgTypes[42]
+func (x *StateMoveReply) Reset() {
+	*x = StateMoveReply{}
+	mi := &file_runner_runner_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WriteOutputsReply) String() string {
+func (x *StateMoveReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteOutputsReply) ProtoMessage() {}
+func (*StateMoveReply) ProtoMessage() {}
 
-func (x *WriteOutputsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[42]
+func (x *StateMoveReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2310,48 +3930,48 @@ func (x *WriteOutputsReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteOutputsReply.ProtoReflect.Descriptor instead.
-func (*WriteOutputsReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use StateMoveReply.ProtoReflect.Descriptor instead.
+func (*StateMoveReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *WriteOutputsReply) GetMessage() string {
+func (x *StateMoveReply) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *WriteOutputsReply) GetChanged() bool {
+func (x *StateMoveReply) GetStateLockIdentifier() string {
 	if x != nil {
-		return x.Changed
+		return x.StateLockIdentifier
 	}
-	return false
+	return ""
 }
 
-type GetOutputsRequest struct {
+type StateRemoveRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	SecretName    string                 `protobuf:"bytes,2,opt,name=secretName,proto3" json:"secretName,omitempty"`
+	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Addresses     []string               `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOutputsRequest) Reset() {
-	*x = GetOutputsRequest{}
-	mi := &file_runner_runner_proto_msgTypes[43]
+func (x *StateRemoveRequest) Reset() {
+	*x = StateRemoveRequest{}
+	mi := &file_runner_runner_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOutputsRequest) String() string {
+func (x *StateRemoveRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOutputsRequest) ProtoMessage() {}
+func (*StateRemoveRequest) ProtoMessage() {}
 
-func (x *GetOutputsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[43]
+func (x *StateRemoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2362,47 +3982,49 @@ func (x *GetOutputsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOutputsRequest.ProtoReflect.Descriptor instead.
-func (*GetOutputsRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use StateRemoveRequest.ProtoReflect.Descriptor instead.
+func (*StateRemoveRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *GetOutputsRequest) GetNamespace() string {
+func (x *StateRemoveRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Namespace
+		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *GetOutputsRequest) GetSecretName() string {
+func (x *StateRemoveRequest) GetAddresses() []string {
 	if x != nil {
-		return x.SecretName
+		return x.Addresses
 	}
-	return ""
+	return nil
 }
 
-type GetOutputsReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Outputs       map[string]string      `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type StateRemoveReply struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Message             string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier string                 `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	RemovedAddresses    []string               `protobuf:"bytes,3,rep,name=removedAddresses,proto3" json:"removedAddresses,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *GetOutputsReply) Reset() {
-	*x = GetOutputsReply{}
-	mi := &file_runner_runner_proto_msgTypes[44]
+func (x *StateRemoveReply) Reset() {
+	*x = StateRemoveReply{}
+	mi := &file_runner_runner_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOutputsReply) String() string {
+func (x *StateRemoveReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOutputsReply) ProtoMessage() {}
+func (*StateRemoveReply) ProtoMessage() {}
 
-func (x *GetOutputsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[44]
+func (x *StateRemoveReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2413,42 +4035,56 @@ func (x *GetOutputsReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOutputsReply.ProtoReflect.Descriptor instead.
-func (*GetOutputsReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{44}
+// Deprecated: Use StateRemoveReply.ProtoReflect.Descriptor instead.
+func (*StateRemoveReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *GetOutputsReply) GetOutputs() map[string]string {
+func (x *StateRemoveReply) GetMessage() string {
 	if x != nil {
-		return x.Outputs
+		return x.Message
+	}
+	return ""
+}
+
+func (x *StateRemoveReply) GetStateLockIdentifier() string {
+	if x != nil {
+		return x.StateLockIdentifier
+	}
+	return ""
+}
+
+func (x *StateRemoveReply) GetRemovedAddresses() []string {
+	if x != nil {
+		return x.RemovedAddresses
 	}
 	return nil
 }
 
-type InitRequest struct {
+type ImportRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TfInstance    string                 `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	Upgrade       bool                   `protobuf:"varint,2,opt,name=upgrade,proto3" json:"upgrade,omitempty"`
-	ForceCopy     bool                   `protobuf:"varint,3,opt,name=forceCopy,proto3" json:"forceCopy,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InitRequest) Reset() {
-	*x = InitRequest{}
-	mi := &file_runner_runner_proto_msgTypes[45]
+func (x *ImportRequest) Reset() {
+	*x = ImportRequest{}
+	mi := &file_runner_runner_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InitRequest) String() string {
+func (x *ImportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InitRequest) ProtoMessage() {}
+func (*ImportRequest) ProtoMessage() {}
 
-func (x *InitRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[45]
+func (x *ImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2459,33 +4095,33 @@ func (x *InitRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InitRequest.ProtoReflect.Descriptor instead.
-func (*InitRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{45}
+// Deprecated: Use ImportRequest.ProtoReflect.Descriptor instead.
+func (*ImportRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *InitRequest) GetTfInstance() string {
+func (x *ImportRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *InitRequest) GetUpgrade() bool {
+func (x *ImportRequest) GetAddress() string {
 	if x != nil {
-		return x.Upgrade
+		return x.Address
 	}
-	return false
+	return ""
 }
 
-func (x *InitRequest) GetForceCopy() bool {
+func (x *ImportRequest) GetId() string {
 	if x != nil {
-		return x.ForceCopy
+		return x.Id
 	}
-	return false
+	return ""
 }
 
-type InitReply struct {
+type ImportReply struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
 	Message             string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	StateLockIdentifier string                 `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
@@ -2493,21 +4129,21 @@ type InitReply struct {
 	sizeCache           protoimpl.SizeCache
 }
 
-func (x *InitReply) Reset() {
-	*x = InitReply{}
-	mi := &file_runner_runner_proto_msgTypes[46]
+func (x *ImportReply) Reset() {
+	*x = ImportReply{}
+	mi := &file_runner_runner_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InitReply) String() string {
+func (x *ImportReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InitReply) ProtoMessage() {}
+func (*ImportReply) ProtoMessage() {}
 
-func (x *InitReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[46]
+func (x *ImportReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2518,19 +4154,19 @@ func (x *InitReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InitReply.ProtoReflect.Descriptor instead.
-func (*InitReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{46}
+// Deprecated: Use ImportReply.ProtoReflect.Descriptor instead.
+func (*ImportReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *InitReply) GetMessage() string {
+func (x *ImportReply) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *InitReply) GetStateLockIdentifier() string {
+func (x *ImportReply) GetStateLockIdentifier() string {
 	if x != nil {
 		return x.StateLockIdentifier
 	}
@@ -2546,7 +4182,7 @@ type WorkspaceRequest struct {
 
 func (x *WorkspaceRequest) Reset() {
 	*x = WorkspaceRequest{}
-	mi := &file_runner_runner_proto_msgTypes[47]
+	mi := &file_runner_runner_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2558,7 +4194,7 @@ func (x *WorkspaceRequest) String() string {
 func (*WorkspaceRequest) ProtoMessage() {}
 
 func (x *WorkspaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[47]
+	mi := &file_runner_runner_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2571,7 +4207,7 @@ func (x *WorkspaceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WorkspaceRequest.ProtoReflect.Descriptor instead.
 func (*WorkspaceRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{47}
+	return file_runner_runner_proto_rawDescGZIP(), []int{74}
 }
 
 func (x *WorkspaceRequest) GetTfInstance() string {
@@ -2590,7 +4226,7 @@ type WorkspaceReply struct {
 
 func (x *WorkspaceReply) Reset() {
 	*x = WorkspaceReply{}
-	mi := &file_runner_runner_proto_msgTypes[48]
+	mi := &file_runner_runner_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2602,7 +4238,7 @@ func (x *WorkspaceReply) String() string {
 func (*WorkspaceReply) ProtoMessage() {}
 
 func (x *WorkspaceReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[48]
+	mi := &file_runner_runner_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2615,7 +4251,7 @@ func (x *WorkspaceReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WorkspaceReply.ProtoReflect.Descriptor instead.
 func (*WorkspaceReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{48}
+	return file_runner_runner_proto_rawDescGZIP(), []int{75}
 }
 
 func (x *WorkspaceReply) GetMessage() string {
@@ -2636,7 +4272,7 @@ type CreateWorkspaceBlobRequest struct {
 
 func (x *CreateWorkspaceBlobRequest) Reset() {
 	*x = CreateWorkspaceBlobRequest{}
-	mi := &file_runner_runner_proto_msgTypes[49]
+	mi := &file_runner_runner_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2648,7 +4284,7 @@ func (x *CreateWorkspaceBlobRequest) String() string {
 func (*CreateWorkspaceBlobRequest) ProtoMessage() {}
 
 func (x *CreateWorkspaceBlobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[49]
+	mi := &file_runner_runner_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2661,7 +4297,7 @@ func (x *CreateWorkspaceBlobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateWorkspaceBlobRequest.ProtoReflect.Descriptor instead.
 func (*CreateWorkspaceBlobRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{49}
+	return file_runner_runner_proto_rawDescGZIP(), []int{76}
 }
 
 func (x *CreateWorkspaceBlobRequest) GetTfInstance() string {
@@ -2695,7 +4331,7 @@ type CreateWorkspaceBlobReply struct {
 
 func (x *CreateWorkspaceBlobReply) Reset() {
 	*x = CreateWorkspaceBlobReply{}
-	mi := &file_runner_runner_proto_msgTypes[50]
+	mi := &file_runner_runner_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2707,7 +4343,7 @@ func (x *CreateWorkspaceBlobReply) String() string {
 func (*CreateWorkspaceBlobReply) ProtoMessage() {}
 
 func (x *CreateWorkspaceBlobReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[50]
+	mi := &file_runner_runner_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2720,7 +4356,7 @@ func (x *CreateWorkspaceBlobReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateWorkspaceBlobReply.ProtoReflect.Descriptor instead.
 func (*CreateWorkspaceBlobReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{50}
+	return file_runner_runner_proto_rawDescGZIP(), []int{77}
 }
 
 func (x *CreateWorkspaceBlobReply) GetBlob() []byte {
@@ -2746,7 +4382,7 @@ type UploadRequest struct {
 
 func (x *UploadRequest) Reset() {
 	*x = UploadRequest{}
-	mi := &file_runner_runner_proto_msgTypes[51]
+	mi := &file_runner_runner_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2758,7 +4394,7 @@ func (x *UploadRequest) String() string {
 func (*UploadRequest) ProtoMessage() {}
 
 func (x *UploadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[51]
+	mi := &file_runner_runner_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2771,7 +4407,7 @@ func (x *UploadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
 func (*UploadRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{51}
+	return file_runner_runner_proto_rawDescGZIP(), []int{78}
 }
 
 func (x *UploadRequest) GetBlob() []byte {
@@ -2790,7 +4426,7 @@ type UploadReply struct {
 
 func (x *UploadReply) Reset() {
 	*x = UploadReply{}
-	mi := &file_runner_runner_proto_msgTypes[52]
+	mi := &file_runner_runner_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2802,7 +4438,7 @@ func (x *UploadReply) String() string {
 func (*UploadReply) ProtoMessage() {}
 
 func (x *UploadReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[52]
+	mi := &file_runner_runner_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2815,7 +4451,7 @@ func (x *UploadReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadReply.ProtoReflect.Descriptor instead.
 func (*UploadReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{52}
+	return file_runner_runner_proto_rawDescGZIP(), []int{79}
 }
 
 func (x *UploadReply) GetMessage() string {
@@ -2838,7 +4474,7 @@ type FinalizeSecretsRequest struct {
 
 func (x *FinalizeSecretsRequest) Reset() {
 	*x = FinalizeSecretsRequest{}
-	mi := &file_runner_runner_proto_msgTypes[53]
+	mi := &file_runner_runner_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2850,7 +4486,7 @@ func (x *FinalizeSecretsRequest) String() string {
 func (*FinalizeSecretsRequest) ProtoMessage() {}
 
 func (x *FinalizeSecretsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[53]
+	mi := &file_runner_runner_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2863,7 +4499,7 @@ func (x *FinalizeSecretsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FinalizeSecretsRequest.ProtoReflect.Descriptor instead.
 func (*FinalizeSecretsRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{53}
+	return file_runner_runner_proto_rawDescGZIP(), []int{80}
 }
 
 func (x *FinalizeSecretsRequest) GetNamespace() string {
@@ -2911,7 +4547,7 @@ type FinalizeSecretsReply struct {
 
 func (x *FinalizeSecretsReply) Reset() {
 	*x = FinalizeSecretsReply{}
-	mi := &file_runner_runner_proto_msgTypes[54]
+	mi := &file_runner_runner_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2923,7 +4559,7 @@ func (x *FinalizeSecretsReply) String() string {
 func (*FinalizeSecretsReply) ProtoMessage() {}
 
 func (x *FinalizeSecretsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[54]
+	mi := &file_runner_runner_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2936,7 +4572,7 @@ func (x *FinalizeSecretsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FinalizeSecretsReply.ProtoReflect.Descriptor instead.
 func (*FinalizeSecretsReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{54}
+	return file_runner_runner_proto_rawDescGZIP(), []int{81}
 }
 
 func (x *FinalizeSecretsReply) GetMessage() string {
@@ -2962,7 +4598,7 @@ type ForceUnlockRequest struct {
 
 func (x *ForceUnlockRequest) Reset() {
 	*x = ForceUnlockRequest{}
-	mi := &file_runner_runner_proto_msgTypes[55]
+	mi := &file_runner_runner_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2974,7 +4610,7 @@ func (x *ForceUnlockRequest) String() string {
 func (*ForceUnlockRequest) ProtoMessage() {}
 
 func (x *ForceUnlockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[55]
+	mi := &file_runner_runner_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2987,7 +4623,7 @@ func (x *ForceUnlockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ForceUnlockRequest.ProtoReflect.Descriptor instead.
 func (*ForceUnlockRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{55}
+	return file_runner_runner_proto_rawDescGZIP(), []int{82}
 }
 
 func (x *ForceUnlockRequest) GetLockIdentifier() string {
@@ -3007,7 +4643,7 @@ type ForceUnlockReply struct {
 
 func (x *ForceUnlockReply) Reset() {
 	*x = ForceUnlockReply{}
-	mi := &file_runner_runner_proto_msgTypes[56]
+	mi := &file_runner_runner_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3019,7 +4655,7 @@ func (x *ForceUnlockReply) String() string {
 func (*ForceUnlockReply) ProtoMessage() {}
 
 func (x *ForceUnlockReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[56]
+	mi := &file_runner_runner_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3032,7 +4668,7 @@ func (x *ForceUnlockReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ForceUnlockReply.ProtoReflect.Descriptor instead.
 func (*ForceUnlockReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{56}
+	return file_runner_runner_proto_rawDescGZIP(), []int{83}
 }
 
 func (x *ForceUnlockReply) GetMessage() string {
@@ -3057,7 +4693,7 @@ type BreakTheGlassRequest struct {
 
 func (x *BreakTheGlassRequest) Reset() {
 	*x = BreakTheGlassRequest{}
-	mi := &file_runner_runner_proto_msgTypes[57]
+	mi := &file_runner_runner_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3069,7 +4705,7 @@ func (x *BreakTheGlassRequest) String() string {
 func (*BreakTheGlassRequest) ProtoMessage() {}
 
 func (x *BreakTheGlassRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[57]
+	mi := &file_runner_runner_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3082,7 +4718,7 @@ func (x *BreakTheGlassRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BreakTheGlassRequest.ProtoReflect.Descriptor instead.
 func (*BreakTheGlassRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{57}
+	return file_runner_runner_proto_rawDescGZIP(), []int{84}
 }
 
 type BreakTheGlassReply struct {
@@ -3095,7 +4731,7 @@ type BreakTheGlassReply struct {
 
 func (x *BreakTheGlassReply) Reset() {
 	*x = BreakTheGlassReply{}
-	mi := &file_runner_runner_proto_msgTypes[58]
+	mi := &file_runner_runner_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3107,7 +4743,7 @@ func (x *BreakTheGlassReply) String() string {
 func (*BreakTheGlassReply) ProtoMessage() {}
 
 func (x *BreakTheGlassReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[58]
+	mi := &file_runner_runner_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3120,7 +4756,7 @@ func (x *BreakTheGlassReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BreakTheGlassReply.ProtoReflect.Descriptor instead.
 func (*BreakTheGlassReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{58}
+	return file_runner_runner_proto_rawDescGZIP(), []int{85}
 }
 
 func (x *BreakTheGlassReply) GetMessage() string {
@@ -3177,12 +4813,16 @@ const file_runner_runner_proto_rawDesc = "" +
 	"workingDir\x127\n" +
 	"\ffileMappings\x18\x02 \x03(\v2\x13.runner.fileMappingR\ffileMappings\"3\n" +
 	"\x17CreateFileMappingsReply\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"u\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xc3\x01\n" +
 	"\x17UploadAndExtractRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
 	"\x05tarGz\x18\x03 \x01(\fR\x05tarGz\x12\x12\n" +
-	"\x04path\x18\x04 \x01(\tR\x04path\"O\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12L\n" +
+	"\x13additionalArtifacts\x18\x05 \x03(\v2\x1a.runner.AdditionalArtifactR\x13additionalArtifacts\">\n" +
+	"\x12AdditionalArtifact\x12\x14\n" +
+	"\x05tarGz\x18\x01 \x01(\fR\x05tarGz\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\"O\n" +
 	"\x15UploadAndExtractReply\x12\x1e\n" +
 	"\n" +
 	"workingDir\x18\x01 \x01(\tR\n" +
@@ -3202,11 +4842,20 @@ const file_runner_runner_proto_rawDesc = "" +
 	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\"3\n" +
 	"\x15ProcessCliConfigReply\x12\x1a\n" +
-	"\bfilePath\x18\x01 \x01(\tR\bfilePath\":\n" +
+	"\bfilePath\x18\x01 \x01(\tR\bfilePath\"\xfd\x01\n" +
 	"\x18GenerateVarsForTFRequest\x12\x1e\n" +
 	"\n" +
 	"workingDir\x18\x01 \x01(\tR\n" +
-	"workingDir\"2\n" +
+	"workingDir\x12b\n" +
+	"\x10terraformOutputs\x18\x02 \x03(\v26.runner.GenerateVarsForTFRequest.TerraformOutputsEntryR\x10terraformOutputs\x1a]\n" +
+	"\x15TerraformOutputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12.\n" +
+	"\x05value\x18\x02 \x01(\v2\x18.runner.TerraformOutputsR\x05value:\x028\x01\"\x8f\x01\n" +
+	"\x10TerraformOutputs\x12?\n" +
+	"\aoutputs\x18\x01 \x03(\v2%.runner.TerraformOutputs.OutputsEntryR\aoutputs\x1a:\n" +
+	"\fOutputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"2\n" +
 	"\x16GenerateVarsForTFReply\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\"9\n" +
 	"\x17GenerateTemplateRequest\x12\x1e\n" +
@@ -3214,7 +4863,55 @@ const file_runner_runner_proto_rawDesc = "" +
 	"workingDir\x18\x01 \x01(\tR\n" +
 	"workingDir\"1\n" +
 	"\x15GenerateTemplateReply\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"\xdb\x01\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"@\n" +
+	"\x1eGenerateImportsAndMovesRequest\x12\x1e\n" +
+	"\n" +
+	"workingDir\x18\x01 \x01(\tR\n" +
+	"workingDir\"b\n" +
+	"\x1cGenerateImportsAndMovesReply\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12(\n" +
+	"\x0fimportAddresses\x18\x02 \x03(\tR\x0fimportAddresses\"S\n" +
+	"\x0fValidateRequest\x12\x1e\n" +
+	"\n" +
+	"tfInstance\x18\x01 \x01(\tR\n" +
+	"tfInstance\x12 \n" +
+	"\vcheckFormat\x18\x02 \x01(\bR\vcheckFormat\"\x8d\x01\n" +
+	"\rValidateReply\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\x12 \n" +
+	"\vdiagnostics\x18\x03 \x03(\tR\vdiagnostics\x12*\n" +
+	"\x10unformattedFiles\x18\x04 \x03(\tR\x10unformattedFiles\"-\n" +
+	"\vTestRequest\x12\x1e\n" +
+	"\n" +
+	"tfInstance\x18\x01 \x01(\tR\n" +
+	"tfInstance\"\xe5\x01\n" +
+	"\tTestReply\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x16\n" +
+	"\x06passed\x18\x02 \x01(\bR\x06passed\x12 \n" +
+	"\vpassedCount\x18\x03 \x01(\x05R\vpassedCount\x12 \n" +
+	"\vfailedCount\x18\x04 \x01(\x05R\vfailedCount\x12\"\n" +
+	"\ferroredCount\x18\x05 \x01(\x05R\ferroredCount\x12\"\n" +
+	"\fskippedCount\x18\x06 \x01(\x05R\fskippedCount\x12\x1a\n" +
+	"\bfailures\x18\a \x03(\tR\bfailures\"c\n" +
+	"\x15CheckVariablesRequest\x12\x1e\n" +
+	"\n" +
+	"tfInstance\x18\x01 \x01(\tR\n" +
+	"tfInstance\x12*\n" +
+	"\x10sourceRefRootDir\x18\x02 \x01(\tR\x10sourceRefRootDir\"\xb3\x01\n" +
+	"\x13CheckVariablesReply\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x124\n" +
+	"\tvariables\x18\x02 \x03(\v2\x16.runner.VariableSchemaR\tvariables\x12\x18\n" +
+	"\amissing\x18\x03 \x03(\tR\amissing\x12\x18\n" +
+	"\ainvalid\x18\x04 \x03(\tR\ainvalid\x12\x18\n" +
+	"\aunknown\x18\x05 \x03(\tR\aunknown\"\xd0\x01\n" +
+	"\x0eVariableSchema\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\adefault\x18\x04 \x01(\fR\adefault\x12\x1a\n" +
+	"\brequired\x18\x05 \x01(\bR\brequired\x12\x1c\n" +
+	"\tsensitive\x18\x06 \x01(\bR\tsensitive\x12 \n" +
+	"\vvalidations\x18\a \x01(\x05R\vvalidations\"\xdb\x01\n" +
 	"\vPlanRequest\x12\x1e\n" +
 	"\n" +
 	"tfInstance\x18\x01 \x01(\tR\n" +
@@ -3312,7 +5009,7 @@ const file_runner_runner_proto_rawDesc = "" +
 	"OutputMeta\x12\x1c\n" +
 	"\tsensitive\x18\x01 \x01(\bR\tsensitive\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\fR\x04type\x12\x14\n" +
-	"\x05value\x18\x03 \x01(\fR\x05value\"\xfb\x03\n" +
+	"\x05value\x18\x03 \x01(\fR\x05value\"\xbf\x05\n" +
 	"\x13WriteOutputsRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1e\n" +
@@ -3322,7 +5019,15 @@ const file_runner_runner_proto_rawDesc = "" +
 	"\x04uuid\x18\x04 \x01(\tR\x04uuid\x129\n" +
 	"\x04data\x18\x05 \x03(\v2%.runner.WriteOutputsRequest.DataEntryR\x04data\x12?\n" +
 	"\x06labels\x18\x06 \x03(\v2'.runner.WriteOutputsRequest.LabelsEntryR\x06labels\x12N\n" +
-	"\vannotations\x18\a \x03(\v2,.runner.WriteOutputsRequest.AnnotationsEntryR\vannotations\x1a7\n" +
+	"\vannotations\x18\a \x03(\v2,.runner.WriteOutputsRequest.AnnotationsEntryR\vannotations\x12\x12\n" +
+	"\x04kind\x18\b \x01(\tR\x04kind\x12\x1e\n" +
+	"\n" +
+	"secretType\x18\t \x01(\tR\n" +
+	"secretType\x12(\n" +
+	"\x0ftargetNamespace\x18\n" +
+	" \x01(\tR\x0ftargetNamespace\x122\n" +
+	"\x14kubeConfigSecretName\x18\v \x01(\tR\x14kubeConfigSecretName\x120\n" +
+	"\x13kubeConfigSecretKey\x18\f \x01(\tR\x13kubeConfigSecretKey\x1a7\n" +
 	"\tDataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\x1a9\n" +
@@ -3331,7 +5036,19 @@ const file_runner_runner_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
 	"\x10AnnotationsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"G\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8c\x02\n" +
+	"\x14DeleteOutputsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1e\n" +
+	"\n" +
+	"secretName\x18\x02 \x01(\tR\n" +
+	"secretName\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12(\n" +
+	"\x0ftargetNamespace\x18\x04 \x01(\tR\x0ftargetNamespace\x122\n" +
+	"\x14kubeConfigSecretName\x18\x05 \x01(\tR\x14kubeConfigSecretName\x120\n" +
+	"\x13kubeConfigSecretKey\x18\x06 \x01(\tR\x13kubeConfigSecretKey\x12\x12\n" +
+	"\x04name\x18\a \x01(\tR\x04name\".\n" +
+	"\x12DeleteOutputsReply\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"G\n" +
 	"\x11WriteOutputsReply\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
 	"\achanged\x18\x02 \x01(\bR\achanged\"Q\n" +
@@ -3353,6 +5070,72 @@ const file_runner_runner_proto_rawDesc = "" +
 	"\tforceCopy\x18\x03 \x01(\bR\tforceCopy\"W\n" +
 	"\tInitReply\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x120\n" +
+	"\x13stateLockIdentifier\x18\x02 \x01(\tR\x13stateLockIdentifier\"\xef\x01\n" +
+	"\x13MigrateStateRequest\x12\x1e\n" +
+	"\n" +
+	"tfInstance\x18\x01 \x01(\tR\n" +
+	"tfInstance\x12\x1e\n" +
+	"\n" +
+	"workingDir\x18\x02 \x01(\tR\n" +
+	"workingDir\x124\n" +
+	"\x15previousBackendConfig\x18\x03 \x01(\fR\x15previousBackendConfig\x12$\n" +
+	"\rbackendConfig\x18\x04 \x01(\fR\rbackendConfig\x12 \n" +
+	"\vmigrationID\x18\x05 \x01(\tR\vmigrationID\x12\x1a\n" +
+	"\brevision\x18\x06 \x01(\tR\brevision\"\x7f\n" +
+	"\x11MigrateStateReply\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x120\n" +
+	"\x13stateLockIdentifier\x18\x02 \x01(\tR\x13stateLockIdentifier\x12\x1e\n" +
+	"\n" +
+	"backupName\x18\x03 \x01(\tR\n" +
+	"backupName\"2\n" +
+	"\x10StatePullRequest\x12\x1e\n" +
+	"\n" +
+	"tfInstance\x18\x01 \x01(\tR\n" +
+	"tfInstance\"&\n" +
+	"\x0eStatePullReply\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\fR\x05state\"^\n" +
+	"\x10StatePushRequest\x12\x1e\n" +
+	"\n" +
+	"tfInstance\x18\x01 \x01(\tR\n" +
+	"tfInstance\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\fR\x05state\x12\x14\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\"\\\n" +
+	"\x0eStatePushReply\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x120\n" +
+	"\x13stateLockIdentifier\x18\x02 \x01(\tR\x13stateLockIdentifier\"P\n" +
+	"\x10StateListRequest\x12\x1e\n" +
+	"\n" +
+	"tfInstance\x18\x01 \x01(\tR\n" +
+	"tfInstance\x12\x1c\n" +
+	"\taddresses\x18\x02 \x03(\tR\taddresses\".\n" +
+	"\x0eStateListReply\x12\x1c\n" +
+	"\taddresses\x18\x01 \x03(\tR\taddresses\"l\n" +
+	"\x10StateMoveRequest\x12\x1e\n" +
+	"\n" +
+	"tfInstance\x18\x01 \x01(\tR\n" +
+	"tfInstance\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12 \n" +
+	"\vdestination\x18\x03 \x01(\tR\vdestination\"\\\n" +
+	"\x0eStateMoveReply\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x120\n" +
+	"\x13stateLockIdentifier\x18\x02 \x01(\tR\x13stateLockIdentifier\"R\n" +
+	"\x12StateRemoveRequest\x12\x1e\n" +
+	"\n" +
+	"tfInstance\x18\x01 \x01(\tR\n" +
+	"tfInstance\x12\x1c\n" +
+	"\taddresses\x18\x02 \x03(\tR\taddresses\"\x8a\x01\n" +
+	"\x10StateRemoveReply\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x120\n" +
+	"\x13stateLockIdentifier\x18\x02 \x01(\tR\x13stateLockIdentifier\x12*\n" +
+	"\x10removedAddresses\x18\x03 \x03(\tR\x10removedAddresses\"Y\n" +
+	"\rImportRequest\x12\x1e\n" +
+	"\n" +
+	"tfInstance\x18\x01 \x01(\tR\n" +
+	"tfInstance\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\"Y\n" +
+	"\vImportReply\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x120\n" +
 	"\x13stateLockIdentifier\x18\x02 \x01(\tR\x13stateLockIdentifier\"2\n" +
 	"\x10WorkspaceRequest\x12\x1e\n" +
 	"\n" +
@@ -3392,7 +5175,7 @@ const file_runner_runner_proto_rawDesc = "" +
 	"\x14BreakTheGlassRequest\"H\n" +
 	"\x12BreakTheGlassReply\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess2\xd4\x10\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess2\x99\x17\n" +
 	"\x06Runner\x12<\n" +
 	"\bLookPath\x12\x17.runner.LookPathRequest\x1a\x15.runner.LookPathReply\"\x00\x12H\n" +
 	"\fNewTerraform\x12\x1b.runner.NewTerraformRequest\x1a\x19.runner.NewTerraformReply\"\x00\x126\n" +
@@ -3404,7 +5187,11 @@ const file_runner_runner_proto_rawDesc = "" +
 	"\x12WriteBackendConfig\x12!.runner.WriteBackendConfigRequest\x1a\x1f.runner.WriteBackendConfigReply\"\x00\x12T\n" +
 	"\x10ProcessCliConfig\x12\x1f.runner.ProcessCliConfigRequest\x1a\x1d.runner.ProcessCliConfigReply\"\x00\x12W\n" +
 	"\x11GenerateVarsForTF\x12 .runner.GenerateVarsForTFRequest\x1a\x1e.runner.GenerateVarsForTFReply\"\x00\x12T\n" +
-	"\x10GenerateTemplate\x12\x1f.runner.GenerateTemplateRequest\x1a\x1d.runner.GenerateTemplateReply\"\x00\x120\n" +
+	"\x10GenerateTemplate\x12\x1f.runner.GenerateTemplateRequest\x1a\x1d.runner.GenerateTemplateReply\"\x00\x12i\n" +
+	"\x17GenerateImportsAndMoves\x12&.runner.GenerateImportsAndMovesRequest\x1a$.runner.GenerateImportsAndMovesReply\"\x00\x12<\n" +
+	"\bValidate\x12\x17.runner.ValidateRequest\x1a\x15.runner.ValidateReply\"\x00\x120\n" +
+	"\x04Test\x12\x13.runner.TestRequest\x1a\x11.runner.TestReply\"\x00\x12N\n" +
+	"\x0eCheckVariables\x12\x1d.runner.CheckVariablesRequest\x1a\x1b.runner.CheckVariablesReply\"\x00\x120\n" +
 	"\x04Plan\x12\x13.runner.PlanRequest\x1a\x11.runner.PlanReply\"\x00\x12Q\n" +
 	"\x0fShowPlanFileRaw\x12\x1e.runner.ShowPlanFileRawRequest\x1a\x1c.runner.ShowPlanFileRawReply\"\x00\x12H\n" +
 	"\fShowPlanFile\x12\x1b.runner.ShowPlanFileRequest\x1a\x19.runner.ShowPlanFileReply\"\x00\x12B\n" +
@@ -3416,10 +5203,18 @@ const file_runner_runner_proto_rawDesc = "" +
 	"\fGetInventory\x12\x1b.runner.GetInventoryRequest\x1a\x19.runner.GetInventoryReply\"\x00\x129\n" +
 	"\aDestroy\x12\x16.runner.DestroyRequest\x1a\x14.runner.DestroyReply\"\x00\x126\n" +
 	"\x06Output\x12\x15.runner.OutputRequest\x1a\x13.runner.OutputReply\"\x00\x12H\n" +
-	"\fWriteOutputs\x12\x1b.runner.WriteOutputsRequest\x1a\x19.runner.WriteOutputsReply\"\x00\x12B\n" +
+	"\fWriteOutputs\x12\x1b.runner.WriteOutputsRequest\x1a\x19.runner.WriteOutputsReply\"\x00\x12K\n" +
+	"\rDeleteOutputs\x12\x1c.runner.DeleteOutputsRequest\x1a\x1a.runner.DeleteOutputsReply\"\x00\x12B\n" +
 	"\n" +
 	"GetOutputs\x12\x19.runner.GetOutputsRequest\x1a\x17.runner.GetOutputsReply\"\x00\x120\n" +
-	"\x04Init\x12\x13.runner.InitRequest\x1a\x11.runner.InitReply\"\x00\x12E\n" +
+	"\x04Init\x12\x13.runner.InitRequest\x1a\x11.runner.InitReply\"\x00\x12H\n" +
+	"\fMigrateState\x12\x1b.runner.MigrateStateRequest\x1a\x19.runner.MigrateStateReply\"\x00\x12?\n" +
+	"\tStatePull\x12\x18.runner.StatePullRequest\x1a\x16.runner.StatePullReply\"\x00\x12?\n" +
+	"\tStatePush\x12\x18.runner.StatePushRequest\x1a\x16.runner.StatePushReply\"\x00\x12?\n" +
+	"\tStateList\x12\x18.runner.StateListRequest\x1a\x16.runner.StateListReply\"\x00\x12?\n" +
+	"\tStateMove\x12\x18.runner.StateMoveRequest\x1a\x16.runner.StateMoveReply\"\x00\x12E\n" +
+	"\vStateRemove\x12\x1a.runner.StateRemoveRequest\x1a\x18.runner.StateRemoveReply\"\x00\x126\n" +
+	"\x06Import\x12\x15.runner.ImportRequest\x1a\x13.runner.ImportReply\"\x00\x12E\n" +
 	"\x0fSelectWorkspace\x12\x18.runner.WorkspaceRequest\x1a\x16.runner.WorkspaceReply\"\x00\x12]\n" +
 	"\x13CreateWorkspaceBlob\x12\".runner.CreateWorkspaceBlobRequest\x1a .runner.CreateWorkspaceBlobReply\"\x00\x126\n" +
 	"\x06Upload\x12\x15.runner.UploadRequest\x1a\x13.runner.UploadReply\"\x00\x12Q\n" +
@@ -3440,147 +5235,205 @@ func file_runner_runner_proto_rawDescGZIP() []byte {
 	return file_runner_runner_proto_rawDescData
 }
 
-var file_runner_runner_proto_msgTypes = make([]protoimpl.MessageInfo, 65)
+var file_runner_runner_proto_msgTypes = make([]protoimpl.MessageInfo, 94)
 var file_runner_runner_proto_goTypes = []any{
-	(*LookPathRequest)(nil),            // 0: runner.LookPathRequest
-	(*LookPathReply)(nil),              // 1: runner.LookPathReply
-	(*NewTerraformRequest)(nil),        // 2: runner.NewTerraformRequest
-	(*NewTerraformReply)(nil),          // 3: runner.NewTerraformReply
-	(*SetEnvRequest)(nil),              // 4: runner.SetEnvRequest
-	(*SetEnvReply)(nil),                // 5: runner.SetEnvReply
-	(*FileMapping)(nil),                // 6: runner.fileMapping
-	(*CreateFileMappingsRequest)(nil),  // 7: runner.CreateFileMappingsRequest
-	(*CreateFileMappingsReply)(nil),    // 8: runner.CreateFileMappingsReply
-	(*UploadAndExtractRequest)(nil),    // 9: runner.UploadAndExtractRequest
-	(*UploadAndExtractReply)(nil),      // 10: runner.UploadAndExtractReply
-	(*CleanupDirRequest)(nil),          // 11: runner.CleanupDirRequest
-	(*CleanupDirReply)(nil),            // 12: runner.CleanupDirReply
-	(*WriteBackendConfigRequest)(nil),  // 13: runner.WriteBackendConfigRequest
-	(*WriteBackendConfigReply)(nil),    // 14: runner.WriteBackendConfigReply
-	(*ProcessCliConfigRequest)(nil),    // 15: runner.ProcessCliConfigRequest
-	(*ProcessCliConfigReply)(nil),      // 16: runner.ProcessCliConfigReply
-	(*GenerateVarsForTFRequest)(nil),   // 17: runner.GenerateVarsForTFRequest
-	(*GenerateVarsForTFReply)(nil),     // 18: runner.GenerateVarsForTFReply
-	(*GenerateTemplateRequest)(nil),    // 19: runner.GenerateTemplateRequest
-	(*GenerateTemplateReply)(nil),      // 20: runner.GenerateTemplateReply
-	(*PlanRequest)(nil),                // 21: runner.PlanRequest
-	(*PlanReply)(nil),                  // 22: runner.PlanReply
-	(*ShowPlanFileRequest)(nil),        // 23: runner.ShowPlanFileRequest
-	(*ShowPlanFileReply)(nil),          // 24: runner.ShowPlanFileReply
-	(*ShowPlanFileRawRequest)(nil),     // 25: runner.ShowPlanFileRawRequest
-	(*ShowPlanFileRawReply)(nil),       // 26: runner.ShowPlanFileRawReply
-	(*SaveTFPlanRequest)(nil),          // 27: runner.SaveTFPlanRequest
-	(*SaveTFPlanReply)(nil),            // 28: runner.SaveTFPlanReply
-	(*LoadTFPlanRequest)(nil),          // 29: runner.LoadTFPlanRequest
-	(*LoadTFPlanReply)(nil),            // 30: runner.LoadTFPlanReply
-	(*ApplyRequest)(nil),               // 31: runner.ApplyRequest
-	(*ApplyReply)(nil),                 // 32: runner.ApplyReply
-	(*GetInventoryRequest)(nil),        // 33: runner.GetInventoryRequest
-	(*GetInventoryReply)(nil),          // 34: runner.GetInventoryReply
-	(*Inventory)(nil),                  // 35: runner.Inventory
-	(*DestroyRequest)(nil),             // 36: runner.DestroyRequest
-	(*DestroyReply)(nil),               // 37: runner.DestroyReply
-	(*OutputRequest)(nil),              // 38: runner.OutputRequest
-	(*OutputReply)(nil),                // 39: runner.OutputReply
-	(*OutputMeta)(nil),                 // 40: runner.OutputMeta
-	(*WriteOutputsRequest)(nil),        // 41: runner.WriteOutputsRequest
-	(*WriteOutputsReply)(nil),          // 42: runner.WriteOutputsReply
-	(*GetOutputsRequest)(nil),          // 43: runner.GetOutputsRequest
-	(*GetOutputsReply)(nil),            // 44: runner.GetOutputsReply
-	(*InitRequest)(nil),                // 45: runner.InitRequest
-	(*InitReply)(nil),                  // 46: runner.InitReply
-	(*WorkspaceRequest)(nil),           // 47: runner.WorkspaceRequest
-	(*WorkspaceReply)(nil),             // 48: runner.WorkspaceReply
-	(*CreateWorkspaceBlobRequest)(nil), // 49: runner.CreateWorkspaceBlobRequest
-	(*CreateWorkspaceBlobReply)(nil),   // 50: runner.CreateWorkspaceBlobReply
-	(*UploadRequest)(nil),              // 51: runner.UploadRequest
-	(*UploadReply)(nil),                // 52: runner.UploadReply
-	(*FinalizeSecretsRequest)(nil),     // 53: runner.FinalizeSecretsRequest
-	(*FinalizeSecretsReply)(nil),       // 54: runner.FinalizeSecretsReply
-	(*ForceUnlockRequest)(nil),         // 55: runner.ForceUnlockRequest
-	(*ForceUnlockReply)(nil),           // 56: runner.ForceUnlockReply
-	(*BreakTheGlassRequest)(nil),       // 57: runner.BreakTheGlassRequest
-	(*BreakTheGlassReply)(nil),         // 58: runner.BreakTheGlassReply
-	nil,                                // 59: runner.SetEnvRequest.EnvsEntry
-	nil,                                // 60: runner.OutputReply.OutputsEntry
-	nil,                                // 61: runner.WriteOutputsRequest.DataEntry
-	nil,                                // 62: runner.WriteOutputsRequest.LabelsEntry
-	nil,                                // 63: runner.WriteOutputsRequest.AnnotationsEntry
-	nil,                                // 64: runner.GetOutputsReply.OutputsEntry
+	(*LookPathRequest)(nil),                // 0: runner.LookPathRequest
+	(*LookPathReply)(nil),                  // 1: runner.LookPathReply
+	(*NewTerraformRequest)(nil),            // 2: runner.NewTerraformRequest
+	(*NewTerraformReply)(nil),              // 3: runner.NewTerraformReply
+	(*SetEnvRequest)(nil),                  // 4: runner.SetEnvRequest
+	(*SetEnvReply)(nil),                    // 5: runner.SetEnvReply
+	(*FileMapping)(nil),                    // 6: runner.fileMapping
+	(*CreateFileMappingsRequest)(nil),      // 7: runner.CreateFileMappingsRequest
+	(*CreateFileMappingsReply)(nil),        // 8: runner.CreateFileMappingsReply
+	(*UploadAndExtractRequest)(nil),        // 9: runner.UploadAndExtractRequest
+	(*AdditionalArtifact)(nil),             // 10: runner.AdditionalArtifact
+	(*UploadAndExtractReply)(nil),          // 11: runner.UploadAndExtractReply
+	(*CleanupDirRequest)(nil),              // 12: runner.CleanupDirRequest
+	(*CleanupDirReply)(nil),                // 13: runner.CleanupDirReply
+	(*WriteBackendConfigRequest)(nil),      // 14: runner.WriteBackendConfigRequest
+	(*WriteBackendConfigReply)(nil),        // 15: runner.WriteBackendConfigReply
+	(*ProcessCliConfigRequest)(nil),        // 16: runner.ProcessCliConfigRequest
+	(*ProcessCliConfigReply)(nil),          // 17: runner.ProcessCliConfigReply
+	(*GenerateVarsForTFRequest)(nil),       // 18: runner.GenerateVarsForTFRequest
+	(*TerraformOutputs)(nil),               // 19: runner.TerraformOutputs
+	(*GenerateVarsForTFReply)(nil),         // 20: runner.GenerateVarsForTFReply
+	(*GenerateTemplateRequest)(nil),        // 21: runner.GenerateTemplateRequest
+	(*GenerateTemplateReply)(nil),          // 22: runner.GenerateTemplateReply
+	(*GenerateImportsAndMovesRequest)(nil), // 23: runner.GenerateImportsAndMovesRequest
+	(*GenerateImportsAndMovesReply)(nil),   // 24: runner.GenerateImportsAndMovesReply
+	(*ValidateRequest)(nil),                // 25: runner.ValidateRequest
+	(*ValidateReply)(nil),                  // 26: runner.ValidateReply
+	(*TestRequest)(nil),                    // 27: runner.TestRequest
+	(*TestReply)(nil),                      // 28: runner.TestReply
+	(*CheckVariablesRequest)(nil),          // 29: runner.CheckVariablesRequest
+	(*CheckVariablesReply)(nil),            // 30: runner.CheckVariablesReply
+	(*VariableSchema)(nil),                 // 31: runner.VariableSchema
+	(*PlanRequest)(nil),                    // 32: runner.PlanRequest
+	(*PlanReply)(nil),                      // 33: runner.PlanReply
+	(*ShowPlanFileRequest)(nil),            // 34: runner.ShowPlanFileRequest
+	(*ShowPlanFileReply)(nil),              // 35: runner.ShowPlanFileReply
+	(*ShowPlanFileRawRequest)(nil),         // 36: runner.ShowPlanFileRawRequest
+	(*ShowPlanFileRawReply)(nil),           // 37: runner.ShowPlanFileRawReply
+	(*SaveTFPlanRequest)(nil),              // 38: runner.SaveTFPlanRequest
+	(*SaveTFPlanReply)(nil),                // 39: runner.SaveTFPlanReply
+	(*LoadTFPlanRequest)(nil),              // 40: runner.LoadTFPlanRequest
+	(*LoadTFPlanReply)(nil),                // 41: runner.LoadTFPlanReply
+	(*ApplyRequest)(nil),                   // 42: runner.ApplyRequest
+	(*ApplyReply)(nil),                     // 43: runner.ApplyReply
+	(*GetInventoryRequest)(nil),            // 44: runner.GetInventoryRequest
+	(*GetInventoryReply)(nil),              // 45: runner.GetInventoryReply
+	(*Inventory)(nil),                      // 46: runner.Inventory
+	(*DestroyRequest)(nil),                 // 47: runner.DestroyRequest
+	(*DestroyReply)(nil),                   // 48: runner.DestroyReply
+	(*OutputRequest)(nil),                  // 49: runner.OutputRequest
+	(*OutputReply)(nil),                    // 50: runner.OutputReply
+	(*OutputMeta)(nil),                     // 51: runner.OutputMeta
+	(*WriteOutputsRequest)(nil),            // 52: runner.WriteOutputsRequest
+	(*DeleteOutputsRequest)(nil),           // 53: runner.DeleteOutputsRequest
+	(*DeleteOutputsReply)(nil),             // 54: runner.DeleteOutputsReply
+	(*WriteOutputsReply)(nil),              // 55: runner.WriteOutputsReply
+	(*GetOutputsRequest)(nil),              // 56: runner.GetOutputsRequest
+	(*GetOutputsReply)(nil),                // 57: runner.GetOutputsReply
+	(*InitRequest)(nil),                    // 58: runner.InitRequest
+	(*InitReply)(nil),                      // 59: runner.InitReply
+	(*MigrateStateRequest)(nil),            // 60: runner.MigrateStateRequest
+	(*MigrateStateReply)(nil),              // 61: runner.MigrateStateReply
+	(*StatePullRequest)(nil),               // 62: runner.StatePullRequest
+	(*StatePullReply)(nil),                 // 63: runner.StatePullReply
+	(*StatePushRequest)(nil),               // 64: runner.StatePushRequest
+	(*StatePushReply)(nil),                 // 65: runner.StatePushReply
+	(*StateListRequest)(nil),               // 66: runner.StateListRequest
+	(*StateListReply)(nil),                 // 67: runner.StateListReply
+	(*StateMoveRequest)(nil),               // 68: runner.StateMoveRequest
+	(*StateMoveReply)(nil),                 // 69: runner.StateMoveReply
+	(*StateRemoveRequest)(nil),             // 70: runner.StateRemoveRequest
+	(*StateRemoveReply)(nil),               // 71: runner.StateRemoveReply
+	(*ImportRequest)(nil),                  // 72: runner.ImportRequest
+	(*ImportReply)(nil),                    // 73: runner.ImportReply
+	(*WorkspaceRequest)(nil),               // 74: runner.WorkspaceRequest
+	(*WorkspaceReply)(nil),                 // 75: runner.WorkspaceReply
+	(*CreateWorkspaceBlobRequest)(nil),     // 76: runner.CreateWorkspaceBlobRequest
+	(*CreateWorkspaceBlobReply)(nil),       // 77: runner.CreateWorkspaceBlobReply
+	(*UploadRequest)(nil),                  // 78: runner.UploadRequest
+	(*UploadReply)(nil),                    // 79: runner.UploadReply
+	(*FinalizeSecretsRequest)(nil),         // 80: runner.FinalizeSecretsRequest
+	(*FinalizeSecretsReply)(nil),           // 81: runner.FinalizeSecretsReply
+	(*ForceUnlockRequest)(nil),             // 82: runner.ForceUnlockRequest
+	(*ForceUnlockReply)(nil),               // 83: runner.ForceUnlockReply
+	(*BreakTheGlassRequest)(nil),           // 84: runner.BreakTheGlassRequest
+	(*BreakTheGlassReply)(nil),             // 85: runner.BreakTheGlassReply
+	nil,                                    // 86: runner.SetEnvRequest.EnvsEntry
+	nil,                                    // 87: runner.GenerateVarsForTFRequest.TerraformOutputsEntry
+	nil,                                    // 88: runner.TerraformOutputs.OutputsEntry
+	nil,                                    // 89: runner.OutputReply.OutputsEntry
+	nil,                                    // 90: runner.WriteOutputsRequest.DataEntry
+	nil,                                    // 91: runner.WriteOutputsRequest.LabelsEntry
+	nil,                                    // 92: runner.WriteOutputsRequest.AnnotationsEntry
+	nil,                                    // 93: runner.GetOutputsReply.OutputsEntry
 }
 var file_runner_runner_proto_depIdxs = []int32{
-	59, // 0: runner.SetEnvRequest.envs:type_name -> runner.SetEnvRequest.EnvsEntry
+	86, // 0: runner.SetEnvRequest.envs:type_name -> runner.SetEnvRequest.EnvsEntry
 	6,  // 1: runner.CreateFileMappingsRequest.fileMappings:type_name -> runner.fileMapping
-	35, // 2: runner.GetInventoryReply.inventories:type_name -> runner.Inventory
-	60, // 3: runner.OutputReply.outputs:type_name -> runner.OutputReply.OutputsEntry
-	61, // 4: runner.WriteOutputsRequest.data:type_name -> runner.WriteOutputsRequest.DataEntry
-	62, // 5: runner.WriteOutputsRequest.labels:type_name -> runner.WriteOutputsRequest.LabelsEntry
-	63, // 6: runner.WriteOutputsRequest.annotations:type_name -> runner.WriteOutputsRequest.AnnotationsEntry
-	64, // 7: runner.GetOutputsReply.outputs:type_name -> runner.GetOutputsReply.OutputsEntry
-	40, // 8: runner.OutputReply.OutputsEntry.value:type_name -> runner.OutputMeta
-	0,  // 9: runner.Runner.LookPath:input_type -> runner.LookPathRequest
-	2,  // 10: runner.Runner.NewTerraform:input_type -> runner.NewTerraformRequest
-	4,  // 11: runner.Runner.SetEnv:input_type -> runner.SetEnvRequest
-	7,  // 12: runner.Runner.CreateFileMappings:input_type -> runner.CreateFileMappingsRequest
-	9,  // 13: runner.Runner.UploadAndExtract:input_type -> runner.UploadAndExtractRequest
-	11, // 14: runner.Runner.CleanupDir:input_type -> runner.CleanupDirRequest
-	13, // 15: runner.Runner.WriteBackendConfig:input_type -> runner.WriteBackendConfigRequest
-	15, // 16: runner.Runner.ProcessCliConfig:input_type -> runner.ProcessCliConfigRequest
-	17, // 17: runner.Runner.GenerateVarsForTF:input_type -> runner.GenerateVarsForTFRequest
-	19, // 18: runner.Runner.GenerateTemplate:input_type -> runner.GenerateTemplateRequest
-	21, // 19: runner.Runner.Plan:input_type -> runner.PlanRequest
-	25, // 20: runner.Runner.ShowPlanFileRaw:input_type -> runner.ShowPlanFileRawRequest
-	23, // 21: runner.Runner.ShowPlanFile:input_type -> runner.ShowPlanFileRequest
-	27, // 22: runner.Runner.SaveTFPlan:input_type -> runner.SaveTFPlanRequest
-	29, // 23: runner.Runner.LoadTFPlan:input_type -> runner.LoadTFPlanRequest
-	31, // 24: runner.Runner.Apply:input_type -> runner.ApplyRequest
-	33, // 25: runner.Runner.GetInventory:input_type -> runner.GetInventoryRequest
-	36, // 26: runner.Runner.Destroy:input_type -> runner.DestroyRequest
-	38, // 27: runner.Runner.Output:input_type -> runner.OutputRequest
-	41, // 28: runner.Runner.WriteOutputs:input_type -> runner.WriteOutputsRequest
-	43, // 29: runner.Runner.GetOutputs:input_type -> runner.GetOutputsRequest
-	45, // 30: runner.Runner.Init:input_type -> runner.InitRequest
-	47, // 31: runner.Runner.SelectWorkspace:input_type -> runner.WorkspaceRequest
-	49, // 32: runner.Runner.CreateWorkspaceBlob:input_type -> runner.CreateWorkspaceBlobRequest
-	51, // 33: runner.Runner.Upload:input_type -> runner.UploadRequest
-	53, // 34: runner.Runner.FinalizeSecrets:input_type -> runner.FinalizeSecretsRequest
-	55, // 35: runner.Runner.ForceUnlock:input_type -> runner.ForceUnlockRequest
-	57, // 36: runner.Runner.StartBreakTheGlassSession:input_type -> runner.BreakTheGlassRequest
-	57, // 37: runner.Runner.HasBreakTheGlassSessionDone:input_type -> runner.BreakTheGlassRequest
-	1,  // 38: runner.Runner.LookPath:output_type -> runner.LookPathReply
-	3,  // 39: runner.Runner.NewTerraform:output_type -> runner.NewTerraformReply
-	5,  // 40: runner.Runner.SetEnv:output_type -> runner.SetEnvReply
-	8,  // 41: runner.Runner.CreateFileMappings:output_type -> runner.CreateFileMappingsReply
-	10, // 42: runner.Runner.UploadAndExtract:output_type -> runner.UploadAndExtractReply
-	12, // 43: runner.Runner.CleanupDir:output_type -> runner.CleanupDirReply
-	14, // 44: runner.Runner.WriteBackendConfig:output_type -> runner.WriteBackendConfigReply
-	16, // 45: runner.Runner.ProcessCliConfig:output_type -> runner.ProcessCliConfigReply
-	18, // 46: runner.Runner.GenerateVarsForTF:output_type -> runner.GenerateVarsForTFReply
-	20, // 47: runner.Runner.GenerateTemplate:output_type -> runner.GenerateTemplateReply
-	22, // 48: runner.Runner.Plan:output_type -> runner.PlanReply
-	26, // 49: runner.Runner.ShowPlanFileRaw:output_type -> runner.ShowPlanFileRawReply
-	24, // 50: runner.Runner.ShowPlanFile:output_type -> runner.ShowPlanFileReply
-	28, // 51: runner.Runner.SaveTFPlan:output_type -> runner.SaveTFPlanReply
-	30, // 52: runner.Runner.LoadTFPlan:output_type -> runner.LoadTFPlanReply
-	32, // 53: runner.Runner.Apply:output_type -> runner.ApplyReply
-	34, // 54: runner.Runner.GetInventory:output_type -> runner.GetInventoryReply
-	37, // 55: runner.Runner.Destroy:output_type -> runner.DestroyReply
-	39, // 56: runner.Runner.Output:output_type -> runner.OutputReply
-	42, // 57: runner.Runner.WriteOutputs:output_type -> runner.WriteOutputsReply
-	44, // 58: runner.Runner.GetOutputs:output_type -> runner.GetOutputsReply
-	46, // 59: runner.Runner.Init:output_type -> runner.InitReply
-	48, // 60: runner.Runner.SelectWorkspace:output_type -> runner.WorkspaceReply
-	50, // 61: runner.Runner.CreateWorkspaceBlob:output_type -> runner.CreateWorkspaceBlobReply
-	52, // 62: runner.Runner.Upload:output_type -> runner.UploadReply
-	54, // 63: runner.Runner.FinalizeSecrets:output_type -> runner.FinalizeSecretsReply
-	56, // 64: runner.Runner.ForceUnlock:output_type -> runner.ForceUnlockReply
-	58, // 65: runner.Runner.StartBreakTheGlassSession:output_type -> runner.BreakTheGlassReply
-	58, // 66: runner.Runner.HasBreakTheGlassSessionDone:output_type -> runner.BreakTheGlassReply
-	38, // [38:67] is the sub-list for method output_type
-	9,  // [9:38] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	10, // 2: runner.UploadAndExtractRequest.additionalArtifacts:type_name -> runner.AdditionalArtifact
+	87, // 3: runner.GenerateVarsForTFRequest.terraformOutputs:type_name -> runner.GenerateVarsForTFRequest.TerraformOutputsEntry
+	88, // 4: runner.TerraformOutputs.outputs:type_name -> runner.TerraformOutputs.OutputsEntry
+	31, // 5: runner.CheckVariablesReply.variables:type_name -> runner.VariableSchema
+	46, // 6: runner.GetInventoryReply.inventories:type_name -> runner.Inventory
+	89, // 7: runner.OutputReply.outputs:type_name -> runner.OutputReply.OutputsEntry
+	90, // 8: runner.WriteOutputsRequest.data:type_name -> runner.WriteOutputsRequest.DataEntry
+	91, // 9: runner.WriteOutputsRequest.labels:type_name -> runner.WriteOutputsRequest.LabelsEntry
+	92, // 10: runner.WriteOutputsRequest.annotations:type_name -> runner.WriteOutputsRequest.AnnotationsEntry
+	93, // 11: runner.GetOutputsReply.outputs:type_name -> runner.GetOutputsReply.OutputsEntry
+	19, // 12: runner.GenerateVarsForTFRequest.TerraformOutputsEntry.value:type_name -> runner.TerraformOutputs
+	51, // 13: runner.OutputReply.OutputsEntry.value:type_name -> runner.OutputMeta
+	0,  // 14: runner.Runner.LookPath:input_type -> runner.LookPathRequest
+	2,  // 15: runner.Runner.NewTerraform:input_type -> runner.NewTerraformRequest
+	4,  // 16: runner.Runner.SetEnv:input_type -> runner.SetEnvRequest
+	7,  // 17: runner.Runner.CreateFileMappings:input_type -> runner.CreateFileMappingsRequest
+	9,  // 18: runner.Runner.UploadAndExtract:input_type -> runner.UploadAndExtractRequest
+	12, // 19: runner.Runner.CleanupDir:input_type -> runner.CleanupDirRequest
+	14, // 20: runner.Runner.WriteBackendConfig:input_type -> runner.WriteBackendConfigRequest
+	16, // 21: runner.Runner.ProcessCliConfig:input_type -> runner.ProcessCliConfigRequest
+	18, // 22: runner.Runner.GenerateVarsForTF:input_type -> runner.GenerateVarsForTFRequest
+	21, // 23: runner.Runner.GenerateTemplate:input_type -> runner.GenerateTemplateRequest
+	23, // 24: runner.Runner.GenerateImportsAndMoves:input_type -> runner.GenerateImportsAndMovesRequest
+	25, // 25: runner.Runner.Validate:input_type -> runner.ValidateRequest
+	27, // 26: runner.Runner.Test:input_type -> runner.TestRequest
+	29, // 27: runner.Runner.CheckVariables:input_type -> runner.CheckVariablesRequest
+	32, // 28: runner.Runner.Plan:input_type -> runner.PlanRequest
+	36, // 29: runner.Runner.ShowPlanFileRaw:input_type -> runner.ShowPlanFileRawRequest
+	34, // 30: runner.Runner.ShowPlanFile:input_type -> runner.ShowPlanFileRequest
+	38, // 31: runner.Runner.SaveTFPlan:input_type -> runner.SaveTFPlanRequest
+	40, // 32: runner.Runner.LoadTFPlan:input_type -> runner.LoadTFPlanRequest
+	42, // 33: runner.Runner.Apply:input_type -> runner.ApplyRequest
+	44, // 34: runner.Runner.GetInventory:input_type -> runner.GetInventoryRequest
+	47, // 35: runner.Runner.Destroy:input_type -> runner.DestroyRequest
+	49, // 36: runner.Runner.Output:input_type -> runner.OutputRequest
+	52, // 37: runner.Runner.WriteOutputs:input_type -> runner.WriteOutputsRequest
+	53, // 38: runner.Runner.DeleteOutputs:input_type -> runner.DeleteOutputsRequest
+	56, // 39: runner.Runner.GetOutputs:input_type -> runner.GetOutputsRequest
+	58, // 40: runner.Runner.Init:input_type -> runner.InitRequest
+	60, // 41: runner.Runner.MigrateState:input_type -> runner.MigrateStateRequest
+	62, // 42: runner.Runner.StatePull:input_type -> runner.StatePullRequest
+	64, // 43: runner.Runner.StatePush:input_type -> runner.StatePushRequest
+	66, // 44: runner.Runner.StateList:input_type -> runner.StateListRequest
+	68, // 45: runner.Runner.StateMove:input_type -> runner.StateMoveRequest
+	70, // 46: runner.Runner.StateRemove:input_type -> runner.StateRemoveRequest
+	72, // 47: runner.Runner.Import:input_type -> runner.ImportRequest
+	74, // 48: runner.Runner.SelectWorkspace:input_type -> runner.WorkspaceRequest
+	76, // 49: runner.Runner.CreateWorkspaceBlob:input_type -> runner.CreateWorkspaceBlobRequest
+	78, // 50: runner.Runner.Upload:input_type -> runner.UploadRequest
+	80, // 51: runner.Runner.FinalizeSecrets:input_type -> runner.FinalizeSecretsRequest
+	82, // 52: runner.Runner.ForceUnlock:input_type -> runner.ForceUnlockRequest
+	84, // 53: runner.Runner.StartBreakTheGlassSession:input_type -> runner.BreakTheGlassRequest
+	84, // 54: runner.Runner.HasBreakTheGlassSessionDone:input_type -> runner.BreakTheGlassRequest
+	1,  // 55: runner.Runner.LookPath:output_type -> runner.LookPathReply
+	3,  // 56: runner.Runner.NewTerraform:output_type -> runner.NewTerraformReply
+	5,  // 57: runner.Runner.SetEnv:output_type -> runner.SetEnvReply
+	8,  // 58: runner.Runner.CreateFileMappings:output_type -> runner.CreateFileMappingsReply
+	11, // 59: runner.Runner.UploadAndExtract:output_type -> runner.UploadAndExtractReply
+	13, // 60: runner.Runner.CleanupDir:output_type -> runner.CleanupDirReply
+	15, // 61: runner.Runner.WriteBackendConfig:output_type -> runner.WriteBackendConfigReply
+	17, // 62: runner.Runner.ProcessCliConfig:output_type -> runner.ProcessCliConfigReply
+	20, // 63: runner.Runner.GenerateVarsForTF:output_type -> runner.GenerateVarsForTFReply
+	22, // 64: runner.Runner.GenerateTemplate:output_type -> runner.GenerateTemplateReply
+	24, // 65: runner.Runner.GenerateImportsAndMoves:output_type -> runner.GenerateImportsAndMovesReply
+	26, // 66: runner.Runner.Validate:output_type -> runner.ValidateReply
+	28, // 67: runner.Runner.Test:output_type -> runner.TestReply
+	30, // 68: runner.Runner.CheckVariables:output_type -> runner.CheckVariablesReply
+	33, // 69: runner.Runner.Plan:output_type -> runner.PlanReply
+	37, // 70: runner.Runner.ShowPlanFileRaw:output_type -> runner.ShowPlanFileRawReply
+	35, // 71: runner.Runner.ShowPlanFile:output_type -> runner.ShowPlanFileReply
+	39, // 72: runner.Runner.SaveTFPlan:output_type -> runner.SaveTFPlanReply
+	41, // 73: runner.Runner.LoadTFPlan:output_type -> runner.LoadTFPlanReply
+	43, // 74: runner.Runner.Apply:output_type -> runner.ApplyReply
+	45, // 75: runner.Runner.GetInventory:output_type -> runner.GetInventoryReply
+	48, // 76: runner.Runner.Destroy:output_type -> runner.DestroyReply
+	50, // 77: runner.Runner.Output:output_type -> runner.OutputReply
+	55, // 78: runner.Runner.WriteOutputs:output_type -> runner.WriteOutputsReply
+	54, // 79: runner.Runner.DeleteOutputs:output_type -> runner.DeleteOutputsReply
+	57, // 80: runner.Runner.GetOutputs:output_type -> runner.GetOutputsReply
+	59, // 81: runner.Runner.Init:output_type -> runner.InitReply
+	61, // 82: runner.Runner.MigrateState:output_type -> runner.MigrateStateReply
+	63, // 83: runner.Runner.StatePull:output_type -> runner.StatePullReply
+	65, // 84: runner.Runner.StatePush:output_type -> runner.StatePushReply
+	67, // 85: runner.Runner.StateList:output_type -> runner.StateListReply
+	69, // 86: runner.Runner.StateMove:output_type -> runner.StateMoveReply
+	71, // 87: runner.Runner.StateRemove:output_type -> runner.StateRemoveReply
+	73, // 88: runner.Runner.Import:output_type -> runner.ImportReply
+	75, // 89: runner.Runner.SelectWorkspace:output_type -> runner.WorkspaceReply
+	77, // 90: runner.Runner.CreateWorkspaceBlob:output_type -> runner.CreateWorkspaceBlobReply
+	79, // 91: runner.Runner.Upload:output_type -> runner.UploadReply
+	81, // 92: runner.Runner.FinalizeSecrets:output_type -> runner.FinalizeSecretsReply
+	83, // 93: runner.Runner.ForceUnlock:output_type -> runner.ForceUnlockReply
+	85, // 94: runner.Runner.StartBreakTheGlassSession:output_type -> runner.BreakTheGlassReply
+	85, // 95: runner.Runner.HasBreakTheGlassSessionDone:output_type -> runner.BreakTheGlassReply
+	55, // [55:96] is the sub-list for method output_type
+	14, // [14:55] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_runner_runner_proto_init() }
@@ -3594,7 +5447,7 @@ func file_runner_runner_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_runner_runner_proto_rawDesc), len(file_runner_runner_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   65,
+			NumMessages:   94,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -29,6 +29,10 @@ const (
 	Runner_ProcessCliConfig_FullMethodName            = "/runner.Runner/ProcessCliConfig"
 	Runner_GenerateVarsForTF_FullMethodName           = "/runner.Runner/GenerateVarsForTF"
 	Runner_GenerateTemplate_FullMethodName            = "/runner.Runner/GenerateTemplate"
+	Runner_GenerateImportsAndMoves_FullMethodName     = "/runner.Runner/GenerateImportsAndMoves"
+	Runner_Validate_FullMethodName                    = "/runner.Runner/Validate"
+	Runner_Test_FullMethodName                        = "/runner.Runner/Test"
+	Runner_CheckVariables_FullMethodName              = "/runner.Runner/CheckVariables"
 	Runner_Plan_FullMethodName                        = "/runner.Runner/Plan"
 	Runner_ShowPlanFileRaw_FullMethodName             = "/runner.Runner/ShowPlanFileRaw"
 	Runner_ShowPlanFile_FullMethodName                = "/runner.Runner/ShowPlanFile"
@@ -39,8 +43,16 @@ const (
 	Runner_Destroy_FullMethodName                     = "/runner.Runner/Destroy"
 	Runner_Output_FullMethodName                      = "/runner.Runner/Output"
 	Runner_WriteOutputs_FullMethodName                = "/runner.Runner/WriteOutputs"
+	Runner_DeleteOutputs_FullMethodName               = "/runner.Runner/DeleteOutputs"
 	Runner_GetOutputs_FullMethodName                  = "/runner.Runner/GetOutputs"
 	Runner_Init_FullMethodName                        = "/runner.Runner/Init"
+	Runner_MigrateState_FullMethodName                = "/runner.Runner/MigrateState"
+	Runner_StatePull_FullMethodName                   = "/runner.Runner/StatePull"
+	Runner_StatePush_FullMethodName                   = "/runner.Runner/StatePush"
+	Runner_StateList_FullMethodName                   = "/runner.Runner/StateList"
+	Runner_StateMove_FullMethodName                   = "/runner.Runner/StateMove"
+	Runner_StateRemove_FullMethodName                 = "/runner.Runner/StateRemove"
+	Runner_Import_FullMethodName                      = "/runner.Runner/Import"
 	Runner_SelectWorkspace_FullMethodName             = "/runner.Runner/SelectWorkspace"
 	Runner_CreateWorkspaceBlob_FullMethodName         = "/runner.Runner/CreateWorkspaceBlob"
 	Runner_Upload_FullMethodName                      = "/runner.Runner/Upload"
@@ -64,6 +76,10 @@ type RunnerClient interface {
 	ProcessCliConfig(ctx context.Context, in *ProcessCliConfigRequest, opts ...grpc.CallOption) (*ProcessCliConfigReply, error)
 	GenerateVarsForTF(ctx context.Context, in *GenerateVarsForTFRequest, opts ...grpc.CallOption) (*GenerateVarsForTFReply, error)
 	GenerateTemplate(ctx context.Context, in *GenerateTemplateRequest, opts ...grpc.CallOption) (*GenerateTemplateReply, error)
+	GenerateImportsAndMoves(ctx context.Context, in *GenerateImportsAndMovesRequest, opts ...grpc.CallOption) (*GenerateImportsAndMovesReply, error)
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateReply, error)
+	Test(ctx context.Context, in *TestRequest, opts ...grpc.CallOption) (*TestReply, error)
+	CheckVariables(ctx context.Context, in *CheckVariablesRequest, opts ...grpc.CallOption) (*CheckVariablesReply, error)
 	Plan(ctx context.Context, in *PlanRequest, opts ...grpc.CallOption) (*PlanReply, error)
 	ShowPlanFileRaw(ctx context.Context, in *ShowPlanFileRawRequest, opts ...grpc.CallOption) (*ShowPlanFileRawReply, error)
 	ShowPlanFile(ctx context.Context, in *ShowPlanFileRequest, opts ...grpc.CallOption) (*ShowPlanFileReply, error)
@@ -74,8 +90,16 @@ type RunnerClient interface {
 	Destroy(ctx context.Context, in *DestroyRequest, opts ...grpc.CallOption) (*DestroyReply, error)
 	Output(ctx context.Context, in *OutputRequest, opts ...grpc.CallOption) (*OutputReply, error)
 	WriteOutputs(ctx context.Context, in *WriteOutputsRequest, opts ...grpc.CallOption) (*WriteOutputsReply, error)
+	DeleteOutputs(ctx context.Context, in *DeleteOutputsRequest, opts ...grpc.CallOption) (*DeleteOutputsReply, error)
 	GetOutputs(ctx context.Context, in *GetOutputsRequest, opts ...grpc.CallOption) (*GetOutputsReply, error)
 	Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*InitReply, error)
+	MigrateState(ctx context.Context, in *MigrateStateRequest, opts ...grpc.CallOption) (*MigrateStateReply, error)
+	StatePull(ctx context.Context, in *StatePullRequest, opts ...grpc.CallOption) (*StatePullReply, error)
+	StatePush(ctx context.Context, in *StatePushRequest, opts ...grpc.CallOption) (*StatePushReply, error)
+	StateList(ctx context.Context, in *StateListRequest, opts ...grpc.CallOption) (*StateListReply, error)
+	StateMove(ctx context.Context, in *StateMoveRequest, opts ...grpc.CallOption) (*StateMoveReply, error)
+	StateRemove(ctx context.Context, in *StateRemoveRequest, opts ...grpc.CallOption) (*StateRemoveReply, error)
+	Import(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*ImportReply, error)
 	SelectWorkspace(ctx context.Context, in *WorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceReply, error)
 	CreateWorkspaceBlob(ctx context.Context, in *CreateWorkspaceBlobRequest, opts ...grpc.CallOption) (*CreateWorkspaceBlobReply, error)
 	Upload(ctx context.Context, in *UploadRequest, opts ...grpc.CallOption) (*UploadReply, error)
@@ -193,6 +217,46 @@ func (c *runnerClient) GenerateTemplate(ctx context.Context, in *GenerateTemplat
 	return out, nil
 }
 
+func (c *runnerClient) GenerateImportsAndMoves(ctx context.Context, in *GenerateImportsAndMovesRequest, opts ...grpc.CallOption) (*GenerateImportsAndMovesReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateImportsAndMovesReply)
+	err := c.cc.Invoke(ctx, Runner_GenerateImportsAndMoves_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateReply)
+	err := c.cc.Invoke(ctx, Runner_Validate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) Test(ctx context.Context, in *TestRequest, opts ...grpc.CallOption) (*TestReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestReply)
+	err := c.cc.Invoke(ctx, Runner_Test_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) CheckVariables(ctx context.Context, in *CheckVariablesRequest, opts ...grpc.CallOption) (*CheckVariablesReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckVariablesReply)
+	err := c.cc.Invoke(ctx, Runner_CheckVariables_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runnerClient) Plan(ctx context.Context, in *PlanRequest, opts ...grpc.CallOption) (*PlanReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PlanReply)
@@ -293,6 +357,16 @@ func (c *runnerClient) WriteOutputs(ctx context.Context, in *WriteOutputsRequest
 	return out, nil
 }
 
+func (c *runnerClient) DeleteOutputs(ctx context.Context, in *DeleteOutputsRequest, opts ...grpc.CallOption) (*DeleteOutputsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteOutputsReply)
+	err := c.cc.Invoke(ctx, Runner_DeleteOutputs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runnerClient) GetOutputs(ctx context.Context, in *GetOutputsRequest, opts ...grpc.CallOption) (*GetOutputsReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetOutputsReply)
@@ -313,6 +387,76 @@ func (c *runnerClient) Init(ctx context.Context, in *InitRequest, opts ...grpc.C
 	return out, nil
 }
 
+func (c *runnerClient) MigrateState(ctx context.Context, in *MigrateStateRequest, opts ...grpc.CallOption) (*MigrateStateReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MigrateStateReply)
+	err := c.cc.Invoke(ctx, Runner_MigrateState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) StatePull(ctx context.Context, in *StatePullRequest, opts ...grpc.CallOption) (*StatePullReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatePullReply)
+	err := c.cc.Invoke(ctx, Runner_StatePull_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) StatePush(ctx context.Context, in *StatePushRequest, opts ...grpc.CallOption) (*StatePushReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatePushReply)
+	err := c.cc.Invoke(ctx, Runner_StatePush_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) StateList(ctx context.Context, in *StateListRequest, opts ...grpc.CallOption) (*StateListReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StateListReply)
+	err := c.cc.Invoke(ctx, Runner_StateList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) StateMove(ctx context.Context, in *StateMoveRequest, opts ...grpc.CallOption) (*StateMoveReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StateMoveReply)
+	err := c.cc.Invoke(ctx, Runner_StateMove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) StateRemove(ctx context.Context, in *StateRemoveRequest, opts ...grpc.CallOption) (*StateRemoveReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StateRemoveReply)
+	err := c.cc.Invoke(ctx, Runner_StateRemove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) Import(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*ImportReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportReply)
+	err := c.cc.Invoke(ctx, Runner_Import_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runnerClient) SelectWorkspace(ctx context.Context, in *WorkspaceRequest, opts ...grpc.CallOption) (*WorkspaceReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(WorkspaceReply)
@@ -397,6 +541,10 @@ type RunnerServer interface {
 	ProcessCliConfig(context.Context, *ProcessCliConfigRequest) (*ProcessCliConfigReply, error)
 	GenerateVarsForTF(context.Context, *GenerateVarsForTFRequest) (*GenerateVarsForTFReply, error)
 	GenerateTemplate(context.Context, *GenerateTemplateRequest) (*GenerateTemplateReply, error)
+	GenerateImportsAndMoves(context.Context, *GenerateImportsAndMovesRequest) (*GenerateImportsAndMovesReply, error)
+	Validate(context.Context, *ValidateRequest) (*ValidateReply, error)
+	Test(context.Context, *TestRequest) (*TestReply, error)
+	CheckVariables(context.Context, *CheckVariablesRequest) (*CheckVariablesReply, error)
 	Plan(context.Context, *PlanRequest) (*PlanReply, error)
 	ShowPlanFileRaw(context.Context, *ShowPlanFileRawRequest) (*ShowPlanFileRawReply, error)
 	ShowPlanFile(context.Context, *ShowPlanFileRequest) (*ShowPlanFileReply, error)
@@ -407,8 +555,16 @@ type RunnerServer interface {
 	Destroy(context.Context, *DestroyRequest) (*DestroyReply, error)
 	Output(context.Context, *OutputRequest) (*OutputReply, error)
 	WriteOutputs(context.Context, *WriteOutputsRequest) (*WriteOutputsReply, error)
+	DeleteOutputs(context.Context, *DeleteOutputsRequest) (*DeleteOutputsReply, error)
 	GetOutputs(context.Context, *GetOutputsRequest) (*GetOutputsReply, error)
 	Init(context.Context, *InitRequest) (*InitReply, error)
+	MigrateState(context.Context, *MigrateStateRequest) (*MigrateStateReply, error)
+	StatePull(context.Context, *StatePullRequest) (*StatePullReply, error)
+	StatePush(context.Context, *StatePushRequest) (*StatePushReply, error)
+	StateList(context.Context, *StateListRequest) (*StateListReply, error)
+	StateMove(context.Context, *StateMoveRequest) (*StateMoveReply, error)
+	StateRemove(context.Context, *StateRemoveRequest) (*StateRemoveReply, error)
+	Import(context.Context, *ImportRequest) (*ImportReply, error)
 	SelectWorkspace(context.Context, *WorkspaceRequest) (*WorkspaceReply, error)
 	CreateWorkspaceBlob(context.Context, *CreateWorkspaceBlobRequest) (*CreateWorkspaceBlobReply, error)
 	Upload(context.Context, *UploadRequest) (*UploadReply, error)
@@ -456,6 +612,18 @@ func (UnimplementedRunnerServer) GenerateVarsForTF(context.Context, *GenerateVar
 func (UnimplementedRunnerServer) GenerateTemplate(context.Context, *GenerateTemplateRequest) (*GenerateTemplateReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateTemplate not implemented")
 }
+func (UnimplementedRunnerServer) GenerateImportsAndMoves(context.Context, *GenerateImportsAndMovesRequest) (*GenerateImportsAndMovesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateImportsAndMoves not implemented")
+}
+func (UnimplementedRunnerServer) Validate(context.Context, *ValidateRequest) (*ValidateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedRunnerServer) Test(context.Context, *TestRequest) (*TestReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Test not implemented")
+}
+func (UnimplementedRunnerServer) CheckVariables(context.Context, *CheckVariablesRequest) (*CheckVariablesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckVariables not implemented")
+}
 func (UnimplementedRunnerServer) Plan(context.Context, *PlanRequest) (*PlanReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Plan not implemented")
 }
@@ -486,12 +654,36 @@ func (UnimplementedRunnerServer) Output(context.Context, *OutputRequest) (*Outpu
 func (UnimplementedRunnerServer) WriteOutputs(context.Context, *WriteOutputsRequest) (*WriteOutputsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WriteOutputs not implemented")
 }
+func (UnimplementedRunnerServer) DeleteOutputs(context.Context, *DeleteOutputsRequest) (*DeleteOutputsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOutputs not implemented")
+}
 func (UnimplementedRunnerServer) GetOutputs(context.Context, *GetOutputsRequest) (*GetOutputsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOutputs not implemented")
 }
 func (UnimplementedRunnerServer) Init(context.Context, *InitRequest) (*InitReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Init not implemented")
 }
+func (UnimplementedRunnerServer) MigrateState(context.Context, *MigrateStateRequest) (*MigrateStateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateState not implemented")
+}
+func (UnimplementedRunnerServer) StatePull(context.Context, *StatePullRequest) (*StatePullReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatePull not implemented")
+}
+func (UnimplementedRunnerServer) StatePush(context.Context, *StatePushRequest) (*StatePushReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatePush not implemented")
+}
+func (UnimplementedRunnerServer) StateList(context.Context, *StateListRequest) (*StateListReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StateList not implemented")
+}
+func (UnimplementedRunnerServer) StateMove(context.Context, *StateMoveRequest) (*StateMoveReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StateMove not implemented")
+}
+func (UnimplementedRunnerServer) StateRemove(context.Context, *StateRemoveRequest) (*StateRemoveReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StateRemove not implemented")
+}
+func (UnimplementedRunnerServer) Import(context.Context, *ImportRequest) (*ImportReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
 func (UnimplementedRunnerServer) SelectWorkspace(context.Context, *WorkspaceRequest) (*WorkspaceReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SelectWorkspace not implemented")
 }
@@ -714,6 +906,78 @@ func _Runner_GenerateTemplate_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Runner_GenerateImportsAndMoves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateImportsAndMovesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).GenerateImportsAndMoves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Runner_GenerateImportsAndMoves_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).GenerateImportsAndMoves(ctx, req.(*GenerateImportsAndMovesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Runner_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_Test_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).Test(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Runner_Test_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).Test(ctx, req.(*TestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_CheckVariables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckVariablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).CheckVariables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Runner_CheckVariables_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).CheckVariables(ctx, req.(*CheckVariablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Runner_Plan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PlanRequest)
 	if err := dec(in); err != nil {
@@ -894,6 +1158,24 @@ func _Runner_WriteOutputs_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Runner_DeleteOutputs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOutputsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).DeleteOutputs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Runner_DeleteOutputs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).DeleteOutputs(ctx, req.(*DeleteOutputsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Runner_GetOutputs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetOutputsRequest)
 	if err := dec(in); err != nil {
@@ -930,6 +1212,132 @@ func _Runner_Init_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Runner_MigrateState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).MigrateState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Runner_MigrateState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).MigrateState(ctx, req.(*MigrateStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_StatePull_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatePullRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).StatePull(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Runner_StatePull_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).StatePull(ctx, req.(*StatePullRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_StatePush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatePushRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).StatePush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Runner_StatePush_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).StatePush(ctx, req.(*StatePushRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_StateList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).StateList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Runner_StateList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).StateList(ctx, req.(*StateListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_StateMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateMoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).StateMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Runner_StateMove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).StateMove(ctx, req.(*StateMoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_StateRemove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateRemoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).StateRemove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Runner_StateRemove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).StateRemove(ctx, req.(*StateRemoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_Import_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).Import(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Runner_Import_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).Import(ctx, req.(*ImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Runner_SelectWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WorkspaceRequest)
 	if err := dec(in); err != nil {
@@ -1103,6 +1511,22 @@ var Runner_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateTemplate",
 			Handler:    _Runner_GenerateTemplate_Handler,
 		},
+		{
+			MethodName: "GenerateImportsAndMoves",
+			Handler:    _Runner_GenerateImportsAndMoves_Handler,
+		},
+		{
+			MethodName: "Validate",
+			Handler:    _Runner_Validate_Handler,
+		},
+		{
+			MethodName: "Test",
+			Handler:    _Runner_Test_Handler,
+		},
+		{
+			MethodName: "CheckVariables",
+			Handler:    _Runner_CheckVariables_Handler,
+		},
 		{
 			MethodName: "Plan",
 			Handler:    _Runner_Plan_Handler,
@@ -1143,6 +1567,10 @@ var Runner_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "WriteOutputs",
 			Handler:    _Runner_WriteOutputs_Handler,
 		},
+		{
+			MethodName: "DeleteOutputs",
+			Handler:    _Runner_DeleteOutputs_Handler,
+		},
 		{
 			MethodName: "GetOutputs",
 			Handler:    _Runner_GetOutputs_Handler,
@@ -1151,6 +1579,34 @@ var Runner_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Init",
 			Handler:    _Runner_Init_Handler,
 		},
+		{
+			MethodName: "MigrateState",
+			Handler:    _Runner_MigrateState_Handler,
+		},
+		{
+			MethodName: "StatePull",
+			Handler:    _Runner_StatePull_Handler,
+		},
+		{
+			MethodName: "StatePush",
+			Handler:    _Runner_StatePush_Handler,
+		},
+		{
+			MethodName: "StateList",
+			Handler:    _Runner_StateList_Handler,
+		},
+		{
+			MethodName: "StateMove",
+			Handler:    _Runner_StateMove_Handler,
+		},
+		{
+			MethodName: "StateRemove",
+			Handler:    _Runner_StateRemove_Handler,
+		},
+		{
+			MethodName: "Import",
+			Handler:    _Runner_Import_Handler,
+		},
 		{
 			MethodName: "SelectWorkspace",
 			Handler:    _Runner_SelectWorkspace_Handler,
@@ -60,11 +60,16 @@ func (e *TerraformSessionMismatchError) Error() string {
 }
 
 type LocalPrintfer struct {
-	logger logr.Logger
+	logger   logr.Logger
+	redactor *redactor
 }
 
 func (l LocalPrintfer) Printf(format string, v ...any) {
-	l.logger.Info(fmt.Sprintf(format, v...))
+	msg := fmt.Sprintf(format, v...)
+	if l.redactor != nil {
+		msg = l.redactor.redact(msg)
+	}
+	l.logger.Info(msg)
 }
 
 type TerraformRunnerServer struct {
@@ -75,6 +80,10 @@ type TerraformRunnerServer struct {
 	Done       chan os.Signal
 	terraform  *infrav1.Terraform
 	InstanceID string
+	// envs are the environment variables of the Terraform process
+	envs map[string]string
+	// sensitive holds the values to redact from the output of the session
+	sensitive redactor
 }
 
 const loggerName = "runner.terraform"
@@ -139,6 +148,25 @@ func (r *TerraformRunnerServer) UploadAndExtract(ctx context.Context, req *Uploa
 		return nil, err
 	}
 
+	// overlay the additional artifacts into subdirectories of the working directory
+	for _, artifact := range req.AdditionalArtifacts {
+		targetPath, err := securejoin.SecureJoin(dirPath, artifact.Path)
+		if err != nil {
+			log.Error(err, "unable to join securely", "dirPath", dirPath, "path", artifact.Path)
+			return nil, err
+		}
+
+		if err := os.MkdirAll(targetPath, 0755); err != nil {
+			log.Error(err, "unable to create the directory", "path", targetPath)
+			return nil, err
+		}
+
+		if err := tar.Untar(bytes.NewBuffer(artifact.TarGz), targetPath, opts); err != nil {
+			log.Error(err, "unable to extract tar file", "path", artifact.Path)
+			return nil, fmt.Errorf("failed to untar the artifact of %s, error: %w", artifact.Path, err)
+		}
+	}
+
 	return &UploadAndExtractReply{WorkingDir: dirPath, TmpDir: tmpDir}, nil
 }
 
@@ -217,10 +245,10 @@ func (r *TerraformRunnerServer) ProcessCliConfig(ctx context.Context, req *Proce
 func (r *TerraformRunnerServer) initLogger(log logr.Logger) {
 	disableTestLogging := os.Getenv("DISABLE_TF_LOGS") == "1"
 	if !disableTestLogging {
-		r.tf.SetStdout(os.Stdout)
-		r.tf.SetStderr(os.Stderr)
+		r.tf.SetStdout(r.sensitive.writer(os.Stdout))
+		r.tf.SetStderr(r.sensitive.writer(os.Stderr))
 		if os.Getenv("ENABLE_SENSITIVE_TF_LOGS") == "1" {
-			r.tf.SetLogger(&LocalPrintfer{logger: log})
+			r.tf.SetLogger(&LocalPrintfer{logger: log, redactor: &r.sensitive})
 		}
 	}
 }
@@ -237,6 +265,7 @@ func (r *TerraformRunnerServer) NewTerraform(ctx context.Context, req *NewTerraf
 
 	// hold only 1 instance
 	r.tf = NewTerraformExecWrapper(tf)
+	r.sensitive.reset()
 
 	var terraform infrav1.Terraform
 	if err := terraform.FromBytes(req.Terraform, r.Scheme); err != nil {
@@ -270,6 +299,7 @@ func (r *TerraformRunnerServer) SetEnv(ctx context.Context, req *SetEnvRequest)
 		log.Error(err, "unable to set envvars", "envvars", envs)
 		return nil, err
 	}
+	r.envs = envs
 
 	return &SetEnvReply{Message: "ok"}, nil
 }
@@ -0,0 +1,325 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/hashicorp/hcl2/ext/typeexpr"
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/hcl2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var variableBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "variable", LabelNames: []string{"name"}},
+	},
+}
+
+var variableSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "type"},
+		{Name: "default"},
+		{Name: "description"},
+		{Name: "sensitive"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "validation"},
+	},
+}
+
+// moduleVariable is a variable block of the module, with its type constraint
+// when it could be parsed.
+type moduleVariable struct {
+	schema *VariableSchema
+	typ    cty.Type
+}
+
+// CheckVariables compares the variables given to Terraform with the variable
+// blocks of the module, so missing, mistyped and unknown variables are reported
+// before planning. The discovered variables are returned for the status.
+func (r *TerraformRunnerServer) CheckVariables(ctx context.Context, req *CheckVariablesRequest) (*CheckVariablesReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("checking the variables")
+
+	if err := r.ValidateInstanceID(req.TfInstance); err != nil {
+		log.Error(err, "terraform session mismatch when checking variables")
+
+		return nil, err
+	}
+
+	var varFiles []string
+	for _, path := range r.terraform.Spec.TfVarsFiles {
+		filename, err := securejoin.SecureJoin(req.SourceRefRootDir, path)
+		if err != nil {
+			log.Error(err, "unable to resolve the tfvars file", "path", path)
+			return nil, err
+		}
+		// a missing file is reported by the plan
+		if info, err := os.Stat(filename); err == nil && !info.IsDir() {
+			varFiles = append(varFiles, filename)
+		}
+	}
+
+	reply, err := checkVariables(r.tf.WorkingDir(), varFiles, variablesEnv(r.envs))
+	if err != nil {
+		log.Error(err, "unable to check the variables")
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+// variablesEnv returns the TF_VAR_ variables of the environment Terraform runs
+// with: the environment of the runner, overridden by the one set for the
+// Terraform object.
+func variablesEnv(envs map[string]string) map[string]string {
+	merged := map[string]string{}
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(k, "TF_VAR_") {
+			merged[k] = v
+		}
+	}
+	for k, v := range envs {
+		merged[k] = v
+	}
+	return merged
+}
+
+func checkVariables(workingDir string, varFiles []string, envs map[string]string) (*CheckVariablesReply, error) {
+	parser := hclparse.NewParser()
+
+	declared, err := moduleVariables(parser, workingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := variableValues(parser, workingDir, varFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &CheckVariablesReply{}
+
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		v := declared[name]
+		reply.Variables = append(reply.Variables, v.schema)
+
+		valueList, ok := values[name]
+		if !ok {
+			if _, fromEnv := envs["TF_VAR_"+name]; v.schema.Required && !fromEnv {
+				reply.Missing = append(reply.Missing, name)
+			}
+			continue
+		}
+
+		if v.typ == cty.NilType {
+			continue
+		}
+		for _, value := range valueList {
+			if _, err := convert.Convert(value.value, v.typ); err != nil {
+				reply.Invalid = append(reply.Invalid, fmt.Sprintf("%s: %s (in %s)", name, err, value.file))
+			}
+		}
+	}
+
+	for name := range values {
+		if _, ok := declared[name]; !ok {
+			reply.Unknown = append(reply.Unknown, name)
+		}
+	}
+	sort.Strings(reply.Unknown)
+
+	reply.Message = fmt.Sprintf("%d declared, %d missing, %d invalid, %d unknown",
+		len(reply.Variables), len(reply.Missing), len(reply.Invalid), len(reply.Unknown))
+
+	return reply, nil
+}
+
+// moduleVariables parses the variable blocks of the .tf and .tf.json files of the module.
+func moduleVariables(parser *hclparse.Parser, workingDir string) (map[string]*moduleVariable, error) {
+	files, err := moduleFiles(workingDir, ".tf", ".tf.json")
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]*moduleVariable{}
+	for _, filename := range files {
+		file, diags := parseFile(parser, filename)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("unable to parse %s: %s", filepath.Base(filename), diags.Error())
+		}
+
+		content, _, diags := file.Body.PartialContent(variableBlockSchema)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("unable to read %s: %s", filepath.Base(filename), diags.Error())
+		}
+
+		for _, block := range content.Blocks {
+			v, err := parseVariable(block, file.Bytes, strings.HasSuffix(filename, ".json"))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", filepath.Base(filename), err)
+			}
+			variables[v.schema.Name] = v
+		}
+	}
+
+	return variables, nil
+}
+
+func parseVariable(block *hcl.Block, src []byte, isJSON bool) (*moduleVariable, error) {
+	name := block.Labels[0]
+	content, _, diags := block.Body.PartialContent(variableSchema)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("variable %q: %s", name, diags.Error())
+	}
+
+	v := &moduleVariable{
+		schema: &VariableSchema{
+			Name:        name,
+			Required:    true,
+			Validations: int32(len(content.Blocks)),
+		},
+		typ: cty.NilType,
+	}
+
+	if attr, ok := content.Attributes["sensitive"]; ok {
+		value, diags := attr.Expr.Value(nil)
+		if !diags.HasErrors() && value.Type() == cty.Bool && value.IsKnown() && !value.IsNull() {
+			v.schema.Sensitive = value.True()
+		}
+	}
+
+	if attr, ok := content.Attributes["description"]; ok {
+		value, diags := attr.Expr.Value(nil)
+		if !diags.HasErrors() && value.Type() == cty.String && value.IsKnown() && !value.IsNull() {
+			v.schema.Description = value.AsString()
+		}
+	}
+
+	if attr, ok := content.Attributes["type"]; ok {
+		expr := attr.Expr
+		if isJSON {
+			// in JSON, the type constraint is a string holding the expression
+			value, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() || value.Type() != cty.String || value.IsNull() {
+				return nil, fmt.Errorf("variable %q: the type must be a string", name)
+			}
+			v.schema.Type = value.AsString()
+			expr, diags = hclsyntax.ParseExpression([]byte(v.schema.Type), attr.Range.Filename, attr.Range.Start)
+			if diags.HasErrors() {
+				expr = nil
+			}
+		} else {
+			v.schema.Type = string(attr.Expr.Range().SliceBytes(src))
+		}
+
+		// newer constraints, like optional object attributes, are not checked
+		if expr != nil {
+			if typ, diags := typeexpr.TypeConstraint(expr); !diags.HasErrors() {
+				v.typ = typ
+			}
+		}
+	}
+
+	if attr, ok := content.Attributes["default"]; ok {
+		v.schema.Required = false
+		value, diags := attr.Expr.Value(nil)
+		if !diags.HasErrors() && !v.schema.Sensitive && value.IsWhollyKnown() {
+			if raw, err := ctyjson.Marshal(value, value.Type()); err == nil {
+				v.schema.Default = raw
+			}
+		}
+	}
+
+	return v, nil
+}
+
+type variableValue struct {
+	value cty.Value
+	file  string
+}
+
+// variableValues reads the variable files Terraform loads automatically,
+// including the one generated from .spec.vars and .spec.varsFrom, and the
+// files of .spec.tfVarsFiles given with -var-file.
+func variableValues(parser *hclparse.Parser, workingDir string, varFiles []string) (map[string][]variableValue, error) {
+	files, err := moduleFiles(workingDir, ".auto.tfvars", ".auto.tfvars.json")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range []string{"terraform.tfvars", "terraform.tfvars.json"} {
+		filename := filepath.Join(workingDir, name)
+		if _, err := os.Stat(filename); err == nil {
+			files = append(files, filename)
+		}
+	}
+	files = append(files, varFiles...)
+
+	values := map[string][]variableValue{}
+	for _, filename := range files {
+		file, diags := parseFile(parser, filename)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("unable to parse %s: %s", filepath.Base(filename), diags.Error())
+		}
+
+		attrs, diags := file.Body.JustAttributes()
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("unable to read %s: %s", filepath.Base(filename), diags.Error())
+		}
+
+		for name, attr := range attrs {
+			value, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				return nil, fmt.Errorf("unable to read %s in %s: %s", name, filepath.Base(filename), diags.Error())
+			}
+			values[name] = append(values[name], variableValue{value: value, file: filepath.Base(filename)})
+		}
+	}
+
+	return values, nil
+}
+
+func moduleFiles(dir string, suffixes ...string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		for _, suffix := range suffixes {
+			if strings.HasSuffix(entry.Name(), suffix) {
+				files = append(files, filepath.Join(dir, entry.Name()))
+				break
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func parseFile(parser *hclparse.Parser, filename string) (*hcl.File, hcl.Diagnostics) {
+	if strings.HasSuffix(filename, ".json") {
+		return parser.ParseJSONFile(filename)
+	}
+	return parser.ParseHCLFile(filename)
+}
@@ -0,0 +1,122 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCheckVariables(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"variables.tf": `
+variable "region" {
+  type        = string
+  description = "Region to deploy to"
+}
+
+variable "replicas" {
+  type    = number
+  default = 1
+
+  validation {
+    condition     = var.replicas > 0
+    error_message = "At least one replica is required."
+  }
+}
+
+variable "zones" {
+  type = list(string)
+}
+
+variable "password" {
+  type      = string
+  sensitive = true
+  default   = "changeme"
+}
+
+variable "token" {}
+`,
+		"tags.tf.json": `{"variable": {"tags": {"type": "map(string)", "default": {"team": "platform"}}}}`,
+		"generated.auto.tfvars.json": `{
+  "replicas": "three",
+  "zones": ["a", "b"],
+  "tags": {"team": "infra"},
+  "cluster_name": "prod"
+}`,
+	}
+	for name, content := range files {
+		g.Expect(os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)).To(Succeed())
+	}
+
+	reply, err := checkVariables(dir, nil, map[string]string{"TF_VAR_token": "t0ken"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(reply.Missing).To(Equal([]string{"region"}))
+	g.Expect(reply.Invalid).To(HaveLen(1))
+	g.Expect(reply.Invalid[0]).To(HavePrefix("replicas: a number is required"))
+	g.Expect(reply.Unknown).To(Equal([]string{"cluster_name"}))
+
+	g.Expect(reply.Variables).To(HaveLen(6))
+	schemas := map[string]*VariableSchema{}
+	for _, v := range reply.Variables {
+		schemas[v.Name] = v
+	}
+	g.Expect(schemas["region"].Type).To(Equal("string"))
+	g.Expect(schemas["region"].Description).To(Equal("Region to deploy to"))
+	g.Expect(schemas["region"].Required).To(BeTrue())
+	g.Expect(schemas["replicas"].Required).To(BeFalse())
+	g.Expect(string(schemas["replicas"].Default)).To(Equal("1"))
+	g.Expect(schemas["replicas"].Validations).To(Equal(int32(1)))
+	g.Expect(schemas["zones"].Type).To(Equal("list(string)"))
+	g.Expect(schemas["password"].Sensitive).To(BeTrue())
+	g.Expect(schemas["password"].Default).To(BeEmpty())
+	g.Expect(schemas["tags"].Type).To(Equal("map(string)"))
+	g.Expect(string(schemas["tags"].Default)).To(Equal(`{"team":"platform"}`))
+
+	// numbers given as strings are converted, as Terraform does
+	g.Expect(os.WriteFile(filepath.Join(dir, "generated.auto.tfvars.json"),
+		[]byte(`{"region": "eu-west-1", "replicas": "3", "zones": ["a"]}`), 0644)).To(Succeed())
+	reply, err = checkVariables(dir, nil, map[string]string{"TF_VAR_token": "t0ken"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.Missing).To(BeEmpty())
+	g.Expect(reply.Invalid).To(BeEmpty())
+	g.Expect(reply.Unknown).To(BeEmpty())
+}
+
+func TestCheckVariablesFromTfVarsFilesAndEnv(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(`
+variable "region" {
+  type = string
+}
+
+variable "replicas" {
+  type = number
+}
+
+variable "token" {}
+`), 0644)).To(Succeed())
+
+	// a file of .spec.tfVarsFiles, outside of the module directory
+	varFile := filepath.Join(t.TempDir(), "prod.tfvars")
+	g.Expect(os.WriteFile(varFile, []byte(`
+region   = "eu-west-1"
+replicas = "many"
+`), 0644)).To(Succeed())
+
+	t.Setenv("TF_VAR_token", "t0ken")
+
+	reply, err := checkVariables(dir, []string{varFile}, variablesEnv(nil))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.Missing).To(BeEmpty())
+	g.Expect(reply.Invalid).To(HaveLen(1))
+	g.Expect(reply.Invalid[0]).To(HaveSuffix("(in prod.tfvars)"))
+	g.Expect(reply.Unknown).To(BeEmpty())
+}
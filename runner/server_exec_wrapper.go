@@ -75,6 +75,18 @@ func (t *TerraformExecWrapper) Plan(ctx context.Context, opts ...tfexec.PlanOpti
 	return drifted, t.NormalizeError(err)
 }
 
+func (t *TerraformExecWrapper) StateMv(ctx context.Context, source string, destination string, opts ...tfexec.StateMvCmdOption) error {
+	return t.NormalizeError(t.Terraform.StateMv(ctx, source, destination, opts...))
+}
+
+func (t *TerraformExecWrapper) StateRm(ctx context.Context, address string, opts ...tfexec.StateRmCmdOption) error {
+	return t.NormalizeError(t.Terraform.StateRm(ctx, address, opts...))
+}
+
+func (t *TerraformExecWrapper) Import(ctx context.Context, address, id string, opts ...tfexec.ImportOption) error {
+	return t.NormalizeError(t.Terraform.Import(ctx, address, id, opts...))
+}
+
 func (t *TerraformExecWrapper) NormalizeError(err error) error {
 	if err == nil {
 		return nil
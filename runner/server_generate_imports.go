@@ -0,0 +1,165 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/hcl2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	importsFileName = "imports.tf"
+	movesFileName   = "moved.tf"
+)
+
+// GenerateImportsAndMoves materialises .spec.imports and .spec.moves as import and
+// moved blocks, next to the backend configuration. Imports which have already been
+// applied are skipped, as well as all imports when the resources are being destroyed.
+func (r *TerraformRunnerServer) GenerateImportsAndMoves(ctx context.Context, req *GenerateImportsAndMovesRequest) (*GenerateImportsAndMovesReply, error) {
+	log := controllerruntime.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("generating imports and moves")
+
+	// use from the cached object
+	terraform := *r.terraform
+
+	reply := &GenerateImportsAndMovesReply{Message: "ok"}
+
+	var imports []infrav1.ImportSpec
+	if !terraform.Spec.Destroy && terraform.DeletionTimestamp.IsZero() {
+		imports = terraform.PendingImports()
+	}
+
+	if len(imports) > 0 {
+		f := hclwrite.NewEmptyFile()
+		for _, spec := range imports {
+			to, err := parseAddress(spec.Address)
+			if err != nil {
+				log.Error(err, "invalid import address", "address", spec.Address)
+				return nil, err
+			}
+
+			id, err := r.importID(ctx, terraform.Namespace, spec)
+			if err != nil {
+				log.Error(err, "unable to get the import ID", "address", spec.Address)
+				return nil, err
+			}
+
+			body := f.Body().AppendNewBlock("import", nil).Body()
+			body.SetAttributeTraversal("to", to)
+			body.SetAttributeValue("id", cty.StringVal(id))
+			f.Body().AppendNewline()
+
+			reply.ImportAddresses = append(reply.ImportAddresses, spec.Address)
+		}
+
+		if err := writeGeneratedFile(req.WorkingDir, importsFileName, f.Bytes()); err != nil {
+			log.Error(err, "unable to write imports", "file", importsFileName)
+			return nil, err
+		}
+	}
+
+	if len(terraform.Spec.Moves) > 0 {
+		f := hclwrite.NewEmptyFile()
+		for _, spec := range terraform.Spec.Moves {
+			from, err := parseAddress(spec.From)
+			if err != nil {
+				log.Error(err, "invalid move address", "address", spec.From)
+				return nil, err
+			}
+
+			to, err := parseAddress(spec.To)
+			if err != nil {
+				log.Error(err, "invalid move address", "address", spec.To)
+				return nil, err
+			}
+
+			body := f.Body().AppendNewBlock("moved", nil).Body()
+			body.SetAttributeTraversal("from", from)
+			body.SetAttributeTraversal("to", to)
+			f.Body().AppendNewline()
+		}
+
+		if err := writeGeneratedFile(req.WorkingDir, movesFileName, f.Bytes()); err != nil {
+			log.Error(err, "unable to write moves", "file", movesFileName)
+			return nil, err
+		}
+	}
+
+	return reply, nil
+}
+
+// parseAddress parses a resource address, such as module.network.aws_subnet.private["a"].
+func parseAddress(address string) (hcl.Traversal, error) {
+	traversal, diags := hclsyntax.ParseTraversalAbs([]byte(address), "", hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("invalid resource address %q: %s", address, diags.Error())
+	}
+
+	return traversal, nil
+}
+
+func (r *TerraformRunnerServer) importID(ctx context.Context, namespace string, spec infrav1.ImportSpec) (string, error) {
+	if spec.IDFrom == nil {
+		if spec.ID == "" {
+			return "", fmt.Errorf("import of %s requires an id or idFrom", spec.Address)
+		}
+		return spec.ID, nil
+	}
+
+	ref := spec.IDFrom
+	objectKey := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+
+	var (
+		value []byte
+		ok    bool
+	)
+	switch ref.Kind {
+	case "Secret":
+		var s v1.Secret
+		if err := r.Get(ctx, objectKey, &s); err != nil {
+			return "", err
+		}
+		value, ok = s.Data[ref.Key]
+	case "ConfigMap":
+		var cm v1.ConfigMap
+		if err := r.Get(ctx, objectKey, &cm); err != nil {
+			return "", err
+		}
+		var str string
+		str, ok = cm.Data[ref.Key]
+		value = []byte(str)
+	default:
+		return "", fmt.Errorf("unsupported kind %s for the import of %s", ref.Kind, spec.Address)
+	}
+
+	if !ok {
+		return "", fmt.Errorf("%s %s does not contain the key %s", ref.Kind, objectKey, ref.Key)
+	}
+
+	return strings.TrimSpace(string(value)), nil
+}
+
+// writeGeneratedFile writes a generated file in the working directory,
+// refusing to overwrite a file of the module.
+func writeGeneratedFile(workingDir, name string, data []byte) error {
+	filePath, err := securejoin.SecureJoin(workingDir, name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(filePath); err == nil {
+		return fmt.Errorf("cannot generate %s, the file already exists in the module", name)
+	}
+
+	return os.WriteFile(filePath, data, 0644)
+}
@@ -0,0 +1,91 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGenerateImportsAndMoves(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1.AddToScheme(scheme))
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bucket-id", Namespace: "flux-system"},
+		Data:       map[string][]byte{"id": []byte("my-bucket\n")},
+	}
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			Imports: []infrav1.ImportSpec{
+				{Address: "aws_instance.web", ID: "i-0123456789abcdef0"},
+				{Address: `module.storage.aws_s3_bucket.this["logs"]`, IDFrom: &infrav1.ImportIDReference{Kind: "Secret", Name: "bucket-id", Key: "id"}},
+				{Address: "aws_instance.imported", ID: "i-0000000000000000"},
+			},
+			Moves: []infrav1.MoveSpec{
+				{From: "aws_instance.old", To: "aws_instance.new"},
+			},
+		},
+		Status: infrav1.TerraformStatus{
+			Imports: []infrav1.ImportStatus{{Address: "aws_instance.imported"}},
+		},
+	}
+
+	server := &TerraformRunnerServer{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(),
+		terraform: terraform,
+	}
+
+	workingDir := t.TempDir()
+	reply, err := server.GenerateImportsAndMoves(t.Context(), &GenerateImportsAndMovesRequest{WorkingDir: workingDir})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"aws_instance.web", `module.storage.aws_s3_bucket.this["logs"]`}, reply.ImportAddresses)
+
+	imports, err := os.ReadFile(filepath.Join(workingDir, "imports.tf"))
+	assert.NoError(t, err)
+	assert.Equal(t, `import {
+  to = aws_instance.web
+  id = "i-0123456789abcdef0"
+}
+
+import {
+  to = module.storage.aws_s3_bucket.this["logs"]
+  id = "my-bucket"
+}
+
+`, string(imports))
+
+	moves, err := os.ReadFile(filepath.Join(workingDir, "moved.tf"))
+	assert.NoError(t, err)
+	assert.Equal(t, `moved {
+  from = aws_instance.old
+  to   = aws_instance.new
+}
+
+`, string(moves))
+
+	// a module file is never overwritten
+	_, err = server.GenerateImportsAndMoves(t.Context(), &GenerateImportsAndMovesRequest{WorkingDir: workingDir})
+	assert.Error(t, err)
+}
+
+func TestGenerateImportsAndMovesInvalidAddress(t *testing.T) {
+	server := &TerraformRunnerServer{
+		terraform: &infrav1.Terraform{
+			Spec: infrav1.TerraformSpec{
+				Moves: []infrav1.MoveSpec{{From: "aws_instance.old", To: "aws_instance.new = 1"}},
+			},
+		},
+	}
+
+	_, err := server.GenerateImportsAndMoves(t.Context(), &GenerateImportsAndMovesRequest{WorkingDir: t.TempDir()})
+	assert.Error(t, err)
+}
@@ -1,73 +1,331 @@
 package runner
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io/fs"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/google/go-jsonnet"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 )
 
+const (
+	defaultTemplatingOutput = "generated.tf.json"
+	defaultJsonnetGenerator = "main.jsonnet"
+
+	// cueDataFile is added to the CUE package to expose the data as #tf.
+	cueDataFile = "zz_tf_data.cue"
+)
+
+var (
+	// the binary of the CUE engine, looked up in the PATH
+	cueBinary = "cue"
+
+	cuePackageClause = regexp.MustCompile(`(?m)^package\s+([\w$]+)`)
+)
+
 func (r *TerraformRunnerServer) GenerateTemplate(ctx context.Context, req *GenerateTemplateRequest) (*GenerateTemplateReply, error) {
 	log := controllerruntime.LoggerFrom(ctx).WithName(loggerName)
 	log.Info("generating the template founds")
 
 	workDir := req.WorkingDir
 
-	// find main.tf.tpl file
-	mainTfTplPath := filepath.Join(workDir, "main.tf.tpl")
-	if _, err := os.Stat(mainTfTplPath); os.IsNotExist(err) {
-		log.Info("main.tf.tpl not found, skipping")
+	var templating *infrav1.TemplatingSpec
+	if r.terraform != nil {
+		templating = r.terraform.Spec.Templating
+	}
+
+	if templating == nil {
+		// find main.tf.tpl file
+		mainTfTplPath := filepath.Join(workDir, "main.tf.tpl")
+		if _, err := os.Stat(mainTfTplPath); os.IsNotExist(err) {
+			log.Info("main.tf.tpl not found, skipping")
+			return &GenerateTemplateReply{Message: "ok"}, nil
+		}
+
+		vars, err := readGeneratedVars(workDir)
+		if err != nil {
+			log.Error(err, "unable to read the variables")
+			return nil, err
+		}
+
+		// make it Helm compatible
+		vars["Values"] = vars["values"]
+
+		// we use Helm-like syntax for the template
+		if err := renderTemplateFile(mainTfTplPath, "{{", "}}", vars); err != nil {
+			log.Error(err, "unable to render the template", "filePath", mainTfTplPath)
+			return nil, err
+		}
+
 		return &GenerateTemplateReply{Message: "ok"}, nil
 	}
 
-	// marshal the vars
+	vars, err := readGeneratedVars(workDir)
+	if err != nil {
+		log.Error(err, "unable to read the variables")
+		return nil, err
+	}
+
+	terraform := *r.terraform
+	inputs, err := readInputsForGenerateVarsForTF(ctx, log, r.Client, &terraform)
+	if err != nil {
+		return nil, err
+	}
+
+	switch engine := templating.GetEngine(); engine {
+	case infrav1.GoTemplateEngine:
+		rendered, err := renderTemplates(workDir, templating, goTemplateData(&terraform, vars, inputs))
+		if err != nil {
+			log.Error(err, "unable to render the templates")
+			return nil, err
+		}
+		return &GenerateTemplateReply{Message: fmt.Sprintf("rendered %d templates", rendered)}, nil
+
+	case infrav1.CUEEngine, infrav1.JsonnetEngine:
+		output, err := generateTFJSON(ctx, workDir, templating, generatorData(&terraform, vars, inputs))
+		if err != nil {
+			log.Error(err, "unable to generate the module sources", "engine", engine)
+			return nil, err
+		}
+		return &GenerateTemplateReply{Message: fmt.Sprintf("generated %s with %s", output, engine)}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported templating engine %q", engine)
+	}
+}
+
+// readGeneratedVars reads the variables written by GenerateVarsForTF.
+func readGeneratedVars(workDir string) (map[string]any, error) {
 	vars := make(map[string]any)
 
-	varFilePath := filepath.Join(req.WorkingDir, "generated.auto.tfvars.json")
-	jsonBytes, err := os.ReadFile(varFilePath)
+	jsonBytes, err := os.ReadFile(filepath.Join(workDir, "generated.auto.tfvars.json"))
 	if err != nil {
-		log.Error(err, "unable to read the file", "filePath", varFilePath)
 		return nil, err
 	}
 
 	if err := json.Unmarshal(jsonBytes, &vars); err != nil {
-		log.Error(err, "unable to unmarshal the data")
 		return nil, err
 	}
 
-	// render the template
-	// we use Helm-like syntax for the template
-	tmpl, parseErr := template.New("main.tf.tpl").
-		Delims("{{", "}}").
+	return vars, nil
+}
+
+// goTemplateData exposes the variables at the top level, as main.tf.tpl always
+// did, along with Helm-like fields for the values, the variables, the inputs
+// and the metadata of the object.
+func goTemplateData(terraform *infrav1.Terraform, vars map[string]any, inputs map[string]any) map[string]any {
+	data := make(map[string]any, len(vars)+4)
+	for k, v := range vars {
+		data[k] = v
+	}
+	data["Values"] = vars["values"]
+	data["Vars"] = vars
+	data["Inputs"] = inputs
+	data["Metadata"] = map[string]any{
+		"Name":        terraform.Name,
+		"Namespace":   terraform.Namespace,
+		"Labels":      terraform.Labels,
+		"Annotations": terraform.Annotations,
+	}
+	return data
+}
+
+func generatorData(terraform *infrav1.Terraform, vars map[string]any, inputs map[string]any) map[string]any {
+	return map[string]any{
+		"vars":   vars,
+		"inputs": inputs,
+		"metadata": map[string]any{
+			"name":        terraform.Name,
+			"namespace":   terraform.Namespace,
+			"labels":      terraform.Labels,
+			"annotations": terraform.Annotations,
+		},
+	}
+}
+
+// renderTemplates renders every *.tpl file of the module path, including its
+// subdirectories, to the file of the same name without the extension.
+func renderTemplates(workDir string, templating *infrav1.TemplatingSpec, data map[string]any) (int, error) {
+	left, right := templating.LeftDelimiter, templating.RightDelimiter
+	if left == "" {
+		left = "{{"
+	}
+	if right == "" {
+		right = "}}"
+	}
+
+	var templates []string
+	err := filepath.WalkDir(workDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != workDir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() && strings.HasSuffix(d.Name(), ".tpl") && d.Name() != ".tpl" {
+			templates = append(templates, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, path := range templates {
+		if err := renderTemplateFile(path, left, right, data); err != nil {
+			rel, _ := filepath.Rel(workDir, path)
+			return 0, fmt.Errorf("%s: %w", rel, err)
+		}
+	}
+
+	return len(templates), nil
+}
+
+func renderTemplateFile(path, left, right string, data map[string]any) error {
+	tmpl, err := template.New(filepath.Base(path)).
+		Delims(left, right).
 		Funcs(sprig.TxtFuncMap()).
-		ParseFiles(mainTfTplPath)
-	if parseErr != nil {
-		log.Error(parseErr, "unable to parse the template", "filePath", mainTfTplPath)
-		return nil, parseErr
+		ParseFiles(path)
+	if err != nil {
+		return err
 	}
 
-	mainTfPath := filepath.Join(workDir, "main.tf")
-	f, fileErr := os.Create(mainTfPath)
-	if fileErr != nil {
-		log.Error(fileErr, "unable to create the file", "filePath", mainTfPath)
-		return nil, fileErr
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
 	}
 
-	// make it Helm compatible
-	vars["Values"] = vars["values"]
+	return os.WriteFile(strings.TrimSuffix(path, ".tpl"), buf.Bytes(), 0644)
+}
+
+// generateTFJSON evaluates the CUE or Jsonnet generator and writes the result
+// to a .tf.json file of the module path.
+func generateTFJSON(ctx context.Context, workDir string, templating *infrav1.TemplatingSpec, data map[string]any) (string, error) {
+	output := templating.Output
+	if output == "" {
+		output = defaultTemplatingOutput
+	}
+	if filepath.Base(output) != output || !strings.HasSuffix(output, ".tf.json") {
+		return "", fmt.Errorf("the output %q must be the name of a .tf.json file", output)
+	}
+
+	generator := templating.Generator
+	if generator == "" {
+		generator = "."
+		if templating.Engine == infrav1.JsonnetEngine {
+			generator = defaultJsonnetGenerator
+		}
+	}
+	if !filepath.IsLocal(generator) {
+		return "", fmt.Errorf("the generator %q must be within the module path", generator)
+	}
 
-	if err := tmpl.Execute(f, vars); err != nil {
-		log.Error(err, "unable to execute the template")
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	var generated []byte
+	if templating.Engine == infrav1.CUEEngine {
+		generated, err = evaluateCUE(ctx, workDir, generator, templating.Expression, dataJSON)
+	} else {
+		generated, err = evaluateJsonnet(workDir, generator, dataJSON)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var module map[string]any
+	if err := json.Unmarshal(generated, &module); err != nil {
+		return "", fmt.Errorf("the generator must evaluate to a JSON object: %w", err)
+	}
+
+	return output, os.WriteFile(filepath.Join(workDir, output), generated, 0644)
+}
+
+// evaluateCUE exports the CUE package of the generator directory, with the
+// data exposed as the #tf definition.
+func evaluateCUE(ctx context.Context, workDir, generator, expression string, data []byte) ([]byte, error) {
+	dir := filepath.Join(workDir, generator)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.cue"))
+	if err != nil {
 		return nil, err
 	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no CUE files found in %s", generator)
+	}
+
+	var dataFile bytes.Buffer
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if m := cuePackageClause.FindSubmatch(content); m != nil {
+			fmt.Fprintf(&dataFile, "package %s\n\n", m[1])
+			break
+		}
+	}
+	// JSON is valid CUE
+	fmt.Fprintf(&dataFile, "#tf: %s\n", data)
 
-	if err := f.Close(); err != nil {
+	dataPath := filepath.Join(dir, cueDataFile)
+	if err := os.WriteFile(dataPath, dataFile.Bytes(), 0644); err != nil {
 		return nil, err
 	}
+	defer os.Remove(dataPath)
+
+	args := []string{"export", "./" + filepath.ToSlash(generator), "--out", "json"}
+	if expression != "" {
+		args = append(args, "--expression", expression)
+	}
+	return runGenerator(ctx, workDir, cueBinary, args...)
+}
+
+// evaluateJsonnet evaluates the Jsonnet file of the generator, with the data
+// exposed as the tf external variable.
+func evaluateJsonnet(workDir, generator string, data []byte) ([]byte, error) {
+	vm := jsonnet.MakeVM()
+	vm.Importer(&jsonnet.FileImporter{JPaths: []string{workDir}})
+	vm.ExtCode("tf", string(data))
+
+	output, err := vm.EvaluateFile(filepath.Join(workDir, generator))
+	if err != nil {
+		return nil, fmt.Errorf("unable to evaluate the Jsonnet file: %w", err)
+	}
+
+	return []byte(output), nil
+}
+
+func runGenerator(ctx context.Context, workDir, binary string, args ...string) ([]byte, error) {
+	execPath, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, fmt.Errorf("the %s binary was not found in the runner's PATH, use a runner image which ships it", binary)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, execPath, args...)
+	cmd.Dir = workDir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %w: %s", binary, err, strings.TrimSpace(stderr.String()))
+	}
 
-	return &GenerateTemplateReply{Message: "ok"}, nil
+	return stdout.Bytes(), nil
 }
@@ -0,0 +1,118 @@
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func templatingTestData() map[string]any {
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tenant-a",
+			Namespace: "flux-system",
+			Labels:    map[string]string{"team": "platform"},
+		},
+	}
+	vars := map[string]any{"region": "eu-west-1", "values": map[string]any{"size": "small"}}
+	inputs := map[string]any{"network": map[string]any{"vpc_id": "vpc-1234"}}
+	return map[string]any{
+		"go":        goTemplateData(terraform, vars, inputs),
+		"generator": generatorData(terraform, vars, inputs),
+	}
+}
+
+func TestRenderTemplates(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir := t.TempDir()
+	g.Expect(os.MkdirAll(filepath.Join(dir, "modules", "bucket"), 0755)).To(Succeed())
+	g.Expect(os.MkdirAll(filepath.Join(dir, ".terraform"), 0755)).To(Succeed())
+	files := map[string]string{
+		"main.tf.tpl":                  `region = "<< .region >>"` + "\n" + `name = "<< .Metadata.Name >>-<< .Values.size >>"`,
+		"modules/bucket/bucket.tf.tpl": `vpc = "<< .Inputs.network.vpc_id >>" # {{ not a template }}`,
+		"tenants.auto.tfvars.json.tpl": `{"team": "<< index .Metadata.Labels "team" | upper >>"}`,
+		".terraform/ignored.tf.tpl":    `<< .missing.field >>`,
+	}
+	for name, content := range files {
+		g.Expect(os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)).To(Succeed())
+	}
+
+	rendered, err := renderTemplates(dir, &infrav1.TemplatingSpec{LeftDelimiter: "<<", RightDelimiter: ">>"}, templatingTestData()["go"].(map[string]any))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rendered).To(Equal(3))
+
+	content, err := os.ReadFile(filepath.Join(dir, "main.tf"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(content)).To(Equal("region = \"eu-west-1\"\nname = \"tenant-a-small\""))
+
+	content, err = os.ReadFile(filepath.Join(dir, "modules", "bucket", "bucket.tf"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(content)).To(Equal(`vpc = "vpc-1234" # {{ not a template }}`))
+
+	content, err = os.ReadFile(filepath.Join(dir, "tenants.auto.tfvars.json"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(content)).To(Equal(`{"team": "PLATFORM"}`))
+
+	g.Expect(filepath.Join(dir, ".terraform", "ignored.tf")).ToNot(BeAnExistingFile())
+}
+
+func TestGenerateTFJSON(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := generateTFJSON(t.Context(), t.TempDir(), &infrav1.TemplatingSpec{Engine: infrav1.JsonnetEngine, Generator: "../main.jsonnet"}, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("must be within the module path")))
+
+	_, err = generateTFJSON(t.Context(), t.TempDir(), &infrav1.TemplatingSpec{Engine: infrav1.JsonnetEngine, Output: "main.tf"}, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("must be the name of a .tf.json file")))
+
+	data := templatingTestData()["generator"].(map[string]any)
+
+	t.Run("Jsonnet", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		dir := t.TempDir()
+		g.Expect(os.WriteFile(filepath.Join(dir, "main.jsonnet"), []byte(`
+local tf = std.extVar('tf');
+{ resource: { null_resource: { [tf.metadata.name]: { triggers: { region: tf.vars.region } } } } }
+`), 0644)).To(Succeed())
+
+		output, err := generateTFJSON(t.Context(), dir, &infrav1.TemplatingSpec{Engine: infrav1.JsonnetEngine}, data)
+		g.Expect(err).ToNot(HaveOccurred())
+		content, err := os.ReadFile(filepath.Join(dir, output))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(content).To(MatchJSON(`{"resource":{"null_resource":{"tenant-a":{"triggers":{"region":"eu-west-1"}}}}}`))
+	})
+
+	t.Run("CUE", func(t *testing.T) {
+		if _, err := exec.LookPath(cueBinary); err != nil {
+			t.Skip("cue is not installed")
+		}
+		g := NewGomegaWithT(t)
+
+		dir := t.TempDir()
+		g.Expect(os.MkdirAll(filepath.Join(dir, "gen"), 0755)).To(Succeed())
+		g.Expect(os.WriteFile(filepath.Join(dir, "gen", "main.cue"), []byte(`package gen
+
+module: resource: null_resource: "\(#tf.metadata.name)": triggers: region: #tf.vars.region
+`), 0644)).To(Succeed())
+
+		output, err := generateTFJSON(t.Context(), dir, &infrav1.TemplatingSpec{
+			Engine:     infrav1.CUEEngine,
+			Generator:  "gen",
+			Expression: "module",
+			Output:     "tenant.tf.json",
+		}, data)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(output).To(Equal("tenant.tf.json"))
+		content, err := os.ReadFile(filepath.Join(dir, output))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(content).To(MatchJSON(`{"resource":{"null_resource":{"tenant-a":{"triggers":{"region":"eu-west-1"}}}}}`))
+		g.Expect(filepath.Join(dir, "gen", cueDataFile)).ToNot(BeAnExistingFile())
+	})
+}
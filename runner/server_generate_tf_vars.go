@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	json2 "encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
 	"github.com/flux-iac/tofu-controller/utils"
 	"github.com/go-logr/logr"
+	"github.com/hashicorp/hcl2/hclparse"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/json"
 	v1 "k8s.io/api/core/v1"
@@ -241,6 +243,13 @@ func (r *TerraformRunnerServer) GenerateVarsForTF(ctx context.Context, req *Gene
 		}
 
 		vars["values"] = &apiextensionsv1.JSON{Raw: buf.Bytes()}
+
+		// only the inputs the values are rendered with reach Terraform
+		for _, input := range jsonStrings(map[string]any(inputs)) {
+			if strings.Contains(buf.String(), input) {
+				r.sensitive.add(input)
+			}
+		}
 	}
 
 	log.Info("mapping the Spec.Vars")
@@ -268,6 +277,7 @@ func (r *TerraformRunnerServer) GenerateVarsForTF(ctx context.Context, req *Gene
 			// if VarsKeys is null, use all
 			if vf.VarsKeys == nil {
 				for key, val := range s.Data {
+					r.sensitive.add(string(val))
 					vars[key], err = utils.JSONEncodeBytes(val)
 					if err != nil {
 						err := fmt.Errorf("failed to encode key %s with error: %w", key, err)
@@ -283,6 +293,7 @@ func (r *TerraformRunnerServer) GenerateVarsForTF(ctx context.Context, req *Gene
 						return nil, err
 					}
 
+					r.sensitive.add(string(s.Data[oldKey]))
 					vars[newKey], err = utils.JSONEncodeBytes(s.Data[oldKey])
 					if err != nil {
 						err := fmt.Errorf("failed to encode key %q with error: %w", pattern, err)
@@ -342,9 +353,65 @@ func (r *TerraformRunnerServer) GenerateVarsForTF(ctx context.Context, req *Gene
 					}
 				}
 			}
+		case infrav1.TerraformKind:
+			namespace := vf.Namespace
+			if namespace == "" {
+				namespace = terraform.Namespace
+			}
+			key := fmt.Sprintf("%s/%s", namespace, vf.Name)
+			upstream, ok := req.TerraformOutputs[key]
+			if !ok {
+				if vf.Optional {
+					continue
+				}
+				err := fmt.Errorf("outputs of Terraform %s are not available", key)
+				log.Error(err, "unable to map the outputs of an upstream Terraform")
+				return nil, err
+			}
+
+			// if VarsKeys is null, use all
+			if vf.VarsKeys == nil {
+				for name, val := range upstream.Outputs {
+					vars[name] = &apiextensionsv1.JSON{Raw: val}
+					r.sensitive.addJSON(vars[name])
+				}
+			} else {
+				for _, pattern := range vf.VarsKeys {
+					oldKey, newKey, err := parseRenamePattern(pattern)
+					if err != nil {
+						log.Error(err, "unable to parse rename pattern")
+						return nil, err
+					}
+
+					val, ok := upstream.Outputs[oldKey]
+					if !ok {
+						err := fmt.Errorf("output %q not found in Terraform %s", oldKey, key)
+						log.Error(err, "unable to map the outputs of an upstream Terraform")
+						return nil, err
+					}
+					vars[newKey] = &apiextensionsv1.JSON{Raw: val}
+					r.sensitive.addJSON(vars[newKey])
+				}
+			}
+		default:
+			sourceVars, err := varsFromSource(ctx, r.Client, terraform.Namespace, vf)
+			if err != nil {
+				if vf.Optional && errors.Is(err, ErrVarsNotFound) {
+					continue
+				}
+				log.Error(err, "unable to get variables from source", "kind", vf.Kind, "name", vf.Name)
+				return nil, err
+			}
+			// external sources replace Secrets, so their values are sensitive
+			for key, val := range sourceVars {
+				vars[key] = val
+				r.sensitive.addJSON(val)
+			}
 		}
 	}
 
+	r.addSensitiveVariables(req.WorkingDir, vars)
+
 	jsonBytes, err := json2.Marshal(vars)
 	if err != nil {
 		log.Error(err, "unable to marshal the data")
@@ -387,3 +454,23 @@ func parseRenamePattern(pattern string) (string, string, error) {
 
 	return oldKey, newKey, nil
 }
+
+// addSensitiveVariables registers the values of the variables the module
+// declares sensitive. The module is parsed on a best effort basis, as
+// Terraform reports the errors of the module itself.
+func (r *TerraformRunnerServer) addSensitiveVariables(workingDir string, vars map[string]*apiextensionsv1.JSON) {
+	declared, err := moduleVariables(hclparse.NewParser(), workingDir)
+	if err != nil {
+		return
+	}
+
+	for name, v := range declared {
+		if !v.schema.Sensitive {
+			continue
+		}
+		r.sensitive.addJSON(vars[name])
+		if value, ok := r.envs["TF_VAR_"+name]; ok {
+			r.sensitive.add(value)
+		}
+	}
+}
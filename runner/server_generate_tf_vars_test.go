@@ -2,9 +2,16 @@ package runner
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
 	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 // Here goes your parseRenamePattern function.
@@ -60,3 +67,76 @@ func TestParseRenamePattern(t *testing.T) {
 		g.Expect(newKey).To(Equal(tt.newKey))
 	}
 }
+
+func TestGenerateVarsForTFFromTerraformOutputs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().Build(),
+		terraform: &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "apps"},
+			Spec: infrav1.TerraformSpec{
+				VarsFrom: []infrav1.VarsReference{
+					{Kind: infrav1.TerraformKind, Name: "network", Namespace: "infra"},
+					{Kind: infrav1.TerraformKind, Name: "database", VarsKeys: []string{"endpoint:db_endpoint"}},
+					{Kind: infrav1.TerraformKind, Name: "cache", Optional: true},
+				},
+			},
+		},
+	}
+
+	workingDir := t.TempDir()
+	_, err := server.GenerateVarsForTF(t.Context(), &GenerateVarsForTFRequest{
+		WorkingDir: workingDir,
+		TerraformOutputs: map[string]*TerraformOutputs{
+			"infra/network": {Outputs: map[string][]byte{
+				"vpc_id":  []byte(`"vpc-1234"`),
+				"subnets": []byte(`["a","b"]`),
+			}},
+			"apps/database": {Outputs: map[string][]byte{
+				"endpoint": []byte(`"db.example.com"`),
+				"port":     []byte(`5432`),
+			}},
+		},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	data, err := os.ReadFile(filepath.Join(workingDir, "generated.auto.tfvars.json"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(data).To(MatchJSON(`{"vpc_id":"vpc-1234","subnets":["a","b"],"db_endpoint":"db.example.com"}`))
+	// values read from Secrets are redacted from the output of the session
+	g.Expect(server.sensitive.redact("vpc_id = vpc-1234")).To(Equal("vpc_id = ***"))
+
+	// a required reference without outputs is an error
+	server.terraform.Spec.VarsFrom[2].Optional = false
+	_, err = server.GenerateVarsForTF(t.Context(), &GenerateVarsForTFRequest{WorkingDir: workingDir})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestGenerateVarsForTFRegistersOnlyTheRenderedInputs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithObjects(&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "apps"},
+			Data: map[string][]byte{
+				"password": []byte("p4ssw0rd"),
+				"username": []byte("postgres"),
+			},
+		}).Build(),
+		terraform: &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "apps"},
+			Spec: infrav1.TerraformSpec{
+				ReadInputsFromSecrets: []infrav1.ReadInputsFromSecretSpec{{Name: "db-credentials", As: "db"}},
+				Values:                &apiextensionsv1.JSON{Raw: []byte(`{"password":"${{ .db.password }}"}`)},
+			},
+		},
+	}
+
+	_, err := server.GenerateVarsForTF(t.Context(), &GenerateVarsForTFRequest{WorkingDir: t.TempDir()})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(server.sensitive.redact("password p4ssw0rd")).To(Equal("password ***"))
+	// the other keys of the Secret do not reach Terraform
+	g.Expect(server.sensitive.redact("user postgres")).To(Equal("user postgres"))
+}
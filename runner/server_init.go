@@ -15,6 +15,8 @@ import (
 
 	"github.com/hashicorp/terraform-exec/tfexec"
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
 )
 
 func (r *TerraformRunnerServer) tfInit(ctx context.Context, opts ...tfexec.InitOption) error {
@@ -51,10 +53,40 @@ func (r *TerraformRunnerServer) Init(ctx context.Context, req *InitRequest) (*In
 	terraform := r.terraform
 
 	log.Info("mapping the Spec.BackendConfigsFrom")
+	backendConfigsOpts, err := r.backendConfigOptions(ctx, terraform.Namespace, terraform.Spec.BackendConfigsFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	initOpts := []tfexec.InitOption{tfexec.Upgrade(req.Upgrade), tfexec.ForceCopy(req.ForceCopy)}
+	initOpts = append(initOpts, backendConfigsOpts...)
+	if err := r.tfInit(ctx, initOpts...); err != nil {
+		st := status.New(codes.Internal, err.Error())
+		var stateErr *StateLockError
+
+		if errors.As(err, &stateErr) {
+			st, err = st.WithDetails(&InitReply{Message: "not ok", StateLockIdentifier: stateErr.ID})
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		log.Error(err, "unable to initialize")
+		return nil, st.Err()
+	}
+
+	return &InitReply{Message: "ok"}, nil
+}
+
+// backendConfigOptions maps the given backend config references to -backend-config init options.
+func (r *TerraformRunnerServer) backendConfigOptions(ctx context.Context, namespace string, refs []infrav1.BackendConfigsReference) ([]tfexec.InitOption, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+
 	backendConfigsOpts := []tfexec.InitOption{}
-	for _, bf := range terraform.Spec.BackendConfigsFrom {
+	for _, bf := range refs {
 		objectKey := types.NamespacedName{
-			Namespace: terraform.Namespace,
+			Namespace: namespace,
 			Name:      bf.Name,
 		}
 		switch bf.Kind {
@@ -104,23 +136,5 @@ func (r *TerraformRunnerServer) Init(ctx context.Context, req *InitRequest) (*In
 		}
 	}
 
-	initOpts := []tfexec.InitOption{tfexec.Upgrade(req.Upgrade), tfexec.ForceCopy(req.ForceCopy)}
-	initOpts = append(initOpts, backendConfigsOpts...)
-	if err := r.tfInit(ctx, initOpts...); err != nil {
-		st := status.New(codes.Internal, err.Error())
-		var stateErr *StateLockError
-
-		if errors.As(err, &stateErr) {
-			st, err = st.WithDetails(&InitReply{Message: "not ok", StateLockIdentifier: stateErr.ID})
-
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		log.Error(err, "unable to initialize")
-		return nil, st.Err()
-	}
-
-	return &InitReply{Message: "ok"}, nil
+	return backendConfigsOpts, nil
 }
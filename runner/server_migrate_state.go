@@ -0,0 +1,138 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flux-iac/tofu-controller/api/statebackup"
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+)
+
+// MigrateState migrates the Terraform state from the previous backend configuration
+// to the current one. The previous backend is initialized first, so that its state
+// can be pulled and backed up, and is then re-initialized against the new backend
+// with -force-copy, which implies -migrate-state.
+func (r *TerraformRunnerServer) MigrateState(ctx context.Context, req *MigrateStateRequest) (*MigrateStateReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("migrating state")
+
+	if err := r.ValidateInstanceID(req.TfInstance); err != nil {
+		log.Error(err, "terraform session mismatch when migrating state")
+
+		return nil, err
+	}
+
+	terraform := r.terraform
+
+	if _, err := r.WriteBackendConfig(ctx, &WriteBackendConfigRequest{
+		DirPath:       req.WorkingDir,
+		BackendConfig: req.PreviousBackendConfig,
+	}); err != nil {
+		return nil, err
+	}
+
+	log.Info("initializing the previous backend")
+	previousOpts, err := r.backendConfigOptions(ctx, terraform.Namespace, terraform.Status.StateMigration.BackendConfigsFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.tfInit(ctx, previousOpts...); err != nil {
+		return nil, migrateStateError(fmt.Errorf("unable to initialize the previous backend: %w", err))
+	}
+
+	if ws := terraform.WorkspaceName(); ws != infrav1.DefaultWorkspaceName {
+		if err := r.tf.WorkspaceSelect(ctx, ws); err != nil {
+			log.Error(err, "unable to select workspace of the previous backend", "workspace", ws)
+			return nil, fmt.Errorf("failed to select workspace %s: %w", ws, err)
+		}
+	}
+
+	log.Info("backing up state", "migration", req.MigrationID)
+	state, err := r.tf.StatePull(ctx)
+	if err != nil {
+		log.Error(err, "unable to pull state")
+		return nil, err
+	}
+
+	// the previous backend may hold no state at all, leaving nothing to back up
+	var backupName string
+	if state != "" {
+		backupName, err = r.writeStateBackup(ctx, req.MigrationID, req.Revision, []byte(state))
+		if err != nil {
+			log.Error(err, "unable to back up state", "migration", req.MigrationID)
+			return nil, err
+		}
+	}
+
+	if _, err := r.WriteBackendConfig(ctx, &WriteBackendConfigRequest{
+		DirPath:       req.WorkingDir,
+		BackendConfig: req.BackendConfig,
+	}); err != nil {
+		return nil, err
+	}
+
+	log.Info("initializing the new backend")
+	opts, err := r.backendConfigOptions(ctx, terraform.Namespace, terraform.Spec.BackendConfigsFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	initOpts := []tfexec.InitOption{tfexec.ForceCopy(true)}
+	initOpts = append(initOpts, opts...)
+	if err := r.tfInit(ctx, initOpts...); err != nil {
+		return nil, migrateStateError(err)
+	}
+
+	return &MigrateStateReply{Message: "ok", BackupName: backupName}, nil
+}
+
+func migrateStateError(err error) error {
+	st := status.New(codes.Internal, err.Error())
+
+	var stateErr *StateLockError
+	if errors.As(err, &stateErr) {
+		withDetails, detailsErr := st.WithDetails(&MigrateStateReply{Message: "not ok", StateLockIdentifier: stateErr.ID})
+		if detailsErr != nil {
+			return detailsErr
+		}
+		st = withDetails
+	}
+
+	return st.Err()
+}
+
+// writeStateBackup stores the state in a Secret next to the Terraform object. The Secret
+// is not owned by the Terraform object, so that the backup outlives it.
+func (r *TerraformRunnerServer) writeStateBackup(ctx context.Context, migrationID, revision string, state []byte) (string, error) {
+	secret, err := statebackup.ToSecret(r.terraform, r.terraform.Namespace, statebackup.ReasonMigration, migrationID, revision, state)
+	if err != nil {
+		return "", err
+	}
+
+	err = r.Create(ctx, secret)
+	if !apierrors.IsAlreadyExists(err) {
+		return secret.Name, err
+	}
+
+	// a previous attempt of the same migration failed after taking the backup,
+	// the previous backend still holds the state, so the backup is refreshed
+	var existing corev1.Secret
+	if err := r.Get(ctx, client.ObjectKeyFromObject(secret), &existing); err != nil {
+		return "", err
+	}
+	existing.Labels = secret.Labels
+	existing.Annotations = secret.Annotations
+	existing.Data = secret.Data
+
+	return secret.Name, r.Update(ctx, &existing)
+}
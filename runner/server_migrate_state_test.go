@@ -0,0 +1,51 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestWriteStateBackupRetry(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1.AddToScheme(scheme))
+
+	// as the API server, refuse the updates without a resource version
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if obj.GetResourceVersion() == "" {
+				return fmt.Errorf("metadata.resourceVersion: Invalid value: 0x0: must be specified for an update")
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	}).Build()
+
+	server := &TerraformRunnerServer{
+		Client: kubeClient,
+		terraform: &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		},
+	}
+
+	name, err := server.writeStateBackup(t.Context(), "1234", "main@sha1:1", []byte(`{"serial": 1, "lineage": "a"}`))
+	assert.NoError(t, err)
+
+	// a retry of the same migration refreshes the backup
+	retried, err := server.writeStateBackup(t.Context(), "1234", "main@sha1:2", []byte(`{"serial": 2, "lineage": "a"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, name, retried)
+
+	var secret v1.Secret
+	assert.NoError(t, kubeClient.Get(t.Context(), types.NamespacedName{Namespace: "flux-system", Name: name}, &secret))
+	assert.Equal(t, "2", secret.Annotations["infra.contrib.fluxcd.io/state-backup-serial"])
+}
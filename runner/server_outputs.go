@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
 
 	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
 	"github.com/hashicorp/terraform-exec/tfexec"
@@ -12,9 +13,15 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// outputsSourceNamespaceLabel records the namespace of the Terraform object
+// which wrote outputs to a remote cluster.
+const outputsSourceNamespaceLabel = "infra.contrib.fluxcd.io/terraform-namespace"
+
 func (r *TerraformRunnerServer) tfOutput(ctx context.Context, opts ...tfexec.OutputOption) (map[string]tfexec.OutputMeta, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 
@@ -56,16 +63,45 @@ func (r *TerraformRunnerServer) Output(ctx context.Context, req *OutputRequest)
 
 func (r *TerraformRunnerServer) WriteOutputs(ctx context.Context, req *WriteOutputsRequest) (*WriteOutputsReply, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+
+	c, err := r.outputsClient(ctx, req.Namespace, req.KubeConfigSecretName, req.KubeConfigSecretKey)
+	if err != nil {
+		log.Error(err, "unable to get the client of the remote cluster", "kubeConfigSecret", req.KubeConfigSecretName)
+		return nil, err
+	}
+
+	if req.Kind == "ConfigMap" {
+		return r.writeOutputsToConfigMap(ctx, c, req)
+	}
+
 	log.Info("write outputs to secret")
 
-	objectKey := types.NamespacedName{Namespace: req.Namespace, Name: req.SecretName}
+	secretType := corev1.SecretType(req.SecretType)
+	if secretType == "" {
+		secretType = corev1.SecretTypeOpaque
+	}
+
+	objectKey := types.NamespacedName{Namespace: outputsNamespace(req.Namespace, req.TargetNamespace), Name: req.SecretName}
 	var outputSecret corev1.Secret
 
+	objectMeta := outputsObjectMeta(req)
+
 	drift := true
 	create := true
-	if err := r.Get(ctx, objectKey, &outputSecret); err == nil {
-		// if everything is there, we don't write anything
-		if reflect.DeepEqual(outputSecret.Data, req.Data) {
+	if err := c.Get(ctx, objectKey, &outputSecret); err == nil {
+		if err := checkOutputsOwner(&outputSecret, req.Namespace, req.Name, req.Uuid, req.KubeConfigSecretName != ""); err != nil {
+			log.Error(err, "refusing to write outputs")
+			return nil, err
+		}
+
+		if outputSecret.Type != secretType {
+			// the type of a Secret is immutable, so it has to be re-created
+			if err := c.Delete(ctx, &outputSecret); err != nil {
+				log.Error(err, "unable to delete secret of type", "type", outputSecret.Type)
+				return nil, err
+			}
+		} else if reflect.DeepEqual(outputSecret.Data, req.Data) && !mergeOutputsMetadata(&outputSecret, objectMeta) {
+			// if everything is there, we don't write anything
 			drift = false
 		} else {
 			// found, but need update
@@ -78,35 +114,20 @@ func (r *TerraformRunnerServer) WriteOutputs(ctx context.Context, req *WriteOutp
 
 	if drift {
 		if create {
-			vTrue := true
 			outputSecret = corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:        req.SecretName,
-					Namespace:   req.Namespace,
-					Labels:      req.Labels,
-					Annotations: req.Annotations,
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							APIVersion: infrav1.GroupVersion.Group + "/" + infrav1.GroupVersion.Version,
-							Kind:       infrav1.TerraformKind,
-							Name:       req.Name,
-							UID:        types.UID(req.Uuid),
-							Controller: &vTrue,
-						},
-					},
-				},
-				Type: corev1.SecretTypeOpaque,
-				Data: req.Data,
+				ObjectMeta: objectMeta,
+				Type:       secretType,
+				Data:       req.Data,
 			}
 
-			err := r.Create(ctx, &outputSecret)
+			err := c.Create(ctx, &outputSecret)
 			if err != nil {
 				log.Error(err, "unable to create secret")
 				return nil, err
 			}
 		} else {
 			outputSecret.Data = req.Data
-			err := r.Update(ctx, &outputSecret)
+			err := c.Update(ctx, &outputSecret)
 			if err != nil {
 				log.Error(err, "unable to update secret")
 				return nil, err
@@ -119,6 +140,231 @@ func (r *TerraformRunnerServer) WriteOutputs(ctx context.Context, req *WriteOutp
 	return &WriteOutputsReply{Message: "ok", Changed: false}, nil
 }
 
+func (r *TerraformRunnerServer) writeOutputsToConfigMap(ctx context.Context, c client.Client, req *WriteOutputsRequest) (*WriteOutputsReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("write outputs to configmap")
+
+	data := map[string]string{}
+	for k, v := range req.Data {
+		data[k] = string(v)
+	}
+
+	objectMeta := outputsObjectMeta(req)
+
+	objectKey := types.NamespacedName{Namespace: outputsNamespace(req.Namespace, req.TargetNamespace), Name: req.SecretName}
+	var outputConfigMap corev1.ConfigMap
+	if err := c.Get(ctx, objectKey, &outputConfigMap); err == nil {
+		if err := checkOutputsOwner(&outputConfigMap, req.Namespace, req.Name, req.Uuid, req.KubeConfigSecretName != ""); err != nil {
+			log.Error(err, "refusing to write outputs")
+			return nil, err
+		}
+
+		metadataChanged := mergeOutputsMetadata(&outputConfigMap, objectMeta)
+		if reflect.DeepEqual(outputConfigMap.Data, data) && !metadataChanged {
+			return &WriteOutputsReply{Message: "ok", Changed: false}, nil
+		}
+
+		outputConfigMap.Data = data
+		if err := c.Update(ctx, &outputConfigMap); err != nil {
+			log.Error(err, "unable to update configmap")
+			return nil, err
+		}
+
+		return &WriteOutputsReply{Message: "ok", Changed: true}, nil
+	} else if !apierrors.IsNotFound(err) {
+		log.Error(err, "unable to get output configmap")
+		return nil, err
+	}
+
+	outputConfigMap = corev1.ConfigMap{
+		ObjectMeta: objectMeta,
+		Data:       data,
+	}
+	if err := c.Create(ctx, &outputConfigMap); err != nil {
+		log.Error(err, "unable to create configmap")
+		return nil, err
+	}
+
+	return &WriteOutputsReply{Message: "ok", Changed: true}, nil
+}
+
+// outputsObjectMeta returns the metadata of an object holding outputs. In the
+// local cluster, the object is owned by the Terraform object. In a remote cluster,
+// it is labelled with the Terraform object instead, as owner references cannot
+// cross clusters.
+func outputsObjectMeta(req *WriteOutputsRequest) metav1.ObjectMeta {
+	objectMeta := metav1.ObjectMeta{
+		Name:        req.SecretName,
+		Namespace:   outputsNamespace(req.Namespace, req.TargetNamespace),
+		Labels:      req.Labels,
+		Annotations: req.Annotations,
+	}
+
+	if req.KubeConfigSecretName != "" {
+		labels := map[string]string{}
+		for k, v := range req.Labels {
+			labels[k] = v
+		}
+		labels[infrav1.RunnerLabel] = req.Name
+		labels[outputsSourceNamespaceLabel] = req.Namespace
+		objectMeta.Labels = labels
+		return objectMeta
+	}
+
+	vTrue := true
+	objectMeta.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: infrav1.GroupVersion.Group + "/" + infrav1.GroupVersion.Version,
+			Kind:       infrav1.TerraformKind,
+			Name:       req.Name,
+			UID:        types.UID(req.Uuid),
+			Controller: &vTrue,
+		},
+	}
+	return objectMeta
+}
+
+// checkOutputsOwner returns an error unless the existing object holding outputs
+// has been written for the Terraform object of the request: controlled by it in
+// the local cluster, or labelled with it in a remote cluster. Objects written by
+// anything else are never overwritten nor deleted.
+func checkOutputsOwner(obj client.Object, namespace, name, uid string, remote bool) error {
+	if remote {
+		labels := obj.GetLabels()
+		if labels[infrav1.RunnerLabel] == name && labels[outputsSourceNamespaceLabel] == namespace {
+			return nil
+		}
+	} else if owner := metav1.GetControllerOf(obj); owner != nil && owner.UID == types.UID(uid) {
+		return nil
+	}
+
+	return fmt.Errorf("%s/%s already exists and is not managed by Terraform %s/%s",
+		obj.GetNamespace(), obj.GetName(), namespace, name)
+}
+
+// mergeOutputsMetadata sets the wanted labels and annotations on an existing
+// object, keeping the ones set by others. It reports whether the object has
+// changed.
+func mergeOutputsMetadata(obj client.Object, wanted metav1.ObjectMeta) bool {
+	changed := false
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for k, v := range wanted.Labels {
+		if value, ok := labels[k]; !ok || value != v {
+			labels[k] = v
+			changed = true
+		}
+	}
+	obj.SetLabels(labels)
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for k, v := range wanted.Annotations {
+		if value, ok := annotations[k]; !ok || value != v {
+			annotations[k] = v
+			changed = true
+		}
+	}
+	obj.SetAnnotations(annotations)
+
+	return changed
+}
+
+// DeleteOutputs deletes an object holding outputs, which is not garbage collected
+// as it lives in a remote cluster. Only an object labelled with the Terraform
+// object is deleted.
+func (r *TerraformRunnerServer) DeleteOutputs(ctx context.Context, req *DeleteOutputsRequest) (*DeleteOutputsReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("delete outputs", "kind", req.Kind, "name", req.SecretName)
+
+	c, err := r.outputsClient(ctx, req.Namespace, req.KubeConfigSecretName, req.KubeConfigSecretKey)
+	if err != nil {
+		log.Error(err, "unable to get the client of the remote cluster", "kubeConfigSecret", req.KubeConfigSecretName)
+		return nil, err
+	}
+
+	var obj client.Object = &corev1.Secret{}
+	if req.Kind == "ConfigMap" {
+		obj = &corev1.ConfigMap{}
+	}
+	objectKey := types.NamespacedName{Namespace: outputsNamespace(req.Namespace, req.TargetNamespace), Name: req.SecretName}
+	if err := c.Get(ctx, objectKey, obj); apierrors.IsNotFound(err) {
+		return &DeleteOutputsReply{Message: "ok"}, nil
+	} else if err != nil {
+		log.Error(err, "unable to get outputs", "kind", req.Kind, "name", req.SecretName)
+		return nil, err
+	}
+
+	if err := checkOutputsOwner(obj, req.Namespace, req.Name, "", true); err != nil {
+		log.Error(err, "refusing to delete outputs")
+		return nil, err
+	}
+
+	uid := obj.GetUID()
+	if err := c.Delete(ctx, obj, client.Preconditions{UID: &uid}); err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, "unable to delete outputs", "kind", req.Kind, "name", req.SecretName)
+		return nil, err
+	}
+
+	return &DeleteOutputsReply{Message: "ok"}, nil
+}
+
+// outputsClient returns the client to write outputs with, which is the client
+// of the local cluster, unless a Secret containing a kubeconfig is referenced.
+func (r *TerraformRunnerServer) outputsClient(ctx context.Context, namespace, kubeConfigSecretName, kubeConfigSecretKey string) (client.Client, error) {
+	if kubeConfigSecretName == "" {
+		return r.Client, nil
+	}
+
+	var secret corev1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: kubeConfigSecretName}, &secret); err != nil {
+		return nil, fmt.Errorf("unable to get the kubeconfig secret %s: %w", kubeConfigSecretName, err)
+	}
+
+	kubeConfig, err := kubeConfigFromSecret(&secret, kubeConfigSecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig in secret %s: %w", kubeConfigSecretName, err)
+	}
+
+	return client.New(restConfig, client.Options{Scheme: r.Scheme})
+}
+
+// kubeConfigFromSecret returns the kubeconfig stored in the key of the Secret,
+// which defaults to value, then value.yaml as for Flux Kustomizations.
+func kubeConfigFromSecret(secret *corev1.Secret, key string) ([]byte, error) {
+	keys := []string{key}
+	if key == "" {
+		keys = []string{"value", "value.yaml"}
+	}
+
+	for _, k := range keys {
+		if kubeConfig, ok := secret.Data[k]; ok && len(kubeConfig) > 0 {
+			return kubeConfig, nil
+		}
+	}
+
+	return nil, fmt.Errorf("secret %s does not contain a kubeconfig in the key(s) %s", secret.Name, strings.Join(keys, ", "))
+}
+
+// outputsNamespace returns the namespace of the outputs, which defaults to the
+// namespace of the Terraform object.
+func outputsNamespace(namespace, targetNamespace string) string {
+	if targetNamespace != "" {
+		return targetNamespace
+	}
+	return namespace
+}
+
 func (r *TerraformRunnerServer) GetOutputs(ctx context.Context, req *GetOutputsRequest) (*GetOutputsReply, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("get outputs")
@@ -0,0 +1,239 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWriteOutputsTypedSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1.AddToScheme(scheme))
+
+	vTrue := true
+	opaque := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "db-tls",
+			Namespace:       "flux-system",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Terraform", Name: "helloworld", UID: "1234", Controller: &vTrue}},
+		},
+		Type: v1.SecretTypeOpaque,
+		Data:       map[string][]byte{"certificate": []byte("cert")},
+	}
+
+	server := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(opaque).Build(),
+	}
+
+	data := map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")}
+	req := &WriteOutputsRequest{
+		Namespace:  "flux-system",
+		Name:       "helloworld",
+		SecretName: "db-tls",
+		Uuid:       "1234",
+		Data:       data,
+		Kind:       "Secret",
+		SecretType: string(v1.SecretTypeTLS),
+	}
+
+	reply, err := server.WriteOutputs(t.Context(), req)
+	assert.NoError(t, err)
+	assert.True(t, reply.Changed)
+
+	var secret v1.Secret
+	assert.NoError(t, server.Get(t.Context(), types.NamespacedName{Namespace: "flux-system", Name: "db-tls"}, &secret))
+	assert.Equal(t, v1.SecretTypeTLS, secret.Type)
+	assert.Equal(t, data, secret.Data)
+	assert.Equal(t, "helloworld", secret.OwnerReferences[0].Name)
+
+	reply, err = server.WriteOutputs(t.Context(), req)
+	assert.NoError(t, err)
+	assert.False(t, reply.Changed)
+}
+
+func TestWriteOutputsConfigMap(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1.AddToScheme(scheme))
+
+	server := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+	}
+
+	req := &WriteOutputsRequest{
+		Namespace:  "flux-system",
+		Name:       "helloworld",
+		SecretName: "db",
+		Uuid:       "1234",
+		Data:       map[string][]byte{"endpoint": []byte("db.example.com")},
+		Labels:     map[string]string{"app": "db"},
+		Kind:       "ConfigMap",
+	}
+
+	reply, err := server.WriteOutputs(t.Context(), req)
+	assert.NoError(t, err)
+	assert.True(t, reply.Changed)
+
+	var cm v1.ConfigMap
+	assert.NoError(t, server.Get(t.Context(), types.NamespacedName{Namespace: "flux-system", Name: "db"}, &cm))
+	assert.Equal(t, map[string]string{"endpoint": "db.example.com"}, cm.Data)
+	assert.Equal(t, "db", cm.Labels["app"])
+
+	reply, err = server.WriteOutputs(t.Context(), req)
+	assert.NoError(t, err)
+	assert.False(t, reply.Changed)
+
+	req.Data = map[string][]byte{"endpoint": []byte("db2.example.com")}
+	reply, err = server.WriteOutputs(t.Context(), req)
+	assert.NoError(t, err)
+	assert.True(t, reply.Changed)
+
+	// the labels and annotations are reconciled along with the data
+	req.Labels = map[string]string{"app": "database"}
+	req.Annotations = map[string]string{"team": "data"}
+	reply, err = server.WriteOutputs(t.Context(), req)
+	assert.NoError(t, err)
+	assert.True(t, reply.Changed)
+	assert.NoError(t, server.Get(t.Context(), types.NamespacedName{Namespace: "flux-system", Name: "db"}, &cm))
+	assert.Equal(t, "database", cm.Labels["app"])
+	assert.Equal(t, "data", cm.Annotations["team"])
+}
+
+func TestWriteOutputsRefusesUnownedObjects(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1.AddToScheme(scheme))
+
+	vTrue := true
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "flux-system"},
+		Type:       v1.SecretTypeOpaque,
+		Data:       map[string][]byte{"password": []byte("s3cr3t")},
+	}
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "app",
+			Namespace:       "flux-system",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Terraform", Name: "other", UID: "5678", Controller: &vTrue}},
+		},
+		Data: map[string]string{"endpoint": "other.example.com"},
+	}
+	server := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, cm).Build(),
+	}
+
+	_, err := server.WriteOutputs(t.Context(), &WriteOutputsRequest{
+		Namespace:  "flux-system",
+		Name:       "helloworld",
+		SecretName: "db",
+		Uuid:       "1234",
+		Data:       map[string][]byte{"tls.crt": []byte("cert")},
+		Kind:       "Secret",
+		SecretType: string(v1.SecretTypeTLS),
+	})
+	assert.Error(t, err)
+
+	_, err = server.WriteOutputs(t.Context(), &WriteOutputsRequest{
+		Namespace:  "flux-system",
+		Name:       "helloworld",
+		SecretName: "app",
+		Uuid:       "1234",
+		Data:       map[string][]byte{"endpoint": []byte("db.example.com")},
+		Kind:       "ConfigMap",
+	})
+	assert.Error(t, err)
+
+	// neither object has been touched
+	var written v1.Secret
+	assert.NoError(t, server.Get(t.Context(), types.NamespacedName{Namespace: "flux-system", Name: "db"}, &written))
+	assert.Equal(t, secret.Data, written.Data)
+	var writtenCM v1.ConfigMap
+	assert.NoError(t, server.Get(t.Context(), types.NamespacedName{Namespace: "flux-system", Name: "app"}, &writtenCM))
+	assert.Equal(t, cm.Data, writtenCM.Data)
+}
+
+func TestRemoteOutputsObjectMeta(t *testing.T) {
+	objectMeta := outputsObjectMeta(&WriteOutputsRequest{
+		Namespace:            "flux-system",
+		Name:                 "helloworld",
+		SecretName:           "db",
+		Uuid:                 "1234",
+		Labels:               map[string]string{"app": "db"},
+		TargetNamespace:      "apps",
+		KubeConfigSecretName: "workload-kubeconfig",
+	})
+
+	assert.Equal(t, "apps", objectMeta.Namespace)
+	assert.Empty(t, objectMeta.OwnerReferences)
+	assert.Equal(t, map[string]string{
+		"app":                               "db",
+		"infra.contrib.fluxcd.io/terraform": "helloworld",
+		outputsSourceNamespaceLabel:         "flux-system",
+	}, objectMeta.Labels)
+}
+
+func TestKubeConfigFromSecret(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-kubeconfig"},
+		Data:       map[string][]byte{"value.yaml": []byte("apiVersion: v1")},
+	}
+
+	kubeConfig, err := kubeConfigFromSecret(secret, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "apiVersion: v1", string(kubeConfig))
+
+	_, err = kubeConfigFromSecret(secret, "kubeconfig")
+	assert.Error(t, err)
+}
+
+func TestDeleteOutputs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1.AddToScheme(scheme))
+
+	cm := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name:      "db",
+		Namespace: "flux-system",
+		Labels: map[string]string{
+			"infra.contrib.fluxcd.io/terraform": "helloworld",
+			outputsSourceNamespaceLabel:         "flux-system",
+		},
+	}}
+	unrelated := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "flux-system"}}
+	server := &TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm, unrelated).Build(),
+	}
+
+	req := &DeleteOutputsRequest{Namespace: "flux-system", Name: "helloworld", SecretName: "db", Kind: "ConfigMap"}
+	_, err := server.DeleteOutputs(t.Context(), req)
+	assert.NoError(t, err)
+
+	// deleting again is not an error
+	_, err = server.DeleteOutputs(t.Context(), req)
+	assert.NoError(t, err)
+
+	// an object not labelled with the Terraform object is left alone
+	_, err = server.DeleteOutputs(t.Context(), &DeleteOutputsRequest{Namespace: "flux-system", Name: "helloworld", SecretName: "db", Kind: "Secret"})
+	assert.Error(t, err)
+	assert.NoError(t, server.Get(t.Context(), types.NamespacedName{Namespace: "flux-system", Name: "db"}, &v1.Secret{}))
+}
+
+func TestCheckRemoteOutputsOwner(t *testing.T) {
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "apps",
+			Labels: map[string]string{
+				"infra.contrib.fluxcd.io/terraform": "helloworld",
+				outputsSourceNamespaceLabel:         "other-namespace",
+			},
+		},
+	}
+
+	// a remote object is matched by both labels, as names repeat across namespaces
+	assert.Error(t, checkOutputsOwner(cm, "flux-system", "helloworld", "", true))
+	assert.Error(t, checkOutputsOwner(cm, "other-namespace", "other", "", true))
+	assert.NoError(t, checkOutputsOwner(cm, "other-namespace", "helloworld", "", true))
+}
@@ -101,8 +101,8 @@ func (r *TerraformRunnerServer) tfPlan(ctx context.Context, opts ...tfexec.PlanO
 	// sanitize the error message only if it's not a state lock error
 	var sl *StateLockError
 	if err != nil && !errors.As(err, &sl) {
-		fmt.Fprint(os.Stderr, sanitizeLog(errBuf.String()))
-		err = errors.New(sanitizeLog(err.Error()))
+		fmt.Fprint(os.Stderr, r.sensitive.redact(sanitizeLog(errBuf.String())))
+		err = errors.New(r.sensitive.redact(sanitizeLog(err.Error())))
 	}
 
 	return diff, err
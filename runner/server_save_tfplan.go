@@ -83,6 +83,9 @@ func (r *TerraformRunnerServer) SaveTFPlan(ctx context.Context, req *SaveTFPlanR
 			return nil, err
 		}
 
+		// the readable plan is stored in ConfigMaps and shown in pull requests
+		rawOutput = r.sensitive.redact(rawOutput)
+
 		rawPlan, err := plan.NewFromBytes(req.Name, req.Namespace, r.terraform.WorkspaceName(), req.Uuid, planId, []byte(rawOutput))
 		if err != nil {
 			log.Error(err, "Unable to create plan")
@@ -23,7 +23,7 @@ func (r *TerraformRunnerServer) ShowPlanFileRaw(ctx context.Context, req *ShowPl
 		return nil, err
 	}
 
-	return &ShowPlanFileRawReply{RawOutput: rawOutput}, nil
+	return &ShowPlanFileRawReply{RawOutput: r.sensitive.redact(rawOutput)}, nil
 }
 
 func (r *TerraformRunnerServer) ShowPlanFile(ctx context.Context, req *ShowPlanFileRequest) (*ShowPlanFileReply, error) {
@@ -48,5 +48,11 @@ func (r *TerraformRunnerServer) ShowPlanFile(ctx context.Context, req *ShowPlanF
 		return nil, err
 	}
 
+	jsonBytes, err = r.sensitive.redactJSON(jsonBytes)
+	if err != nil {
+		log.Error(err, "unable to redact the json plan")
+		return nil, err
+	}
+
 	return &ShowPlanFileReply{JsonOutput: jsonBytes}, nil
 }
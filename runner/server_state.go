@@ -0,0 +1,268 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func (r *TerraformRunnerServer) StatePull(ctx context.Context, req *StatePullRequest) (*StatePullReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("pulling state")
+
+	if err := r.ValidateInstanceID(req.TfInstance); err != nil {
+		log.Error(err, "terraform session mismatch when pulling state")
+
+		return nil, err
+	}
+
+	state, err := r.tf.StatePull(ctx)
+	if err != nil {
+		log.Error(err, "unable to pull state")
+		return nil, err
+	}
+
+	return &StatePullReply{State: []byte(state)}, nil
+}
+
+func (r *TerraformRunnerServer) StatePush(ctx context.Context, req *StatePushRequest) (*StatePushReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("pushing state")
+
+	if err := r.ValidateInstanceID(req.TfInstance); err != nil {
+		log.Error(err, "terraform session mismatch when pushing state")
+
+		return nil, err
+	}
+
+	stateFile, err := os.CreateTemp("", "tfstate-*.json")
+	if err != nil {
+		log.Error(err, "unable to create state file")
+		return nil, err
+	}
+	defer os.Remove(stateFile.Name())
+
+	if _, err := stateFile.Write(req.State); err != nil {
+		stateFile.Close()
+		log.Error(err, "unable to write state file", "path", stateFile.Name())
+		return nil, err
+	}
+	if err := stateFile.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := r.tf.StatePush(ctx, stateFile.Name(), tfexec.Force(req.Force)); err != nil {
+		err = r.tf.NormalizeError(err)
+		st := status.New(codes.Internal, err.Error())
+		var stateErr *StateLockError
+
+		if errors.As(err, &stateErr) {
+			st, err = st.WithDetails(&StatePushReply{Message: "not ok", StateLockIdentifier: stateErr.ID})
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		log.Error(err, "unable to push state")
+		return nil, st.Err()
+	}
+
+	return &StatePushReply{Message: "ok"}, nil
+}
+
+// stateResource holds the fields of a resource in the Terraform state used to build its address.
+type stateResource struct {
+	Module    string `json:"module"`
+	Mode      string `json:"mode"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Instances []struct {
+		IndexKey any `json:"index_key"`
+	} `json:"instances"`
+}
+
+// stateAddresses returns the addresses of the resource instances in the state,
+// in the format of `terraform state list`.
+func stateAddresses(state []byte) ([]string, error) {
+	var s struct {
+		Resources []stateResource `json:"resources"`
+	}
+	if len(state) == 0 {
+		return nil, nil
+	}
+	if err := json.Unmarshal(state, &s); err != nil {
+		return nil, fmt.Errorf("unable to parse the Terraform state: %w", err)
+	}
+
+	var addresses []string
+	for _, resource := range s.Resources {
+		address := resource.Type + "." + resource.Name
+		if resource.Mode == "data" {
+			address = "data." + address
+		}
+		if resource.Module != "" {
+			address = resource.Module + "." + address
+		}
+
+		for _, instance := range resource.Instances {
+			switch key := instance.IndexKey.(type) {
+			case float64:
+				addresses = append(addresses, fmt.Sprintf("%s[%d]", address, int64(key)))
+			case string:
+				addresses = append(addresses, fmt.Sprintf("%s[%q]", address, key))
+			default:
+				addresses = append(addresses, address)
+			}
+		}
+	}
+
+	sort.Strings(addresses)
+	return addresses, nil
+}
+
+// matchAddress returns true if the address is, or is contained in, one of the filters.
+func matchAddress(address string, filters []string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+
+	for _, filter := range filters {
+		if address == filter ||
+			strings.HasPrefix(address, filter+".") ||
+			strings.HasPrefix(address, filter+"[") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *TerraformRunnerServer) StateList(ctx context.Context, req *StateListRequest) (*StateListReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("listing state")
+
+	if err := r.ValidateInstanceID(req.TfInstance); err != nil {
+		log.Error(err, "terraform session mismatch when listing state")
+
+		return nil, err
+	}
+
+	state, err := r.tf.StatePull(ctx)
+	if err != nil {
+		log.Error(err, "unable to pull state")
+		return nil, err
+	}
+
+	addresses, err := stateAddresses([]byte(state))
+	if err != nil {
+		log.Error(err, "unable to list state")
+		return nil, err
+	}
+
+	reply := &StateListReply{}
+	for _, address := range addresses {
+		if matchAddress(address, req.Addresses) {
+			reply.Addresses = append(reply.Addresses, address)
+		}
+	}
+
+	return reply, nil
+}
+
+func (r *TerraformRunnerServer) StateMove(ctx context.Context, req *StateMoveRequest) (*StateMoveReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("moving state", "source", req.Source, "destination", req.Destination)
+
+	if err := r.ValidateInstanceID(req.TfInstance); err != nil {
+		log.Error(err, "terraform session mismatch when moving state")
+
+		return nil, err
+	}
+
+	if err := r.tf.StateMv(ctx, req.Source, req.Destination, tfexec.Lock(true)); err != nil {
+		log.Error(err, "unable to move state", "source", req.Source, "destination", req.Destination)
+		return nil, stateOperationError(err, func(lockID string) protoadapt.MessageV1 {
+			return &StateMoveReply{Message: "not ok", StateLockIdentifier: lockID}
+		})
+	}
+
+	return &StateMoveReply{Message: fmt.Sprintf("Moved %s to %s", req.Source, req.Destination)}, nil
+}
+
+func (r *TerraformRunnerServer) StateRemove(ctx context.Context, req *StateRemoveRequest) (*StateRemoveReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("removing from state", "addresses", req.Addresses)
+
+	if err := r.ValidateInstanceID(req.TfInstance); err != nil {
+		log.Error(err, "terraform session mismatch when removing from state")
+
+		return nil, err
+	}
+
+	var removed []string
+	for _, address := range req.Addresses {
+		if err := r.tf.StateRm(ctx, address, tfexec.Lock(true)); err != nil {
+			log.Error(err, "unable to remove from state", "address", address, "removed", removed)
+			if len(removed) > 0 {
+				err = fmt.Errorf("%w (already removed: %s)", err, strings.Join(removed, ", "))
+			}
+			return nil, stateOperationError(err, func(lockID string) protoadapt.MessageV1 {
+				return &StateRemoveReply{Message: "not ok", StateLockIdentifier: lockID, RemovedAddresses: removed}
+			})
+		}
+		removed = append(removed, address)
+	}
+
+	return &StateRemoveReply{Message: fmt.Sprintf("Removed %s", strings.Join(req.Addresses, ", "))}, nil
+}
+
+func (r *TerraformRunnerServer) Import(ctx context.Context, req *ImportRequest) (*ImportReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("importing into state", "address", req.Address, "id", req.Id)
+
+	if err := r.ValidateInstanceID(req.TfInstance); err != nil {
+		log.Error(err, "terraform session mismatch when importing into state")
+
+		return nil, err
+	}
+
+	if err := r.tf.Import(ctx, req.Address, req.Id, tfexec.Lock(true)); err != nil {
+		log.Error(err, "unable to import into state", "address", req.Address, "id", req.Id)
+		return nil, stateOperationError(err, func(lockID string) protoadapt.MessageV1 {
+			return &ImportReply{Message: "not ok", StateLockIdentifier: lockID}
+		})
+	}
+
+	return &ImportReply{Message: fmt.Sprintf("Imported %s as %s", req.Id, req.Address)}, nil
+}
+
+// stateOperationError converts the error of a state operation into a gRPC error,
+// carrying the reply built by details, with the identifier of the state lock if
+// the state is locked.
+func stateOperationError(err error, details func(lockID string) protoadapt.MessageV1) error {
+	st := status.New(codes.Internal, err.Error())
+
+	var lockID string
+	var stateErr *StateLockError
+	if errors.As(err, &stateErr) {
+		lockID = stateErr.ID
+	}
+
+	withDetails, detailsErr := st.WithDetails(details(lockID))
+	if detailsErr != nil {
+		return detailsErr
+	}
+
+	return withDetails.Err()
+}
@@ -0,0 +1,95 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/status"
+)
+
+func TestStateAddresses(t *testing.T) {
+	state := []byte(`{
+  "version": 4,
+  "serial": 3,
+  "resources": [
+    {"mode": "managed", "type": "aws_instance", "name": "web", "instances": [{"index_key": 1}, {"index_key": 0}]},
+    {"mode": "data", "type": "aws_ami", "name": "ubuntu", "instances": [{}]},
+    {"module": "module.network", "mode": "managed", "type": "aws_subnet", "name": "private", "instances": [{"index_key": "a"}]},
+    {"mode": "managed", "type": "random_pet", "name": "name", "instances": [{}]}
+  ]
+}`)
+
+	addresses, err := stateAddresses(state)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"aws_instance.web[0]",
+		"aws_instance.web[1]",
+		"data.aws_ami.ubuntu",
+		`module.network.aws_subnet.private["a"]`,
+		"random_pet.name",
+	}, addresses)
+
+	addresses, err = stateAddresses(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, addresses)
+
+	_, err = stateAddresses([]byte("not a state"))
+	assert.Error(t, err)
+}
+
+func TestMatchAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		filters []string
+		want    bool
+	}{
+		{address: "random_pet.name", filters: nil, want: true},
+		{address: "aws_instance.web[0]", filters: []string{"aws_instance.web"}, want: true},
+		{address: "aws_instance.web[0]", filters: []string{"aws_instance.web[0]"}, want: true},
+		{address: "aws_instance.webserver", filters: []string{"aws_instance.web"}, want: false},
+		{address: "module.network.aws_subnet.private", filters: []string{"module.network"}, want: true},
+		{address: "module.networks.aws_subnet.private", filters: []string{"module.network"}, want: false},
+		{address: "random_pet.name", filters: []string{"aws_instance.web", "random_pet.name"}, want: true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchAddress(tt.address, tt.filters), "address %s, filters %v", tt.address, tt.filters)
+	}
+}
+
+func TestStateRemoveReportsRemovedAddresses(t *testing.T) {
+	// a terraform binary failing to remove aws_instance.web
+	dir := t.TempDir()
+	execPath := filepath.Join(dir, "terraform")
+	script := `#!/bin/sh
+for arg; do address=$arg; done
+if [ "$address" = "aws_instance.web" ]; then
+  echo "Error: Invalid target address" >&2
+  exit 1
+fi
+`
+	assert.NoError(t, os.WriteFile(execPath, []byte(script), 0o755))
+
+	tf, err := tfexec.NewTerraform(dir, execPath)
+	assert.NoError(t, err)
+
+	server := &TerraformRunnerServer{InstanceID: "1234", tf: NewTerraformExecWrapper(tf)}
+
+	_, err = server.StateRemove(t.Context(), &StateRemoveRequest{
+		TfInstance: "1234",
+		Addresses:  []string{"random_pet.name", "aws_instance.web", "random_pet.other"},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "already removed: random_pet.name")
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Len(t, st.Details(), 1)
+	reply, ok := st.Details()[0].(*StateRemoveReply)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"random_pet.name"}, reply.RemovedAddresses)
+	assert.Empty(t, reply.StateLockIdentifier)
+}
@@ -0,0 +1,207 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// generatedFiles are the files written into the module by the runner itself,
+// which are not subject to the format check.
+var generatedFiles = map[string]bool{
+	"backend_override.tf": true,
+	importsFileName:       true,
+	movesFileName:         true,
+}
+
+// Validate runs `terraform validate` and, if requested, `terraform fmt -check`
+// against the initialized working directory. Validation errors are reported in
+// the reply rather than as an error, so the controller can tell them apart from
+// failures to run the commands.
+func (r *TerraformRunnerServer) Validate(ctx context.Context, req *ValidateRequest) (*ValidateReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("validating the module")
+
+	if err := r.ValidateInstanceID(req.TfInstance); err != nil {
+		log.Error(err, "terraform session mismatch when validating")
+
+		return nil, err
+	}
+
+	output, err := r.tf.Validate(ctx)
+	if err != nil {
+		log.Error(err, "unable to validate the module")
+		return nil, err
+	}
+
+	reply := &ValidateReply{Valid: output.Valid}
+	for _, diagnostic := range output.Diagnostics {
+		if diagnostic.Severity != tfjson.DiagnosticSeverityError {
+			continue
+		}
+		reply.Diagnostics = append(reply.Diagnostics, r.sensitive.redact(formatDiagnostic(diagnostic)))
+	}
+
+	if req.CheckFormat {
+		_, files, err := r.tf.FormatCheck(ctx)
+		if err != nil {
+			log.Error(err, "unable to check the format of the module")
+			return nil, err
+		}
+
+		for _, file := range files {
+			if generatedFiles[filepath.Base(file)] {
+				continue
+			}
+			reply.UnformattedFiles = append(reply.UnformattedFiles, file)
+		}
+
+		if len(reply.UnformattedFiles) > 0 {
+			reply.Valid = false
+		}
+	}
+
+	switch {
+	case reply.Valid:
+		reply.Message = "The configuration is valid"
+	case len(reply.Diagnostics) > 0:
+		reply.Message = fmt.Sprintf("The configuration is invalid: %d error(s)", len(reply.Diagnostics))
+	default:
+		reply.Message = fmt.Sprintf("The configuration is not formatted: %d file(s)", len(reply.UnformattedFiles))
+	}
+
+	return reply, nil
+}
+
+// Test runs `terraform test` against the *.tftest.hcl files of the module.
+func (r *TerraformRunnerServer) Test(ctx context.Context, req *TestRequest) (*TestReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("testing the module")
+
+	if err := r.ValidateInstanceID(req.TfInstance); err != nil {
+		log.Error(err, "terraform session mismatch when testing")
+
+		return nil, err
+	}
+
+	defer r.initLogger(log)
+
+	var output bytes.Buffer
+	// terraform test exits with an error when a test fails, so the result is taken
+	// from the summary and the error is only returned when there is no summary.
+	testErr := r.tf.Test(ctx, &output)
+
+	reply, err := parseTestOutput(output.Bytes())
+	if err != nil {
+		if testErr != nil {
+			err = testErr
+		}
+		log.Error(err, "unable to test the module")
+		return nil, err
+	}
+	reply.Failures = r.sensitive.redactAll(reply.Failures)
+
+	return reply, nil
+}
+
+// testMessage is a line of the machine-readable output of `terraform test -json`.
+type testMessage struct {
+	Type     string `json:"type"`
+	TestFile string `json:"@testfile"`
+	TestRun  string `json:"@testrun"`
+
+	Run *struct {
+		Path     string `json:"path"`
+		Run      string `json:"run"`
+		Progress string `json:"progress"`
+		Status   string `json:"status"`
+	} `json:"test_run"`
+
+	Summary *struct {
+		Status  string `json:"status"`
+		Passed  int32  `json:"passed"`
+		Failed  int32  `json:"failed"`
+		Errored int32  `json:"errored"`
+		Skipped int32  `json:"skipped"`
+	} `json:"test_summary"`
+
+	Diagnostic *tfjson.Diagnostic `json:"diagnostic"`
+}
+
+func parseTestOutput(output []byte) (*TestReply, error) {
+	var reply *TestReply
+	var failures []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var msg testMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+
+		switch msg.Type {
+		case "test_run":
+			if msg.Run == nil || msg.Run.Progress != "complete" {
+				continue
+			}
+			if msg.Run.Status == "fail" || msg.Run.Status == "error" {
+				failures = append(failures, fmt.Sprintf("%s, run %q: %s", msg.Run.Path, msg.Run.Run, msg.Run.Status))
+			}
+		case "diagnostic":
+			if msg.Diagnostic == nil || msg.Diagnostic.Severity != tfjson.DiagnosticSeverityError {
+				continue
+			}
+			diagnostic := formatDiagnostic(*msg.Diagnostic)
+			if msg.TestRun != "" {
+				diagnostic = fmt.Sprintf("%s, run %q: %s", msg.TestFile, msg.TestRun, diagnostic)
+			} else if msg.TestFile != "" {
+				diagnostic = fmt.Sprintf("%s: %s", msg.TestFile, diagnostic)
+			}
+			failures = append(failures, diagnostic)
+		case "test_summary":
+			if msg.Summary == nil {
+				continue
+			}
+			// a module without test files reports a pending status
+			reply = &TestReply{
+				Passed:       msg.Summary.Status == "pass" || msg.Summary.Status == "pending",
+				PassedCount:  msg.Summary.Passed,
+				FailedCount:  msg.Summary.Failed,
+				ErroredCount: msg.Summary.Errored,
+				SkippedCount: msg.Summary.Skipped,
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if reply == nil {
+		return nil, fmt.Errorf("terraform test did not report a summary")
+	}
+
+	reply.Failures = failures
+	reply.Message = fmt.Sprintf("%d passed, %d failed, %d errored, %d skipped",
+		reply.PassedCount, reply.FailedCount, reply.ErroredCount, reply.SkippedCount)
+
+	return reply, nil
+}
+
+func formatDiagnostic(diagnostic tfjson.Diagnostic) string {
+	msg := diagnostic.Summary
+	if diagnostic.Detail != "" {
+		msg = fmt.Sprintf("%s: %s", msg, diagnostic.Detail)
+	}
+	if diagnostic.Range != nil {
+		msg = fmt.Sprintf("%s (%s line %d)", msg, diagnostic.Range.Filename, diagnostic.Range.Start.Line)
+	}
+
+	return msg
+}
@@ -0,0 +1,36 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTestOutput(t *testing.T) {
+	output := `{"@level":"info","@message":"Terraform 1.9.0","type":"version","terraform":"1.9.0","ui":"1.2"}
+{"@level":"info","@message":"Found 1 file and 2 run blocks","type":"test_abstract","test_abstract":{"main.tftest.hcl":["defaults","names"]}}
+{"@level":"info","@message":"main.tftest.hcl... in progress","@testfile":"main.tftest.hcl","type":"test_file","test_file":{"path":"main.tftest.hcl","progress":"starting"}}
+{"@level":"info","@message":"  \"defaults\"... pass","@testfile":"main.tftest.hcl","@testrun":"defaults","type":"test_run","test_run":{"path":"main.tftest.hcl","run":"defaults","progress":"complete","status":"pass"}}
+{"@level":"info","@message":"  \"names\"... fail","@testfile":"main.tftest.hcl","@testrun":"names","type":"test_run","test_run":{"path":"main.tftest.hcl","run":"names","progress":"complete","status":"fail"}}
+{"@level":"error","@message":"Error: Test assertion failed","@testfile":"main.tftest.hcl","@testrun":"names","type":"diagnostic","diagnostic":{"severity":"error","summary":"Test assertion failed","detail":"unexpected name","range":{"filename":"main.tftest.hcl","start":{"line":12,"column":5,"byte":0},"end":{"line":12,"column":30,"byte":0}}}}
+{"@level":"info","@message":"Failure! 1 passed, 1 failed.","type":"test_summary","test_summary":{"status":"fail","passed":1,"failed":1,"errored":0,"skipped":0}}
+`
+
+	reply, err := parseTestOutput([]byte(output))
+	assert.NoError(t, err)
+	assert.False(t, reply.Passed)
+	assert.Equal(t, int32(1), reply.PassedCount)
+	assert.Equal(t, int32(1), reply.FailedCount)
+	assert.Equal(t, "1 passed, 1 failed, 0 errored, 0 skipped", reply.Message)
+	assert.Equal(t, []string{
+		`main.tftest.hcl, run "names": fail`,
+		`main.tftest.hcl, run "names": Test assertion failed: unexpected name (main.tftest.hcl line 12)`,
+	}, reply.Failures)
+
+	reply, err = parseTestOutput([]byte(`{"@level":"info","@message":"Success! 0 passed, 0 failed.","type":"test_summary","test_summary":{"status":"pending","passed":0,"failed":0,"errored":0,"skipped":0}}`))
+	assert.NoError(t, err)
+	assert.True(t, reply.Passed)
+
+	_, err = parseTestOutput([]byte("Error: Failed to load plugin schemas\n"))
+	assert.Error(t, err)
+}
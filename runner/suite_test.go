@@ -192,7 +192,7 @@ func findKubeConfig(e *envtest.Environment) (string, error) {
 }
 
 func startGRPCServer(server *runner.TerraformRunnerServer, addr string) error {
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(server.RedactInterceptor))
 
 	// local runner, use the same client as the manager
 	runner.RegisterRunnerServer(grpcServer, server)
@@ -0,0 +1,104 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrVarsNotFound is returned by a VarsSource when the variables it points at
+// do not exist. Optional references ignore it.
+var ErrVarsNotFound = errors.New("variables not found")
+
+// VarsSource provides variables from outside of the cluster, so they do not
+// have to be mirrored into Secrets.
+type VarsSource interface {
+	// Vars returns the variables, JSON encoded and keyed by name.
+	Vars(ctx context.Context) (map[string]*apiextensionsv1.JSON, error)
+}
+
+// VarsSourceFactory creates the VarsSource of a varsFrom reference. The client
+// reads the Secrets the reference needs from the namespace of the Terraform object.
+type VarsSourceFactory func(ctx context.Context, c client.Client, namespace string, ref infrav1.VarsReference) (VarsSource, error)
+
+var (
+	varsSourcesMu sync.RWMutex
+	varsSources   = map[string]VarsSourceFactory{}
+)
+
+// RegisterVarsSource makes a varsFrom kind available to GenerateVarsForTF.
+func RegisterVarsSource(kind string, factory VarsSourceFactory) {
+	varsSourcesMu.Lock()
+	defer varsSourcesMu.Unlock()
+	varsSources[kind] = factory
+}
+
+func lookupVarsSource(kind string) (VarsSourceFactory, bool) {
+	varsSourcesMu.RLock()
+	defer varsSourcesMu.RUnlock()
+	factory, ok := varsSources[kind]
+	return factory, ok
+}
+
+func init() {
+	RegisterVarsSource(infrav1.VaultVarsSourceKind, newVaultVarsSource)
+	RegisterVarsSource(infrav1.HTTPVarsSourceKind, newHTTPVarsSource)
+}
+
+// varsFromSource fetches the variables of a reference served by a registered
+// VarsSource, selected and renamed by its VarsKeys.
+func varsFromSource(ctx context.Context, c client.Client, namespace string, ref infrav1.VarsReference) (map[string]*apiextensionsv1.JSON, error) {
+	factory, ok := lookupVarsSource(ref.Kind)
+	if !ok {
+		return nil, fmt.Errorf("unsupported varsFrom kind %q", ref.Kind)
+	}
+
+	source, err := factory(ctx, c, namespace, ref)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure %s %s: %w", ref.Kind, ref.Name, err)
+	}
+
+	all, err := source.Vars(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read variables from %s %s: %w", ref.Kind, ref.Name, err)
+	}
+
+	// if VarsKeys is null, use all
+	if ref.VarsKeys == nil {
+		return all, nil
+	}
+
+	vars := map[string]*apiextensionsv1.JSON{}
+	for _, pattern := range ref.VarsKeys {
+		oldKey, newKey, err := parseRenamePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		val, ok := all[oldKey]
+		if !ok {
+			return nil, fmt.Errorf("key %q not found in %s %s", oldKey, ref.Kind, ref.Name)
+		}
+		vars[newKey] = val
+	}
+	return vars, nil
+}
+
+// encodeVars encodes the fields of a JSON object as variables.
+func encodeVars(fields map[string]any) (map[string]*apiextensionsv1.JSON, error) {
+	vars := map[string]*apiextensionsv1.JSON{}
+	for key, value := range fields {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode key %s with error: %w", key, err)
+		}
+		vars[key] = &apiextensionsv1.JSON{Raw: raw}
+	}
+	return vars, nil
+}
@@ -0,0 +1,116 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const httpVarsDefaultTimeout = 30 * time.Second
+
+type httpVarsSource struct {
+	spec       infrav1.HTTPVarsSource
+	headers    http.Header
+	httpClient *http.Client
+}
+
+func newHTTPVarsSource(ctx context.Context, c client.Client, namespace string, ref infrav1.VarsReference) (VarsSource, error) {
+	if ref.HTTP == nil {
+		return nil, fmt.Errorf("the http field is required for the %s kind", ref.Kind)
+	}
+
+	timeout := httpVarsDefaultTimeout
+	if ref.HTTP.Timeout != nil {
+		timeout = ref.HTTP.Timeout.Duration
+	}
+
+	source := &httpVarsSource{
+		spec:       *ref.HTTP,
+		headers:    http.Header{},
+		httpClient: &http.Client{Timeout: timeout},
+	}
+
+	if ref.HTTP.HeadersSecretRef != nil {
+		var secret v1.Secret
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.HTTP.HeadersSecretRef.Name}, &secret); err != nil {
+			return nil, fmt.Errorf("unable to get the headers secret: %w", err)
+		}
+		for name, value := range secret.Data {
+			source.headers.Set(name, strings.TrimSpace(string(value)))
+		}
+	}
+
+	return source, nil
+}
+
+func (s *httpVarsSource) Vars(ctx context.Context) (map[string]*apiextensionsv1.JSON, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.spec.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = s.headers.Clone()
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrVarsNotFound, resp.Status)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("unable to decode the document: %w", err)
+	}
+
+	fields, err := selectField(document, s.spec.Field)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeVars(fields)
+}
+
+// selectField walks a dot separated path into a JSON document, and returns
+// the object found there.
+func selectField(document any, field string) (map[string]any, error) {
+	current := document
+	if field != "" {
+		for _, name := range strings.Split(field, ".") {
+			object, ok := current.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("field %q: %q is not in an object", field, name)
+			}
+			if current, ok = object[name]; !ok {
+				return nil, fmt.Errorf("field %q: %q not found", field, name)
+			}
+		}
+	}
+
+	object, ok := current.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("field %q is not an object", field)
+	}
+	return object, nil
+}
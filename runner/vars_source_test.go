@@ -0,0 +1,188 @@
+package runner
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newVaultStandIn serves the parts of the Vault API used by the Vault
+// source: the Kubernetes login, and the KV v1 and v2 reads.
+func newVaultStandIn(t *testing.T) *httptest.Server {
+	writeJSON := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/auth/kubernetes/login", func(w http.ResponseWriter, r *http.Request) {
+		var login map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&login)
+		if login["role"] != "app" || login["jwt"] != "service-account-jwt" {
+			w.WriteHeader(http.StatusForbidden)
+			writeJSON(w, map[string]any{"errors": []string{"permission denied"}})
+			return
+		}
+		writeJSON(w, map[string]any{"auth": map[string]any{"client_token": "login-token"}})
+	})
+	mux.HandleFunc("GET /v1/secret/data/app", func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("X-Vault-Token"); token != "root" && token != "login-token" {
+			w.WriteHeader(http.StatusForbidden)
+			writeJSON(w, map[string]any{"errors": []string{"permission denied"}})
+			return
+		}
+		writeJSON(w, map[string]any{"data": map[string]any{
+			"data":     map[string]any{"password": "s3cr3t", "replicas": 3},
+			"metadata": map[string]any{"version": 1},
+		}})
+	})
+	mux.HandleFunc("GET /v1/kv/app", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"data": map[string]any{"password": "v1-s3cr3t"}})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestVaultVarsSource(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	vault := newVaultStandIn(t)
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	g.Expect(os.WriteFile(tokenPath, []byte("service-account-jwt\n"), 0600)).To(Succeed())
+	defaultTokenPath := serviceAccountTokenPath
+	serviceAccountTokenPath = tokenPath
+	t.Cleanup(func() { serviceAccountTokenPath = defaultTokenPath })
+
+	c := fake.NewClientBuilder().WithObjects(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "flux-system"},
+		Data:       map[string][]byte{"token": []byte("root")},
+	}).Build()
+
+	expected := map[string]*apiextensionsv1.JSON{
+		"password": {Raw: []byte(`"s3cr3t"`)},
+		"replicas": {Raw: []byte(`3`)},
+	}
+
+	// token auth
+	vars, err := varsFromSource(t.Context(), c, "flux-system", infrav1.VarsReference{
+		Kind: infrav1.VaultVarsSourceKind,
+		Name: "app",
+		Vault: &infrav1.VaultVarsSource{
+			Address: vault.URL,
+			Path:    "app",
+			Auth:    infrav1.VaultAuth{TokenSecretRef: &meta.SecretKeyReference{Name: "vault-token"}},
+		},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(vars).To(Equal(expected))
+
+	// Kubernetes auth, with a rename
+	vars, err = varsFromSource(t.Context(), c, "flux-system", infrav1.VarsReference{
+		Kind:     infrav1.VaultVarsSourceKind,
+		Name:     "app",
+		VarsKeys: []string{"password:db_password"},
+		Vault: &infrav1.VaultVarsSource{
+			Address: vault.URL,
+			Path:    "app",
+			Auth:    infrav1.VaultAuth{Kubernetes: &infrav1.VaultKubernetesAuth{Role: "app"}},
+		},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(vars).To(Equal(map[string]*apiextensionsv1.JSON{"db_password": {Raw: []byte(`"s3cr3t"`)}}))
+
+	// KV v1
+	vars, err = varsFromSource(t.Context(), c, "flux-system", infrav1.VarsReference{
+		Kind: infrav1.VaultVarsSourceKind,
+		Name: "app",
+		Vault: &infrav1.VaultVarsSource{
+			Address:   vault.URL,
+			Mount:     "kv",
+			Path:      "app",
+			KVVersion: 1,
+			Auth:      infrav1.VaultAuth{TokenSecretRef: &meta.SecretKeyReference{Name: "vault-token"}},
+		},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(vars).To(Equal(map[string]*apiextensionsv1.JSON{"password": {Raw: []byte(`"v1-s3cr3t"`)}}))
+
+	// a missing secret is reported as not found
+	_, err = varsFromSource(t.Context(), c, "flux-system", infrav1.VarsReference{
+		Kind: infrav1.VaultVarsSourceKind,
+		Name: "missing",
+		Vault: &infrav1.VaultVarsSource{
+			Address: vault.URL,
+			Path:    "missing",
+			Auth:    infrav1.VaultAuth{TokenSecretRef: &meta.SecretKeyReference{Name: "vault-token"}},
+		},
+	})
+	g.Expect(err).To(MatchError(ErrVarsNotFound))
+
+	// a rejected login is an error
+	_, err = varsFromSource(t.Context(), c, "flux-system", infrav1.VarsReference{
+		Kind: infrav1.VaultVarsSourceKind,
+		Name: "app",
+		Vault: &infrav1.VaultVarsSource{
+			Address: vault.URL,
+			Path:    "app",
+			Auth:    infrav1.VaultAuth{Kubernetes: &infrav1.VaultKubernetesAuth{Role: "other"}},
+		},
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("permission denied")))
+}
+
+func TestHTTPVarsSource(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0ken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"environments":{"staging":{"region":"eu-west-1","zones":["a","b"]}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c := fake.NewClientBuilder().WithObjects(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "config-api", Namespace: "flux-system"},
+		Data:       map[string][]byte{"Authorization": []byte("Bearer t0ken")},
+	}).Build()
+
+	ref := infrav1.VarsReference{
+		Kind: infrav1.HTTPVarsSourceKind,
+		Name: "config-api",
+		HTTP: &infrav1.HTTPVarsSource{
+			URL:              server.URL,
+			HeadersSecretRef: &meta.LocalObjectReference{Name: "config-api"},
+			Field:            "environments.staging",
+		},
+	}
+
+	vars, err := varsFromSource(t.Context(), c, "flux-system", ref)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(vars).To(Equal(map[string]*apiextensionsv1.JSON{
+		"region": {Raw: []byte(`"eu-west-1"`)},
+		"zones":  {Raw: []byte(`["a","b"]`)},
+	}))
+
+	ref.HTTP.Field = "environments.production"
+	_, err = varsFromSource(t.Context(), c, "flux-system", ref)
+	g.Expect(err).To(HaveOccurred())
+
+	ref.HTTP.HeadersSecretRef = nil
+	_, err = varsFromSource(t.Context(), c, "flux-system", ref)
+	g.Expect(err).To(MatchError(ContainSubstring("401")))
+}
@@ -0,0 +1,189 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// serviceAccountTokenPath is where the runner pod finds its service account
+// token, used by the Vault Kubernetes auth method.
+var serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+const vaultRequestTimeout = 30 * time.Second
+
+type vaultVarsSource struct {
+	spec       infrav1.VaultVarsSource
+	token      string
+	httpClient *http.Client
+}
+
+func newVaultVarsSource(ctx context.Context, c client.Client, namespace string, ref infrav1.VarsReference) (VarsSource, error) {
+	if ref.Vault == nil {
+		return nil, fmt.Errorf("the vault field is required for the %s kind", ref.Kind)
+	}
+
+	source := &vaultVarsSource{
+		spec:       *ref.Vault,
+		httpClient: &http.Client{Timeout: vaultRequestTimeout},
+	}
+	if source.spec.Mount == "" {
+		source.spec.Mount = "secret"
+	}
+	if source.spec.KVVersion == 0 {
+		source.spec.KVVersion = 2
+	}
+
+	auth := ref.Vault.Auth
+	switch {
+	case auth.TokenSecretRef != nil && auth.Kubernetes != nil:
+		return nil, fmt.Errorf("only one of tokenSecretRef and kubernetes can be set")
+	case auth.TokenSecretRef != nil:
+		key := auth.TokenSecretRef.Key
+		if key == "" {
+			key = "token"
+		}
+
+		var secret v1.Secret
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: auth.TokenSecretRef.Name}, &secret); err != nil {
+			return nil, fmt.Errorf("unable to get the Vault token secret: %w", err)
+		}
+		token, ok := secret.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("key %q not found in the Vault token secret %s", key, auth.TokenSecretRef.Name)
+		}
+		source.token = strings.TrimSpace(string(token))
+	case auth.Kubernetes != nil:
+		// the login is deferred to Vars, the token is only valid for a short while
+	default:
+		return nil, fmt.Errorf("one of tokenSecretRef and kubernetes must be set")
+	}
+
+	return source, nil
+}
+
+func (s *vaultVarsSource) Vars(ctx context.Context) (map[string]*apiextensionsv1.JSON, error) {
+	if s.spec.Auth.Kubernetes != nil {
+		token, err := s.kubernetesLogin(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.token = token
+	}
+
+	path := strings.Trim(s.spec.Path, "/")
+	if s.spec.KVVersion == 2 {
+		path = "data/" + path
+	}
+
+	var secret struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := s.do(ctx, http.MethodGet, strings.Trim(s.spec.Mount, "/")+"/"+path, nil, &secret); err != nil {
+		return nil, err
+	}
+
+	fields := secret.Data
+	if s.spec.KVVersion == 2 {
+		// KV v2 nests the secret with its metadata
+		data, ok := secret.Data["data"].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: secret %s has no data, it may have been deleted", ErrVarsNotFound, s.spec.Path)
+		}
+		fields = data
+	}
+
+	return encodeVars(fields)
+}
+
+func (s *vaultVarsSource) kubernetesLogin(ctx context.Context) (string, error) {
+	jwt, err := os.ReadFile(serviceAccountTokenPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the service account token: %w", err)
+	}
+
+	mount := s.spec.Auth.Kubernetes.Mount
+	if mount == "" {
+		mount = "kubernetes"
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"role": s.spec.Auth.Kubernetes.Role,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var login struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	if err := s.do(ctx, http.MethodPost, "auth/"+strings.Trim(mount, "/")+"/login", body, &login); err != nil {
+		return "", fmt.Errorf("unable to log in to Vault: %w", err)
+	}
+	if login.Auth.ClientToken == "" {
+		return "", fmt.Errorf("unable to log in to Vault: no client token returned")
+	}
+
+	return login.Auth.ClientToken, nil
+}
+
+func (s *vaultVarsSource) do(ctx context.Context, method, path string, body []byte, out any) error {
+	url := strings.TrimRight(s.spec.Address, "/") + "/v1/" + path
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if s.token != "" {
+		req.Header.Set("X-Vault-Token", s.token)
+	}
+	if s.spec.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.spec.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s %s", ErrVarsNotFound, method, path)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		if json.Unmarshal(data, &vaultErr) == nil && len(vaultErr.Errors) > 0 {
+			return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.Join(vaultErr.Errors, ", "))
+		}
+		return fmt.Errorf("%s %s: %s", method, path, resp.Status)
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("unable to decode the response of %s %s: %w", method, path, err)
+	}
+	return nil
+}
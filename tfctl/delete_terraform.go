@@ -7,10 +7,13 @@ import (
 
 	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// DeleteTerraform deletes the terraform resource from the cluster
-func (c *CLI) DeleteTerraform(out io.Writer, resource string) error {
+// DeleteTerraform deletes the terraform resource from the cluster. With
+// cascade, the resource is annotated first so that the controller deletes its
+// dependants, in reverse dependency order, before the resource itself.
+func (c *CLI) DeleteTerraform(out io.Writer, resource string, cascade bool) error {
 	key := types.NamespacedName{
 		Name:      resource,
 		Namespace: c.namespace,
@@ -21,11 +24,29 @@ func (c *CLI) DeleteTerraform(out io.Writer, resource string) error {
 		return err
 	}
 
+	if cascade {
+		patch := client.MergeFrom(terraform.DeepCopy())
+		annotations := terraform.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[infrav1.CascadeDeletionAnnotation] = "true"
+		terraform.SetAnnotations(annotations)
+		if err := c.client.Patch(context.TODO(), terraform, patch); err != nil {
+			return err
+		}
+	}
+
 	if err := c.client.Delete(context.TODO(), terraform); err != nil {
 		return err
 	}
 
-	fmt.Fprintf(out, " deleted Terraform resource %s/%s\n", c.namespace, resource)
+	if cascade {
+		fmt.Fprintf(out, " deleting Terraform resource %s/%s and its dependants\n", c.namespace, resource)
+		return nil
+	}
+
+	fmt.Fprintf(out, " deleted Terraform resource %s/%s\n", c.namespace, resource)
 
 	return nil
 }
@@ -0,0 +1,39 @@
+package tfctl
+
+import (
+	"bytes"
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDeleteTerraformCascade(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	network := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{
+		Name:       "network",
+		Namespace:  "default",
+		Finalizers: []string{infrav1.TerraformFinalizer},
+	}}
+	cli := &CLI{
+		client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(network).Build(),
+		namespace: "default",
+	}
+
+	var out bytes.Buffer
+	g.Expect(cli.DeleteTerraform(&out, "network", true)).To(Succeed())
+	g.Expect(out.String()).To(Equal(" deleting Terraform resource default/network and its dependants\n"))
+
+	// the finalizer keeps the object until the controller has deleted its dependants
+	g.Expect(cli.client.Get(t.Context(), client.ObjectKeyFromObject(network), network)).To(Succeed())
+	g.Expect(network.DeletionTimestamp.IsZero()).To(BeFalse())
+	g.Expect(network.Annotations).To(HaveKeyWithValue(infrav1.CascadeDeletionAnnotation, "true"))
+}
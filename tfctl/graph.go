@@ -0,0 +1,156 @@
+package tfctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/flux-iac/tofu-controller/api/graph"
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	GraphFormatDOT     = "dot"
+	GraphFormatMermaid = "mermaid"
+)
+
+// readiness of a node of the dependency graph, with its colour
+type readiness struct {
+	class string
+	fill  string
+}
+
+var (
+	nodeReady    = readiness{class: "ready", fill: "#c3e6cb"}
+	nodeNotReady = readiness{class: "notready", fill: "#f5c6cb"}
+	nodeUnknown  = readiness{class: "unknown", fill: "#ffeeba"}
+	nodeMissing  = readiness{class: "missing", fill: "#e2e3e5"}
+	// a dependency in another namespace than the listed one, which may exist
+	nodeNotListed = readiness{class: "notlisted", fill: "#ffffff"}
+)
+
+const cycleColour = "#dc3545"
+
+// Graph prints the dependency graph of the Terraform resources, in DOT or
+// Mermaid format. The edges go from a dependency to its dependants, and the
+// nodes are coloured by readiness.
+func (c *CLI) Graph(ctx context.Context, out io.Writer, format string, allNamespaces bool) error {
+	var opts []client.ListOption
+	namespace := ""
+	if !allNamespaces {
+		namespace = c.namespace
+		opts = append(opts, client.InNamespace(namespace))
+	}
+
+	terraformList := &infrav1.TerraformList{}
+	if err := c.client.List(ctx, terraformList, opts...); err != nil {
+		return err
+	}
+
+	return renderGraph(out, graph.New(terraformList.Items), format, namespace)
+}
+
+// renderGraph renders the graph of the objects listed in the namespace, or in
+// all namespaces when it is empty.
+func renderGraph(out io.Writer, g *graph.Graph, format, namespace string) error {
+	switch format {
+	case GraphFormatDOT:
+		renderDOT(out, g, namespace)
+	case GraphFormatMermaid:
+		renderMermaid(out, g, namespace)
+	default:
+		return fmt.Errorf("unknown graph format %q, must be %s or %s", format, GraphFormatDOT, GraphFormatMermaid)
+	}
+	return nil
+}
+
+func nodeReadiness(g *graph.Graph, node types.NamespacedName, namespace string) readiness {
+	terraform := g.Terraform(node)
+	if terraform == nil {
+		if namespace != "" && node.Namespace != namespace {
+			return nodeNotListed
+		}
+		return nodeMissing
+	}
+	for _, condition := range terraform.Status.Conditions {
+		if condition.Type != meta.ReadyCondition {
+			continue
+		}
+		switch condition.Status {
+		case metav1.ConditionTrue:
+			return nodeReady
+		case metav1.ConditionFalse:
+			return nodeNotReady
+		}
+	}
+	return nodeUnknown
+}
+
+func renderDOT(out io.Writer, g *graph.Graph, namespace string) {
+	fmt.Fprintln(out, "digraph terraform {")
+	fmt.Fprintln(out, "  rankdir=LR;")
+	fmt.Fprintln(out, `  node [shape=box, style="rounded,filled"];`)
+
+	for _, node := range g.Nodes() {
+		r := nodeReadiness(g, node, namespace)
+		style := ""
+		if r == nodeMissing || r == nodeNotListed {
+			style = `, style="rounded,filled,dashed"`
+		}
+		fmt.Fprintf(out, "  %q [fillcolor=%q%s];\n", node.String(), r.fill, style)
+	}
+
+	for _, node := range g.Nodes() {
+		for _, dependency := range g.DependsOn(node) {
+			attrs := ""
+			if g.InCycle(node, dependency) {
+				attrs = fmt.Sprintf(" [color=%q, penwidth=2]", cycleColour)
+			}
+			fmt.Fprintf(out, "  %q -> %q%s;\n", dependency.String(), node.String(), attrs)
+		}
+	}
+
+	fmt.Fprintln(out, "}")
+}
+
+func renderMermaid(out io.Writer, g *graph.Graph, namespace string) {
+	fmt.Fprintln(out, "graph LR")
+
+	nodes := g.Nodes()
+	ids := make(map[types.NamespacedName]string, len(nodes))
+	classes := map[string][]string{}
+	for i, node := range nodes {
+		ids[node] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(out, "  %s[%q]\n", ids[node], node.String())
+		r := nodeReadiness(g, node, namespace)
+		classes[r.class] = append(classes[r.class], ids[node])
+	}
+
+	var link int
+	var cycleLinks []string
+	for _, node := range nodes {
+		for _, dependency := range g.DependsOn(node) {
+			fmt.Fprintf(out, "  %s --> %s\n", ids[dependency], ids[node])
+			if g.InCycle(node, dependency) {
+				cycleLinks = append(cycleLinks, fmt.Sprint(link))
+			}
+			link++
+		}
+	}
+
+	for _, r := range []readiness{nodeReady, nodeNotReady, nodeUnknown, nodeMissing, nodeNotListed} {
+		if len(classes[r.class]) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "  classDef %s fill:%s\n", r.class, r.fill)
+		fmt.Fprintf(out, "  class %s %s\n", strings.Join(classes[r.class], ","), r.class)
+	}
+	if len(cycleLinks) > 0 {
+		fmt.Fprintf(out, "  linkStyle %s stroke:%s,stroke-width:2px\n", strings.Join(cycleLinks, ","), cycleColour)
+	}
+}
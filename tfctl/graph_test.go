@@ -0,0 +1,93 @@
+package tfctl
+
+import (
+	"bytes"
+	"testing"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGraph(t *testing.T) {
+	g := NewWithT(t)
+
+	ready := []metav1.Condition{{Type: meta.ReadyCondition, Status: metav1.ConditionTrue}}
+	notReady := []metav1.Condition{{Type: meta.ReadyCondition, Status: metav1.ConditionFalse}}
+
+	objects := []client.Object{
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "network", Namespace: "default"},
+			Status:     infrav1.TerraformStatus{Conditions: ready},
+		},
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec: infrav1.TerraformSpec{DependsOn: []meta.NamespacedObjectReference{
+				{Name: "network"}, {Name: "cache"}, {Name: "dns", Namespace: "platform"},
+			}},
+			Status: infrav1.TerraformStatus{Conditions: notReady},
+		},
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "default"},
+			Spec: infrav1.TerraformSpec{DependsOn: []meta.NamespacedObjectReference{
+				{Name: "app"},
+			}},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	cli := &CLI{
+		client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		namespace: "default",
+	}
+
+	var out bytes.Buffer
+	g.Expect(cli.Graph(t.Context(), &out, GraphFormatDOT, false)).To(Succeed())
+	g.Expect(out.String()).To(Equal(`digraph terraform {
+  rankdir=LR;
+  node [shape=box, style="rounded,filled"];
+  "default/app" [fillcolor="#f5c6cb"];
+  "default/cache" [fillcolor="#ffeeba"];
+  "default/network" [fillcolor="#c3e6cb"];
+  "platform/dns" [fillcolor="#ffffff", style="rounded,filled,dashed"];
+  "default/network" -> "default/app";
+  "default/cache" -> "default/app" [color="#dc3545", penwidth=2];
+  "platform/dns" -> "default/app";
+  "default/app" -> "default/cache" [color="#dc3545", penwidth=2];
+}
+`))
+
+	out.Reset()
+	g.Expect(cli.Graph(t.Context(), &out, GraphFormatMermaid, false)).To(Succeed())
+	g.Expect(out.String()).To(Equal(`graph LR
+  n0["default/app"]
+  n1["default/cache"]
+  n2["default/network"]
+  n3["platform/dns"]
+  n2 --> n0
+  n1 --> n0
+  n3 --> n0
+  n0 --> n1
+  classDef ready fill:#c3e6cb
+  class n2 ready
+  classDef notready fill:#f5c6cb
+  class n0 notready
+  classDef unknown fill:#ffeeba
+  class n1 unknown
+  classDef notlisted fill:#ffffff
+  class n3 notlisted
+  linkStyle 1,3 stroke:#dc3545,stroke-width:2px
+`))
+
+	// the dependency in another namespace is only missing when all namespaces are listed
+	out.Reset()
+	g.Expect(cli.Graph(t.Context(), &out, GraphFormatDOT, true)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring(`  "platform/dns" [fillcolor="#e2e3e5", style="rounded,filled,dashed"];`))
+
+	g.Expect(cli.Graph(t.Context(), &out, "svg", false)).To(MatchError(ContainSubstring(`unknown graph format "svg"`)))
+}
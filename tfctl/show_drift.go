@@ -0,0 +1,101 @@
+package tfctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/flux-iac/tofu-controller/api/drift"
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	DriftFormatText = "text"
+	DriftFormatJSON = "json"
+)
+
+// ShowDrift displays the report of the drift found by the last drift
+// detection of the given Terraform resource.
+func (c *CLI) ShowDrift(ctx context.Context, out io.Writer, resource string, format string) error {
+	if format != DriftFormatText && format != DriftFormatJSON {
+		return fmt.Errorf("unknown drift report format %q, must be %s or %s", format, DriftFormatText, DriftFormatJSON)
+	}
+
+	key := types.NamespacedName{
+		Name:      resource,
+		Namespace: c.namespace,
+	}
+
+	terraform := &infrav1.Terraform{}
+	if err := c.client.Get(ctx, key, terraform); err != nil {
+		return fmt.Errorf("resource %s not found", resource)
+	}
+
+	if terraform.Status.DriftReport == nil {
+		fmt.Fprintln(out, "No drift detected.")
+		return nil
+	}
+
+	secret := &v1.Secret{}
+	if err := c.client.Get(ctx, types.NamespacedName{Namespace: c.namespace, Name: terraform.Status.DriftReport.SecretName}, secret); err != nil {
+		return fmt.Errorf("unable to get the drift report secret %s: %w", terraform.Status.DriftReport.SecretName, err)
+	}
+
+	report, err := drift.NewFromSecret(secret)
+	if err != nil {
+		return err
+	}
+
+	if format == DriftFormatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	fmt.Fprintf(out, "Drift detected at revision %s on %s, %d resources drifted.\n",
+		report.Revision, report.DetectedAt.UTC().Format(time.RFC3339), len(report.Resources))
+
+	for _, resource := range report.Resources {
+		details := strings.Join(resource.Actions, ", ")
+		if resource.DriftAction != "" {
+			details += ", drift policy: " + resource.DriftAction
+		}
+		fmt.Fprintf(out, "\n# %s (%s)\n", resource.Address, details)
+
+		for _, attribute := range resource.Attributes {
+			if report.Truncated {
+				fmt.Fprintf(out, "    %s\n", attribute.Path)
+				continue
+			}
+			fmt.Fprintf(out, "    %s: %s => %s\n", attribute.Path, formatDriftValue(attribute.Before), formatDriftValue(attribute.After))
+		}
+	}
+
+	if len(report.Outputs) > 0 {
+		fmt.Fprintf(out, "\nChanged outputs: %s\n", strings.Join(report.Outputs, ", "))
+	}
+
+	if report.Truncated {
+		fmt.Fprintln(out, "\nThe values of the attributes were left out of the report, too large for its Secret.")
+	}
+
+	return nil
+}
+
+// formatDriftValue formats a value in JSON, apart from the masked values.
+func formatDriftValue(value interface{}) string {
+	if value == drift.SensitiveValue || value == drift.UnknownValue {
+		return value.(string)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
@@ -0,0 +1,88 @@
+package tfctl
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/flux-iac/tofu-controller/api/drift"
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestShowDrift(t *testing.T) {
+	g := NewWithT(t)
+
+	report := &drift.Report{
+		Revision:   "main@sha1:1234",
+		DetectedAt: metav1.NewTime(time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)),
+		Resources: []drift.Resource{
+			{
+				Address:     "aws_db_instance.main",
+				Type:        "aws_db_instance",
+				Actions:     []string{"update"},
+				DriftAction: "require-approval",
+				Attributes: []drift.Attribute{
+					{Path: "allocated_storage", Before: 50, After: 20},
+					{Path: "password", Before: drift.SensitiveValue, After: drift.SensitiveValue, Sensitive: true},
+					{Path: "tags.env", Before: "dev", After: "prod"},
+				},
+			},
+			{Address: "aws_s3_bucket.logs", Type: "aws_s3_bucket", Actions: []string{"create"}},
+		},
+		Outputs: []string{"url"},
+	}
+	data, err := report.Encode()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	cli := &CLI{
+		namespace: "default",
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&infrav1.Terraform{
+				ObjectMeta: metav1.ObjectMeta{Name: "drifted", Namespace: "default"},
+				Status: infrav1.TerraformStatus{
+					DriftReport: &infrav1.DriftReportReference{SecretName: "tfdrift-default-drifted"},
+				},
+			},
+			&infrav1.Terraform{
+				ObjectMeta: metav1.ObjectMeta{Name: "clean", Namespace: "default"},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tfdrift-default-drifted", Namespace: "default"},
+				Data:       map[string][]byte{drift.ReportKey: []byte(data)},
+			},
+		).Build(),
+	}
+
+	out := &bytes.Buffer{}
+	g.Expect(cli.ShowDrift(t.Context(), out, "drifted", DriftFormatText)).To(Succeed())
+	g.Expect(out.String()).To(Equal(`Drift detected at revision main@sha1:1234 on 2026-10-18T10:00:00Z, 2 resources drifted.
+
+# aws_db_instance.main (update, drift policy: require-approval)
+    allocated_storage: 50 => 20
+    password: (sensitive value) => (sensitive value)
+    tags.env: "dev" => "prod"
+
+# aws_s3_bucket.logs (create)
+
+Changed outputs: url
+`))
+
+	out.Reset()
+	g.Expect(cli.ShowDrift(t.Context(), out, "drifted", DriftFormatJSON)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring(`"address": "aws_db_instance.main"`))
+
+	out.Reset()
+	g.Expect(cli.ShowDrift(t.Context(), out, "clean", DriftFormatText)).To(Succeed())
+	g.Expect(out.String()).To(Equal("No drift detected.\n"))
+
+	g.Expect(cli.ShowDrift(t.Context(), out, "drifted", "yaml")).ToNot(Succeed())
+}
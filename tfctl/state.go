@@ -0,0 +1,125 @@
+package tfctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/flux-iac/tofu-controller/api/statebackup"
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ListStateBackups prints the state backups of the given Terraform resource,
+// from the newest to the oldest.
+func (c *CLI) ListStateBackups(ctx context.Context, out io.Writer, resource string) error {
+	key := types.NamespacedName{
+		Name:      resource,
+		Namespace: c.namespace,
+	}
+
+	terraform := &infrav1.Terraform{}
+	if err := c.client.Get(ctx, key, terraform); err != nil {
+		return fmt.Errorf("resource %s not found", resource)
+	}
+
+	backups, err := listStateBackups(ctx, c.client, terraform)
+	if err != nil {
+		return err
+	}
+
+	if len(backups) == 0 {
+		fmt.Fprintln(out, "There are no state backups.")
+		return nil
+	}
+
+	header := []string{"Name", "Reason", "Serial", "Revision", "Created"}
+
+	table := newTablePrinter(out, header)
+
+	for _, backup := range backups {
+		table.Append([]string{
+			backup.Namespace + "/" + backup.Name,
+			backup.Reason,
+			strconv.FormatInt(backup.Serial, 10),
+			backup.Revision,
+			backup.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	table.Render()
+
+	return nil
+}
+
+// RestoreState requests the controller to restore the state of the given
+// Terraform resource from a backup, and requests a reconciliation.
+func (c *CLI) RestoreState(ctx context.Context, out io.Writer, resource, backup string) error {
+	key := types.NamespacedName{
+		Name:      resource,
+		Namespace: c.namespace,
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		terraform := &infrav1.Terraform{}
+		if err := c.client.Get(ctx, key, terraform); err != nil {
+			return err
+		}
+
+		patch := client.MergeFrom(terraform.DeepCopy())
+
+		annotations := terraform.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[infrav1.RestoreStateAnnotation] = backup
+		annotations[meta.ReconcileRequestAnnotation] = time.Now().Format(time.RFC3339Nano)
+		terraform.SetAnnotations(annotations)
+
+		return c.client.Patch(ctx, terraform, patch)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, " Restore of the state from %s requested for %s/%s\n", backup, c.namespace, resource)
+	return nil
+}
+
+func listStateBackups(ctx context.Context, kubeClient client.Client, terraform *infrav1.Terraform) ([]statebackup.Backup, error) {
+	// backups taken before .spec.stateBackup.namespace was set stay in the
+	// namespace of the Terraform resource
+	namespaces := []string{terraform.GetStateBackupNamespace()}
+	if terraform.Namespace != namespaces[0] {
+		namespaces = append(namespaces, terraform.Namespace)
+	}
+
+	var backups []statebackup.Backup
+	for _, namespace := range namespaces {
+		secrets := &v1.SecretList{}
+		if err := kubeClient.List(ctx, secrets,
+			client.InNamespace(namespace),
+			client.MatchingLabels(statebackup.MatchingLabels(terraform.Name, terraform.WorkspaceName())),
+		); err != nil {
+			return nil, fmt.Errorf("unable to list state backups: %s", err)
+		}
+
+		for _, secret := range secrets.Items {
+			backup, err := statebackup.FromSecret(secret)
+			if err != nil {
+				continue
+			}
+			backups = append(backups, *backup)
+		}
+	}
+
+	statebackup.Sort(backups)
+
+	return backups, nil
+}
@@ -0,0 +1,150 @@
+package tfctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	infrav1 "github.com/flux-iac/tofu-controller/api/v1alpha2"
+	"github.com/fluxcd/pkg/apis/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// StateList lists the resources in the state of the given Terraform resource,
+// optionally filtered by addresses.
+func (c *CLI) StateList(ctx context.Context, out io.Writer, resource string, addresses []string) error {
+	result, err := c.runStateOperation(ctx, resource, infrav1.StateOperation{
+		Type:      infrav1.StateOperationList,
+		Addresses: addresses,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, address := range result.Addresses {
+		fmt.Fprintln(out, address)
+	}
+	return nil
+}
+
+// StateMove moves a resource in the state of the given Terraform resource.
+func (c *CLI) StateMove(ctx context.Context, out io.Writer, resource, source, destination string) error {
+	result, err := c.runStateOperation(ctx, resource, infrav1.StateOperation{
+		Type:        infrav1.StateOperationMove,
+		Source:      source,
+		Destination: destination,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, " %s\n", result.Message)
+	return nil
+}
+
+// StateRemove removes resources from the state of the given Terraform resource.
+func (c *CLI) StateRemove(ctx context.Context, out io.Writer, resource string, addresses []string) error {
+	result, err := c.runStateOperation(ctx, resource, infrav1.StateOperation{
+		Type:      infrav1.StateOperationRemove,
+		Addresses: addresses,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, " %s\n", result.Message)
+	return nil
+}
+
+// StateImport imports an existing resource into the state of the given Terraform resource.
+func (c *CLI) StateImport(ctx context.Context, out io.Writer, resource, address, id string) error {
+	result, err := c.runStateOperation(ctx, resource, infrav1.StateOperation{
+		Type:       infrav1.StateOperationImport,
+		Address:    address,
+		ResourceID: id,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, " %s\n", result.Message)
+	return nil
+}
+
+// runStateOperation requests the controller to run the state operation with the
+// runner of the Terraform resource, and waits for its result.
+func (c *CLI) runStateOperation(ctx context.Context, resource string, op infrav1.StateOperation) (*infrav1.StateOperationStatus, error) {
+	key := types.NamespacedName{
+		Name:      resource,
+		Namespace: c.namespace,
+	}
+
+	op.ID = rand.String(10)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(op)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requestStateOperation(ctx, c.client, key, string(value)); err != nil {
+		return nil, err
+	}
+
+	var result infrav1.StateOperationStatus
+	if err := wait.PollUntilContextTimeout(ctx, 2*time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
+		terraform := &infrav1.Terraform{}
+		if err := c.client.Get(ctx, key, terraform); err != nil {
+			return false, err
+		}
+
+		status := terraform.Status.StateOperation
+		if status.Operation == nil || status.Operation.ID != op.ID {
+			return false, nil
+		}
+
+		result = status
+		return true, nil
+	}); err != nil {
+		return nil, fmt.Errorf("waiting for the state operation %s: %w", op.ID, err)
+	}
+
+	if !result.Succeeded {
+		return nil, fmt.Errorf("%s failed: %s", op.String(), result.Message)
+	}
+
+	return &result, nil
+}
+
+func requestStateOperation(ctx context.Context, kubeClient client.Client, namespacedName types.NamespacedName, op string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		terraform := &infrav1.Terraform{}
+		if err := kubeClient.Get(ctx, namespacedName, terraform); err != nil {
+			return err
+		}
+
+		if _, ok := terraform.GetAnnotations()[infrav1.StateOperationAnnotation]; ok {
+			return fmt.Errorf("another state operation is in progress for %s", namespacedName)
+		}
+
+		patch := client.MergeFrom(terraform.DeepCopy())
+
+		annotations := terraform.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[infrav1.StateOperationAnnotation] = op
+		annotations[meta.ReconcileRequestAnnotation] = time.Now().Format(time.RFC3339Nano)
+		terraform.SetAnnotations(annotations)
+
+		return kubeClient.Patch(ctx, terraform, patch)
+	})
+}